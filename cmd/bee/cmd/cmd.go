@@ -16,6 +16,8 @@ import (
 	chaincfg "github.com/ethersphere/bee/v2/pkg/config"
 	"github.com/ethersphere/bee/v2/pkg/log"
 	"github.com/ethersphere/bee/v2/pkg/node"
+	"github.com/ethersphere/bee/v2/pkg/pss"
+	"github.com/ethersphere/bee/v2/pkg/storer"
 	"github.com/ethersphere/bee/v2/pkg/swarm"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -23,7 +25,8 @@ import (
 
 const (
 	optionNameDataDir                      = "data-dir"
-	optionNameCacheCapacity                = "cache-capacity"
+	optionNameCacheCapacity                = "cache-capacity" // deprecated: use cache-capacity-bytes instead
+	optionNameCacheCapacityBytes           = "cache-capacity-bytes"
 	optionNameDBOpenFilesLimit             = "db-open-files-limit"
 	optionNameDBBlockCacheCapacity         = "db-block-cache-capacity"
 	optionNameDBWriteBufferSize            = "db-write-buffer-size"
@@ -82,6 +85,14 @@ const (
 	optionNameTransactionDebugMode         = "transaction-debug-mode"
 	optionMinimumStorageRadius             = "minimum-storage-radius"
 	optionReserveCapacityDoubling          = "reserve-capacity-doubling"
+	optionNameRestrictedAPI                = "restricted"
+	optionNameAdminPasswordHash            = "admin-password"
+	optionNamePssInboxCapacity             = "pss-inbox-capacity"
+	optionNamePssInboxTTL                  = "pss-inbox-ttl"
+	optionNameNameRegistryTLD              = "name-registry-tld"
+	optionNameNameRegistryOwner            = "name-registry-owner"
+	optionNamePinQuota                     = "pin-quota"
+	optionNameCacheEvictionPolicy          = "cache-eviction-policy"
 )
 
 // nolint:gochecknoinits
@@ -235,7 +246,10 @@ func (c *command) setHomeDir() (err error) {
 
 func (c *command) setAllFlags(cmd *cobra.Command) {
 	cmd.Flags().String(optionNameDataDir, filepath.Join(c.homeDir, ".bee"), "data directory")
-	cmd.Flags().Uint64(optionNameCacheCapacity, 1_000_000, fmt.Sprintf("cache capacity in chunks, multiply by %d to get approximate capacity in bytes", swarm.ChunkSize))
+	cmd.Flags().Uint64(optionNameCacheCapacity, 1_000_000, fmt.Sprintf("deprecated: cache capacity in chunks, converted to the capacity in bytes of as many chunks of %d bytes", swarm.ChunkWithSpanSize)) // deprecated: use cache-capacity-bytes instead
+	cmd.Flags().Uint64(optionNameCacheCapacityBytes, storer.DefaultCacheCapacity, "cache capacity in bytes")
+	cmd.Flags().String(optionNameCacheEvictionPolicy, "lru", "cache eviction policy: lru, lfu or 2q")
+	cmd.Flags().Uint64(optionNamePinQuota, 0, "maximum size of the pinned content in bytes, 0 is unlimited")
 	cmd.Flags().Uint64(optionNameDBOpenFilesLimit, 200, "number of open files allowed by database")
 	cmd.Flags().Uint64(optionNameDBBlockCacheCapacity, 32*1024*1024, "size of block cache of the database in bytes")
 	cmd.Flags().Uint64(optionNameDBWriteBufferSize, 32*1024*1024, "size of the database write buffer in bytes")
@@ -259,7 +273,7 @@ func (c *command) setAllFlags(cmd *cobra.Command) {
 	cmd.Flags().String(optionNamePaymentThreshold, "13500000", "threshold in BZZ where you expect to get paid from your peers")
 	cmd.Flags().Int64(optionNamePaymentTolerance, 25, "excess debt above payment threshold in percentages where you disconnect from your peer")
 	cmd.Flags().Int64(optionNamePaymentEarly, 50, "percentage below the peers payment threshold when we initiate settlement")
-	cmd.Flags().StringSlice(optionNameResolverEndpoints, []string{}, "ENS compatible API endpoint for a TLD and with contract address, can be repeated, format [tld:][contract-addr@]url")
+	cmd.Flags().StringSlice(optionNameResolverEndpoints, []string{}, "ENS compatible API endpoint for a TLD and with contract address, or a DNS server for the TXT records as dns://[host:port], can be repeated, format [tld:][contract-addr@]url")
 	cmd.Flags().Bool(optionNameBootnodeMode, false, "cause the node to always accept incoming connections")
 	cmd.Flags().String(optionNameSwapEndpoint, "", "swap blockchain endpoint") // deprecated: use rpc endpoint instead
 	cmd.Flags().String(optionNameBlockchainRpcEndpoint, "", "rpc blockchain endpoint")
@@ -292,6 +306,12 @@ func (c *command) setAllFlags(cmd *cobra.Command) {
 	cmd.Flags().Bool(optionNameTransactionDebugMode, false, "skips the gas estimate step for contract transactions")
 	cmd.Flags().Uint(optionMinimumStorageRadius, 0, "minimum radius storage threshold")
 	cmd.Flags().Int(optionReserveCapacityDoubling, 0, "reserve capacity doubling")
+	cmd.Flags().Bool(optionNameRestrictedAPI, false, "enable permission check on the http APIs")
+	cmd.Flags().String(optionNameAdminPasswordHash, "", "bcrypt hash of the admin password to get the security token")
+	cmd.Flags().Int(optionNamePssInboxCapacity, pss.DefaultInboxCapacity, "number of messages kept in a pss inbox")
+	cmd.Flags().Duration(optionNamePssInboxTTL, pss.DefaultInboxTTL, "time the messages are kept in a pss inbox")
+	cmd.Flags().String(optionNameNameRegistryTLD, "", "TLD of the names resolved from the swarm name registry, disabled if empty")
+	cmd.Flags().String(optionNameNameRegistryOwner, "", "ethereum address of the swarm name registry owner, the node's own address if empty")
 }
 
 func newLogger(cmd *cobra.Command, verbosity string) (log.Logger, error) {
@@ -85,7 +85,7 @@ func dbInfoCmd(cmd *cobra.Command) {
 				RadiusSetter:    noopRadiusSetter{},
 				Batchstore:      new(postage.NoOpBatchStore),
 				ReserveCapacity: storer.DefaultReserveCapacity,
-				CacheCapacity:   1_000_000,
+				CacheCapacity:   storer.DefaultCacheCapacity,
 			})
 			if err != nil {
 				return fmt.Errorf("localstore: %w", err)
@@ -284,7 +284,7 @@ func dbRepairReserve(cmd *cobra.Command) {
 				RadiusSetter:    noopRadiusSetter{},
 				Batchstore:      new(postage.NoOpBatchStore),
 				ReserveCapacity: storer.DefaultReserveCapacity,
-				CacheCapacity:   1_000_000,
+				CacheCapacity:   storer.DefaultCacheCapacity,
 			})
 			if err != nil {
 				return fmt.Errorf("localstore: %w", err)
@@ -411,7 +411,7 @@ func dbExportReserveCmd(cmd *cobra.Command) {
 				RadiusSetter:    noopRadiusSetter{},
 				Batchstore:      new(postage.NoOpBatchStore),
 				ReserveCapacity: storer.DefaultReserveCapacity,
-				CacheCapacity:   1_000_000,
+				CacheCapacity:   storer.DefaultCacheCapacity,
 			})
 			if err != nil {
 				return fmt.Errorf("localstore: %w", err)
@@ -494,7 +494,7 @@ func dbExportPinningCmd(cmd *cobra.Command) {
 				RadiusSetter:    noopRadiusSetter{},
 				Batchstore:      new(postage.NoOpBatchStore),
 				ReserveCapacity: storer.DefaultReserveCapacity,
-				CacheCapacity:   1_000_000,
+				CacheCapacity:   storer.DefaultCacheCapacity,
 			})
 			if err != nil {
 				return fmt.Errorf("localstore: %w", err)
@@ -604,7 +604,7 @@ func dbImportReserveCmd(cmd *cobra.Command) {
 				RadiusSetter:    noopRadiusSetter{},
 				Batchstore:      new(postage.NoOpBatchStore),
 				ReserveCapacity: storer.DefaultReserveCapacity,
-				CacheCapacity:   1_000_000,
+				CacheCapacity:   storer.DefaultCacheCapacity,
 			})
 			if err != nil {
 				return fmt.Errorf("localstore: %w", err)
@@ -688,7 +688,7 @@ func dbImportPinningCmd(cmd *cobra.Command) {
 				RadiusSetter:    noopRadiusSetter{},
 				Batchstore:      new(postage.NoOpBatchStore),
 				ReserveCapacity: storer.DefaultReserveCapacity,
-				CacheCapacity:   1_000_000,
+				CacheCapacity:   storer.DefaultCacheCapacity,
 			})
 			if err != nil {
 				return fmt.Errorf("localstore: %w", err)
@@ -727,7 +727,7 @@ func dbImportPinningCmd(cmd *cobra.Command) {
 
 				collection, ok := collections[rootAddr]
 				if !ok {
-					collection, err = db.NewCollection(cmd.Context())
+					collection, err = db.NewCollection(cmd.Context(), "", 0)
 					if err != nil {
 						return fmt.Errorf("error creating collection: %w", err)
 					}
@@ -103,7 +103,7 @@ func TestDBExportImportPinning(t *testing.T) {
 
 	for i := 0; i < 2; i++ {
 		rootAddr := swarm.RandAddress(t)
-		collection, err := db1.NewCollection(ctx)
+		collection, err := db1.NewCollection(ctx, "", 0)
 		if err != nil {
 			t.Fatal(err)
 		}
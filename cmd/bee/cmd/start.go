@@ -278,6 +278,15 @@ func buildBeeNode(ctx context.Context, c *command, cmd *cobra.Command, logger lo
 		blockchainRpcEndpoint = swapEndpoint
 	}
 
+	cacheCapacity := c.config.GetUint64(optionNameCacheCapacityBytes)
+	if c.config.IsSet(optionNameCacheCapacity) {
+		if c.config.IsSet(optionNameCacheCapacityBytes) {
+			return nil, fmt.Errorf("options %s and %s cannot be used together", optionNameCacheCapacity, optionNameCacheCapacityBytes)
+		}
+		cacheCapacity = c.config.GetUint64(optionNameCacheCapacity) * swarm.ChunkWithSpanSize
+		logger.Warning("deprecated option used, the capacity in chunks is converted to bytes", "option", optionNameCacheCapacity, "replacement", optionNameCacheCapacityBytes, "bytes", cacheCapacity)
+	}
+
 	var neighborhoodSuggester string
 	if networkID == chaincfg.Mainnet.NetworkID {
 		neighborhoodSuggester = c.config.GetString(optionNameNeighborhoodSuggester)
@@ -285,7 +294,9 @@ func buildBeeNode(ctx context.Context, c *command, cmd *cobra.Command, logger lo
 
 	b, err := node.NewBee(ctx, c.config.GetString(optionNameP2PAddr), signerConfig.publicKey, signerConfig.signer, networkID, logger, signerConfig.libp2pPrivateKey, signerConfig.pssPrivateKey, signerConfig.session, &node.Options{
 		DataDir:                       c.config.GetString(optionNameDataDir),
-		CacheCapacity:                 c.config.GetUint64(optionNameCacheCapacity),
+		CacheCapacity:                 cacheCapacity,
+		CacheEvictionPolicy:           c.config.GetString(optionNameCacheEvictionPolicy),
+		PinQuota:                      c.config.GetUint64(optionNamePinQuota),
 		DBOpenFilesLimit:              c.config.GetUint64(optionNameDBOpenFilesLimit),
 		DBBlockCacheCapacity:          c.config.GetUint64(optionNameDBBlockCacheCapacity),
 		DBWriteBufferSize:             c.config.GetUint64(optionNameDBWriteBufferSize),
@@ -336,6 +347,12 @@ func buildBeeNode(ctx context.Context, c *command, cmd *cobra.Command, logger lo
 		TrxDebugMode:                  c.config.GetBool(optionNameTransactionDebugMode),
 		MinimumStorageRadius:          c.config.GetUint(optionMinimumStorageRadius),
 		ReserveCapacityDoubling:       c.config.GetInt(optionReserveCapacityDoubling),
+		Restricted:                    c.config.GetBool(optionNameRestrictedAPI),
+		AdminPasswordHash:             c.config.GetString(optionNameAdminPasswordHash),
+		PssInboxCapacity:              c.config.GetInt(optionNamePssInboxCapacity),
+		PssInboxTTL:                   c.config.GetDuration(optionNamePssInboxTTL),
+		NameRegistryTLD:               c.config.GetString(optionNameNameRegistryTLD),
+		NameRegistryOwner:             c.config.GetString(optionNameNameRegistryOwner),
 	})
 
 	return b, err
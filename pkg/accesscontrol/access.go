@@ -21,6 +21,12 @@ var (
 	hashFunc      = sha3.NewLegacyKeccak256
 	oneByteArray  = []byte{1}
 	zeroByteArray = []byte{0}
+
+	// nonces of the lookup key and the decryption key of the access key.
+	accessKeyNonces = [][]byte{zeroByteArray, oneByteArray}
+	// nonces of the lookup key and the decryption key of the grantee list key,
+	// which the ACTs of group managed histories hold for the admins.
+	listKeyNonces = [][]byte{{2}, {3}}
 )
 
 // Decryptor is a read-only interface for the ACT.
@@ -79,20 +85,26 @@ func (al ActLogic) AddGrantee(ctx context.Context, storage kvs.KeyValueStore, pu
 		}
 	}
 
-	lookupKey, accessKeyDecryptionKey, err := al.getKeys(granteePubKey)
+	return al.putKey(ctx, storage, accessKey, granteePubKey, accessKeyNonces)
+}
+
+// putKey encrypts the key for the grantee and adds it to the ACT
+// under the lookup key derived with the nonces.
+func (al *ActLogic) putKey(ctx context.Context, storage kvs.KeyValueStore, key []byte, granteePubKey *ecdsa.PublicKey, nonces [][]byte) error {
+	lookupKey, keyDecryptionKey, err := al.getKeys(granteePubKey, nonces)
 	if err != nil {
 		return err
 	}
 
-	// Encrypt the access key for the new Grantee.
-	cipher := encryption.New(encryption.Key(accessKeyDecryptionKey), 0, 0, hashFunc)
-	granteeEncryptedAccessKey, err := cipher.Encrypt(accessKey)
+	// Encrypt the key for the new Grantee.
+	cipher := encryption.New(encryption.Key(keyDecryptionKey), 0, 0, hashFunc)
+	granteeEncryptedKey, err := cipher.Encrypt(key)
 	if err != nil {
 		return fmt.Errorf("failed to encrypt access key: %w", err)
 	}
 
-	// Add the new encrypted access key to the Act.
-	err = storage.Put(ctx, lookupKey, granteeEncryptedAccessKey)
+	// Add the new encrypted key to the Act.
+	err = storage.Put(ctx, lookupKey, granteeEncryptedKey)
 	if err != nil {
 		return fmt.Errorf("failed to put value to KVS: %w", err)
 	}
@@ -102,7 +114,12 @@ func (al ActLogic) AddGrantee(ctx context.Context, storage kvs.KeyValueStore, pu
 
 // Will return the access key for a publisher (public key).
 func (al *ActLogic) getAccessKey(ctx context.Context, storage kvs.KeyValueStore, publisherPubKey *ecdsa.PublicKey) ([]byte, error) {
-	publisherLookupKey, publisherAKDecryptionKey, err := al.getKeys(publisherPubKey)
+	return al.getKey(ctx, storage, publisherPubKey, accessKeyNonces)
+}
+
+// getKey looks up and decrypts the key of the ACT with the lookup key derived with the nonces.
+func (al *ActLogic) getKey(ctx context.Context, storage kvs.KeyValueStore, publisherPubKey *ecdsa.PublicKey, nonces [][]byte) ([]byte, error) {
+	publisherLookupKey, publisherAKDecryptionKey, err := al.getKeys(publisherPubKey, nonces)
 	if err != nil {
 		return nil, err
 	}
@@ -126,9 +143,24 @@ func (al *ActLogic) getAccessKey(ctx context.Context, storage kvs.KeyValueStore,
 	return accessKey, nil
 }
 
-// Generate lookup key and access key decryption key for a given public key.
-func (al *ActLogic) getKeys(publicKey *ecdsa.PublicKey) ([]byte, []byte, error) {
-	nonces := [][]byte{zeroByteArray, oneByteArray}
+// hasKey reports whether the ACT holds a key under the lookup key derived with the nonces.
+func (al *ActLogic) hasKey(ctx context.Context, storage kvs.KeyValueStore, publicKey *ecdsa.PublicKey, nonces [][]byte) (bool, error) {
+	lookupKey, _, err := al.getKeys(publicKey, nonces)
+	if err != nil {
+		return false, err
+	}
+	_, err = storage.Get(ctx, lookupKey)
+	if err != nil {
+		if errors.Is(err, kvs.ErrNotFound) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed go get value from KVS: %w", err)
+	}
+	return true, nil
+}
+
+// Generate lookup key and key decryption key for a given public key.
+func (al *ActLogic) getKeys(publicKey *ecdsa.PublicKey, nonces [][]byte) ([]byte, []byte, error) {
 	keys, err := al.Session.Key(publicKey, nonces)
 	if len(keys) != len(nonces) {
 		return nil, nil, err
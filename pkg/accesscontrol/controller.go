@@ -9,24 +9,46 @@ package accesscontrol
 import (
 	"context"
 	"crypto/ecdsa"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"io"
 	"time"
 
+	"github.com/btcsuite/btcd/btcec/v2"
 	"github.com/ethersphere/bee/v2/pkg/accesscontrol/kvs"
+	"github.com/ethersphere/bee/v2/pkg/crypto"
 	"github.com/ethersphere/bee/v2/pkg/encryption"
 	"github.com/ethersphere/bee/v2/pkg/file"
+	"github.com/ethersphere/bee/v2/pkg/manifest"
 	"github.com/ethersphere/bee/v2/pkg/swarm"
 )
 
+const (
+	// encryptedGranteeListKey is the history entry metadata key of the encrypted grantee list reference.
+	encryptedGranteeListKey = "encryptedglref"
+	// publisherKey is the history entry metadata key of the admin that added
+	// the entry of a group managed history.
+	publisherKey = "publisher"
+	// adminsKey is the history entry metadata key of the admin list reference
+	// of a group managed history.
+	adminsKey = "admins"
+)
+
+// ErrNotAdmin is returned if the caller is not an admin of a group managed history.
+var ErrNotAdmin = errors.New("access control: not an admin of the history")
+
 // Grantees represents an interface for managing and retrieving grantees for a publisher.
 type Grantees interface {
 	// UpdateHandler manages the grantees for the given publisher, updating the list based on provided public keys to add or remove.
 	// Only the publisher can make changes to the grantee list.
 	UpdateHandler(ctx context.Context, ls file.LoadSaver, gls file.LoadSaver, granteeRef swarm.Address, historyRef swarm.Address, publisher *ecdsa.PublicKey, addList, removeList []*ecdsa.PublicKey) (swarm.Address, swarm.Address, swarm.Address, swarm.Address, error)
+	// CreateGroupHandler creates a history with a grantee list that is managed by the group of the
+	// publisher and the admins. Any admin can add or revoke grantees and publish new history entries.
+	CreateGroupHandler(ctx context.Context, ls file.LoadSaver, gls file.LoadSaver, publisher *ecdsa.PublicKey, admins, addList []*ecdsa.PublicKey) (swarm.Address, swarm.Address, swarm.Address, swarm.Address, error)
 	// Get returns the list of grantees for the given publisher.
-	// The list is accessible only by the publisher.
-	Get(ctx context.Context, ls file.LoadSaver, publisher *ecdsa.PublicKey, encryptedglRef swarm.Address) ([]*ecdsa.PublicKey, error)
+	// The list is accessible only by the publisher or, if the history is managed by a group, by the admins.
+	Get(ctx context.Context, ls file.LoadSaver, publisher *ecdsa.PublicKey, encryptedglRef swarm.Address, historyRef swarm.Address) ([]*ecdsa.PublicKey, error)
 }
 
 // Controller represents an interface for managing access control on Swarm.
@@ -37,9 +59,24 @@ type Controller interface {
 	DownloadHandler(ctx context.Context, ls file.LoadSaver, encryptedRef swarm.Address, publisher *ecdsa.PublicKey, historyRef swarm.Address, timestamp int64) (swarm.Address, error)
 	// UploadHandler encrypts the reference and stores it in the history as the latest update.
 	UploadHandler(ctx context.Context, ls file.LoadSaver, reference swarm.Address, publisher *ecdsa.PublicKey, historyRef swarm.Address) (swarm.Address, swarm.Address, swarm.Address, error)
+	// AuditHandler reports for every entry of the history whether the grantee has access to it.
+	AuditHandler(ctx context.Context, ls file.LoadSaver, historyRef swarm.Address, publisher, grantee *ecdsa.PublicKey) ([]GranteeAccess, error)
 	io.Closer
 }
 
+// GranteeAccess represents the access of a grantee to the ACT of a history entry.
+type GranteeAccess struct {
+	// Timestamp is the timestamp of the history entry.
+	Timestamp int64
+	// Publisher is the publisher who wrote the ACT of the entry.
+	Publisher *ecdsa.PublicKey
+	// Verified reports whether the access could be checked, the lookup key of the grantee
+	// can be derived only for the ACTs written by the publisher who audits the history.
+	Verified bool
+	// Granted reports whether the grantee has a lookup key in the ACT.
+	Granted bool
+}
+
 // ControllerStruct represents a controller for access control logic.
 type ControllerStruct struct {
 	access ActLogic
@@ -63,12 +100,12 @@ func (c *ControllerStruct) DownloadHandler(
 	historyRef swarm.Address,
 	timestamp int64,
 ) (swarm.Address, error) {
-	_, act, err := c.getHistoryAndAct(ctx, ls, historyRef, publisher, timestamp)
+	_, act, writer, err := c.getHistoryAndAct(ctx, ls, historyRef, publisher, timestamp)
 	if err != nil {
 		return swarm.ZeroAddress, err
 	}
 
-	return c.access.DecryptRef(ctx, act, encryptedRef, publisher)
+	return c.access.DecryptRef(ctx, act, encryptedRef, writer)
 }
 
 // UploadHandler encrypts the reference and stores it in the history as the latest update.
@@ -79,7 +116,16 @@ func (c *ControllerStruct) UploadHandler(
 	publisher *ecdsa.PublicKey,
 	historyRef swarm.Address,
 ) (swarm.Address, swarm.Address, swarm.Address, error) {
-	history, act, err := c.getHistoryAndAct(ctx, ls, historyRef, publisher, time.Now().Unix())
+	timestamp := time.Now().Unix()
+	g, err := c.getGroup(ctx, ls, historyRef)
+	if err != nil {
+		return swarm.ZeroAddress, swarm.ZeroAddress, swarm.ZeroAddress, err
+	}
+	if g != nil {
+		// the latest entry of a group managed history can be ahead of the clock
+		timestamp = max(timestamp, g.timestamp)
+	}
+	history, act, writer, err := c.getHistoryAndAct(ctx, ls, historyRef, publisher, timestamp)
 	if err != nil {
 		return swarm.ZeroAddress, swarm.ZeroAddress, swarm.ZeroAddress, err
 	}
@@ -92,14 +138,72 @@ func (c *ControllerStruct) UploadHandler(
 		}
 	}
 
-	encryptedRef, err := c.access.EncryptRef(ctx, act, publisher, reference)
+	encryptedRef, err := c.access.EncryptRef(ctx, act, writer, reference)
 	return actRef, newHistoryRef, encryptedRef, err
 }
 
+// AuditHandler reports for every entry of the history, from the latest to the earliest one,
+// whether the grantee has a lookup key in the ACT of the entry.
+func (c *ControllerStruct) AuditHandler(
+	ctx context.Context,
+	ls file.LoadSaver,
+	historyRef swarm.Address,
+	publisher *ecdsa.PublicKey,
+	grantee *ecdsa.PublicKey,
+) ([]GranteeAccess, error) {
+	history, err := NewHistoryReference(ls, historyRef)
+	if err != nil {
+		return nil, err
+	}
+
+	var report []GranteeAccess
+	err = history.Iterate(ctx, func(entry manifest.Entry, timestamp int64) (bool, error) {
+		writer, err := entryPublisher(entry, publisher)
+		if err != nil {
+			return true, err
+		}
+		access := GranteeAccess{
+			Timestamp: timestamp,
+			Publisher: writer,
+		}
+		if writer.Equal(publisher) {
+			act, err := kvs.NewReference(ls, entry.Reference())
+			if err != nil {
+				return true, err
+			}
+			// the publisher always holds the access key of its own ACTs,
+			// without it the ACT belongs to someone else
+			access.Verified, err = c.access.hasKey(ctx, act, publisher, accessKeyNonces)
+			if err != nil {
+				return true, err
+			}
+			if access.Verified {
+				access.Granted, err = c.access.hasKey(ctx, act, grantee, accessKeyNonces)
+				if err != nil {
+					return true, err
+				}
+			}
+		}
+		report = append(report, access)
+		return false, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
+
 // UpdateHandler manages the grantees for the given publisher, updating the list based on provided public keys to add or remove.
 // Only the publisher can make changes to the grantee list.
+// If the history is managed by a group, any of the admins can make changes.
+// Revoking grantees always creates a new access key in a new history entry for the remaining grantees.
+// The references encrypted before can be carried over by decrypting them at the latest entry of the
+// previous history and encrypting them again with UploadHandler on the updated history.
+// Only the references are encrypted again, not the content, so the revoked grantees keep access to the
+// content they already resolved; the content published after the update is out of their reach.
 // Limitation: If an update is called again within a second from the latest upload/update then mantaray save fails with ErrInvalidInput,
-// because the key (timestamp) is already present, hence a new fork is not created.
+// because the key (timestamp) is already present, hence a new fork is not created. This does not apply to group managed histories.
 func (c *ControllerStruct) UpdateHandler(
 	ctx context.Context,
 	ls file.LoadSaver,
@@ -110,12 +214,26 @@ func (c *ControllerStruct) UpdateHandler(
 	addList []*ecdsa.PublicKey,
 	removeList []*ecdsa.PublicKey,
 ) (swarm.Address, swarm.Address, swarm.Address, swarm.Address, error) {
-	history, act, err := c.getHistoryAndAct(ctx, ls, historyRef, publisher, time.Now().Unix())
+	if !historyRef.IsZero() {
+		g, err := c.getGroup(ctx, ls, historyRef)
+		if err != nil {
+			return swarm.ZeroAddress, swarm.ZeroAddress, swarm.ZeroAddress, swarm.ZeroAddress, err
+		}
+		if g != nil {
+			return c.updateGroup(ctx, ls, gls, encryptedglRef, historyRef, g, publisher, addList, removeList)
+		}
+	}
+
+	history, act, _, err := c.getHistoryAndAct(ctx, ls, historyRef, publisher, time.Now().Unix())
 	if err != nil {
 		return swarm.ZeroAddress, swarm.ZeroAddress, swarm.ZeroAddress, swarm.ZeroAddress, err
 	}
 
-	gl, err := c.getGranteeList(ctx, gls, encryptedglRef, publisher)
+	listKey, err := c.refKeyForPublisher(publisher)
+	if err != nil {
+		return swarm.ZeroAddress, swarm.ZeroAddress, swarm.ZeroAddress, swarm.ZeroAddress, err
+	}
+	gl, err := c.getGranteeList(ctx, gls, encryptedglRef, listKey)
 	if err != nil {
 		return swarm.ZeroAddress, swarm.ZeroAddress, swarm.ZeroAddress, swarm.ZeroAddress, err
 	}
@@ -153,7 +271,7 @@ func (c *ControllerStruct) UpdateHandler(
 		return swarm.ZeroAddress, swarm.ZeroAddress, swarm.ZeroAddress, swarm.ZeroAddress, err
 	}
 
-	egranteeRef, err := c.encryptRefForPublisher(publisher, granteeRef)
+	egranteeRef, err := encryptRef(listKey, granteeRef)
 	if err != nil {
 		return swarm.ZeroAddress, swarm.ZeroAddress, swarm.ZeroAddress, swarm.ZeroAddress, err
 	}
@@ -165,7 +283,7 @@ func (c *ControllerStruct) UpdateHandler(
 		}
 	}
 
-	mtdt := map[string]string{"encryptedglref": egranteeRef.String()}
+	mtdt := map[string]string{encryptedGranteeListKey: egranteeRef.String()}
 	hRef, actRef, err := c.saveHistoryAndAct(ctx, history, &mtdt, act)
 	if err != nil {
 		return swarm.ZeroAddress, swarm.ZeroAddress, swarm.ZeroAddress, swarm.ZeroAddress, err
@@ -174,16 +292,289 @@ func (c *ControllerStruct) UpdateHandler(
 	return granteeRef, egranteeRef, hRef, actRef, nil
 }
 
+// CreateGroupHandler creates a history with a grantee list that is managed by the group of the
+// publisher and the admins. Any admin can add or revoke grantees and publish new history entries.
+func (c *ControllerStruct) CreateGroupHandler(
+	ctx context.Context,
+	ls file.LoadSaver,
+	gls file.LoadSaver,
+	publisher *ecdsa.PublicKey,
+	admins []*ecdsa.PublicKey,
+	addList []*ecdsa.PublicKey,
+) (swarm.Address, swarm.Address, swarm.Address, swarm.Address, error) {
+	al := NewGranteeList(ls)
+	err := al.Add(append([]*ecdsa.PublicKey{publisher}, admins...))
+	if err != nil {
+		return swarm.ZeroAddress, swarm.ZeroAddress, swarm.ZeroAddress, swarm.ZeroAddress, err
+	}
+	adminsRef, err := al.Save(ctx)
+	if err != nil {
+		return swarm.ZeroAddress, swarm.ZeroAddress, swarm.ZeroAddress, swarm.ZeroAddress, err
+	}
+
+	gl := NewGranteeList(gls)
+	if len(addList) != 0 {
+		err = gl.Add(addList)
+		if err != nil {
+			return swarm.ZeroAddress, swarm.ZeroAddress, swarm.ZeroAddress, swarm.ZeroAddress, err
+		}
+	}
+
+	history, err := NewHistory(ls)
+	if err != nil {
+		return swarm.ZeroAddress, swarm.ZeroAddress, swarm.ZeroAddress, swarm.ZeroAddress, err
+	}
+
+	g := &group{
+		admins:    al.Get(),
+		adminsRef: adminsRef,
+	}
+	accessKey := encryption.GenerateRandomKey(encryption.KeyLength)
+	listKey := encryption.GenerateRandomKey(encryption.KeyLength)
+	return c.saveGroupEntry(ctx, ls, history, time.Now().Unix(), publisher, g, accessKey, listKey, gl)
+}
+
 // Get returns the list of grantees for the given publisher.
-// The list is accessible only by the publisher.
-func (c *ControllerStruct) Get(ctx context.Context, ls file.LoadSaver, publisher *ecdsa.PublicKey, encryptedglRef swarm.Address) ([]*ecdsa.PublicKey, error) {
-	gl, err := c.getGranteeList(ctx, ls, encryptedglRef, publisher)
+// The list is accessible only by the publisher or, if the history is managed by a group, by the admins.
+func (c *ControllerStruct) Get(ctx context.Context, ls file.LoadSaver, publisher *ecdsa.PublicKey, encryptedglRef swarm.Address, historyRef swarm.Address) ([]*ecdsa.PublicKey, error) {
+	var listKey []byte
+	g, err := c.getGroup(ctx, ls, historyRef)
+	if err != nil {
+		return nil, err
+	}
+	if g != nil {
+		if !g.isAdmin(publisher) {
+			return nil, ErrNotAdmin
+		}
+		listKey, err = c.access.getKey(ctx, g.act, g.writer, listKeyNonces)
+	} else {
+		listKey, err = c.refKeyForPublisher(publisher)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	gl, err := c.getGranteeList(ctx, ls, encryptedglRef, listKey)
 	if err != nil {
 		return nil, err
 	}
 	return gl.Get(), nil
 }
 
+// group holds the state of the latest entry of a group managed history.
+type group struct {
+	timestamp      int64
+	writer         *ecdsa.PublicKey
+	admins         []*ecdsa.PublicKey
+	adminsRef      swarm.Address
+	act            kvs.KeyValueStore
+	encryptedglRef swarm.Address
+}
+
+func (g *group) isAdmin(publisher *ecdsa.PublicKey) bool {
+	for _, admin := range g.admins {
+		if admin.Equal(publisher) {
+			return true
+		}
+	}
+	return false
+}
+
+// getGroup loads the latest entry of the history if it is managed by a group, otherwise it returns nil.
+func (c *ControllerStruct) getGroup(ctx context.Context, ls file.LoadSaver, historyRef swarm.Address) (*group, error) {
+	if historyRef.IsZero() {
+		return nil, nil
+	}
+	history, err := NewHistoryReference(ls, historyRef)
+	if err != nil {
+		return nil, err
+	}
+	entry, timestamp, err := history.Latest(ctx)
+	if err != nil {
+		return nil, err
+	}
+	mtdt := entry.Metadata()
+	if mtdt[adminsKey] == "" {
+		return nil, nil
+	}
+
+	adminsRef, err := swarm.ParseHexAddress(mtdt[adminsKey])
+	if err != nil {
+		return nil, fmt.Errorf("invalid admin list reference: %w", err)
+	}
+	admins, err := NewGranteeListReference(ctx, ls, adminsRef)
+	if err != nil {
+		return nil, err
+	}
+	writer, err := entryPublisher(entry, nil)
+	if err != nil {
+		return nil, err
+	}
+	act, err := kvs.NewReference(ls, entry.Reference())
+	if err != nil {
+		return nil, err
+	}
+	encryptedglRef := swarm.ZeroAddress
+	if v := mtdt[encryptedGranteeListKey]; v != "" {
+		encryptedglRef, err = swarm.ParseHexAddress(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid encrypted grantee list reference: %w", err)
+		}
+	}
+
+	return &group{
+		timestamp:      timestamp,
+		writer:         writer,
+		admins:         admins.Get(),
+		adminsRef:      adminsRef,
+		act:            act,
+		encryptedglRef: encryptedglRef,
+	}, nil
+}
+
+// updateGroup is the UpdateHandler of the group managed histories. The new entry is always
+// added after the latest one, so concurrent updates of the admins are ordered by their timestamp.
+func (c *ControllerStruct) updateGroup(
+	ctx context.Context,
+	ls file.LoadSaver,
+	gls file.LoadSaver,
+	encryptedglRef swarm.Address,
+	historyRef swarm.Address,
+	g *group,
+	publisher *ecdsa.PublicKey,
+	addList []*ecdsa.PublicKey,
+	removeList []*ecdsa.PublicKey,
+) (swarm.Address, swarm.Address, swarm.Address, swarm.Address, error) {
+	if !g.isAdmin(publisher) {
+		return swarm.ZeroAddress, swarm.ZeroAddress, swarm.ZeroAddress, swarm.ZeroAddress, ErrNotAdmin
+	}
+	accessKey, err := c.access.getKey(ctx, g.act, g.writer, accessKeyNonces)
+	if err != nil {
+		return swarm.ZeroAddress, swarm.ZeroAddress, swarm.ZeroAddress, swarm.ZeroAddress, err
+	}
+	listKey, err := c.access.getKey(ctx, g.act, g.writer, listKeyNonces)
+	if err != nil {
+		return swarm.ZeroAddress, swarm.ZeroAddress, swarm.ZeroAddress, swarm.ZeroAddress, err
+	}
+
+	if encryptedglRef.IsZero() {
+		encryptedglRef = g.encryptedglRef
+	}
+	gl, err := c.getGranteeList(ctx, gls, encryptedglRef, listKey)
+	if err != nil {
+		return swarm.ZeroAddress, swarm.ZeroAddress, swarm.ZeroAddress, swarm.ZeroAddress, err
+	}
+	if len(addList) != 0 {
+		err = gl.Add(addList)
+		if err != nil {
+			return swarm.ZeroAddress, swarm.ZeroAddress, swarm.ZeroAddress, swarm.ZeroAddress, err
+		}
+	}
+	if len(removeList) != 0 {
+		err = gl.Remove(removeList)
+		if err != nil {
+			return swarm.ZeroAddress, swarm.ZeroAddress, swarm.ZeroAddress, swarm.ZeroAddress, err
+		}
+		// generate new access key, so that the revoked grantees cannot decrypt the new content
+		accessKey = encryption.GenerateRandomKey(encryption.KeyLength)
+	}
+
+	history, err := NewHistoryReference(ls, historyRef)
+	if err != nil {
+		return swarm.ZeroAddress, swarm.ZeroAddress, swarm.ZeroAddress, swarm.ZeroAddress, err
+	}
+	timestamp := max(time.Now().Unix(), g.timestamp+1)
+	return c.saveGroupEntry(ctx, ls, history, timestamp, publisher, g, accessKey, listKey, gl)
+}
+
+// saveGroupEntry creates a new ACT written by the publisher, in which the admins have both the access key
+// and the grantee list key, while the grantees have only the access key, and adds it to the history.
+func (c *ControllerStruct) saveGroupEntry(
+	ctx context.Context,
+	ls file.LoadSaver,
+	history History,
+	timestamp int64,
+	publisher *ecdsa.PublicKey,
+	g *group,
+	accessKey []byte,
+	listKey []byte,
+	gl GranteeList,
+) (swarm.Address, swarm.Address, swarm.Address, swarm.Address, error) {
+	act, err := kvs.New(ls)
+	if err != nil {
+		return swarm.ZeroAddress, swarm.ZeroAddress, swarm.ZeroAddress, swarm.ZeroAddress, err
+	}
+	for _, admin := range g.admins {
+		err = c.access.putKey(ctx, act, accessKey, admin, accessKeyNonces)
+		if err != nil {
+			return swarm.ZeroAddress, swarm.ZeroAddress, swarm.ZeroAddress, swarm.ZeroAddress, err
+		}
+		err = c.access.putKey(ctx, act, listKey, admin, listKeyNonces)
+		if err != nil {
+			return swarm.ZeroAddress, swarm.ZeroAddress, swarm.ZeroAddress, swarm.ZeroAddress, err
+		}
+	}
+	for _, grantee := range gl.Get() {
+		if g.isAdmin(grantee) {
+			continue
+		}
+		err = c.access.putKey(ctx, act, accessKey, grantee, accessKeyNonces)
+		if err != nil {
+			return swarm.ZeroAddress, swarm.ZeroAddress, swarm.ZeroAddress, swarm.ZeroAddress, err
+		}
+	}
+
+	granteeRef, err := gl.Save(ctx)
+	if err != nil {
+		return swarm.ZeroAddress, swarm.ZeroAddress, swarm.ZeroAddress, swarm.ZeroAddress, err
+	}
+	egranteeRef, err := encryptRef(listKey, granteeRef)
+	if err != nil {
+		return swarm.ZeroAddress, swarm.ZeroAddress, swarm.ZeroAddress, swarm.ZeroAddress, err
+	}
+
+	actRef, err := act.Save(ctx)
+	if err != nil {
+		return swarm.ZeroAddress, swarm.ZeroAddress, swarm.ZeroAddress, swarm.ZeroAddress, err
+	}
+	mtdt := map[string]string{
+		encryptedGranteeListKey: egranteeRef.String(),
+		publisherKey:            hex.EncodeToString(crypto.EncodeSecp256k1PublicKey(publisher)),
+		adminsKey:               g.adminsRef.String(),
+	}
+	err = history.Add(ctx, actRef, &timestamp, &mtdt)
+	if err != nil {
+		return swarm.ZeroAddress, swarm.ZeroAddress, swarm.ZeroAddress, swarm.ZeroAddress, err
+	}
+	hRef, err := history.Store(ctx)
+	if err != nil {
+		return swarm.ZeroAddress, swarm.ZeroAddress, swarm.ZeroAddress, swarm.ZeroAddress, err
+	}
+
+	return granteeRef, egranteeRef, hRef, actRef, nil
+}
+
+// entryPublisher returns the admin that added the entry of a group managed history,
+// or the fallback if the entry was added by the single publisher of the history.
+func entryPublisher(entry manifest.Entry, fallback *ecdsa.PublicKey) (*ecdsa.PublicKey, error) {
+	v, ok := entry.Metadata()[publisherKey]
+	if !ok {
+		if fallback == nil {
+			return nil, ErrInvalidPublicKey
+		}
+		return fallback, nil
+	}
+	b, err := hex.DecodeString(v)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidPublicKey, err)
+	}
+	key, err := btcec.ParsePubKey(b)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidPublicKey, err)
+	}
+	return key.ToECDSA(), nil
+}
+
 func (c *ControllerStruct) newActWithPublisher(ctx context.Context, ls file.LoadSaver, publisher *ecdsa.PublicKey) (kvs.KeyValueStore, error) {
 	act, err := kvs.New(ls)
 	if err != nil {
@@ -197,32 +588,38 @@ func (c *ControllerStruct) newActWithPublisher(ctx context.Context, ls file.Load
 	return act, nil
 }
 
-func (c *ControllerStruct) getHistoryAndAct(ctx context.Context, ls file.LoadSaver, historyRef swarm.Address, publisher *ecdsa.PublicKey, timestamp int64) (history History, act kvs.KeyValueStore, err error) {
+// getHistoryAndAct returns the history, the ACT of the entry at the timestamp and the publisher who wrote the ACT.
+func (c *ControllerStruct) getHistoryAndAct(ctx context.Context, ls file.LoadSaver, historyRef swarm.Address, publisher *ecdsa.PublicKey, timestamp int64) (history History, act kvs.KeyValueStore, writer *ecdsa.PublicKey, err error) {
 	if historyRef.IsZero() {
 		history, err = NewHistory(ls)
 		if err != nil {
-			return nil, nil, err
+			return nil, nil, nil, err
 		}
 		act, err = c.newActWithPublisher(ctx, ls, publisher)
 		if err != nil {
-			return nil, nil, err
+			return nil, nil, nil, err
 		}
+		writer = publisher
 	} else {
 		history, err = NewHistoryReference(ls, historyRef)
 		if err != nil {
-			return nil, nil, err
+			return nil, nil, nil, err
 		}
 		entry, err := history.Lookup(ctx, timestamp)
 		if err != nil {
-			return nil, nil, err
+			return nil, nil, nil, err
 		}
 		act, err = kvs.NewReference(ls, entry.Reference())
 		if err != nil {
-			return nil, nil, err
+			return nil, nil, nil, err
+		}
+		writer, err = entryPublisher(entry, publisher)
+		if err != nil {
+			return nil, nil, nil, err
 		}
 	}
 
-	return history, act, nil
+	return history, act, writer, nil
 }
 
 func (c *ControllerStruct) saveHistoryAndAct(ctx context.Context, history History, mtdt *map[string]string, act kvs.KeyValueStore) (swarm.Address, swarm.Address, error) {
@@ -242,11 +639,11 @@ func (c *ControllerStruct) saveHistoryAndAct(ctx context.Context, history Histor
 	return historyRef, actRef, nil
 }
 
-func (c *ControllerStruct) getGranteeList(ctx context.Context, ls file.LoadSaver, encryptedglRef swarm.Address, publisher *ecdsa.PublicKey) (gl GranteeList, err error) {
+func (c *ControllerStruct) getGranteeList(ctx context.Context, ls file.LoadSaver, encryptedglRef swarm.Address, key []byte) (gl GranteeList, err error) {
 	if encryptedglRef.IsZero() {
 		gl = NewGranteeList(ls)
 	} else {
-		granteeref, err := c.decryptRefForPublisher(publisher, encryptedglRef)
+		granteeref, err := decryptRef(key, encryptedglRef)
 		if err != nil {
 			return nil, err
 		}
@@ -260,12 +657,17 @@ func (c *ControllerStruct) getGranteeList(ctx context.Context, ls file.LoadSaver
 	return gl, nil
 }
 
-func (c *ControllerStruct) encryptRefForPublisher(publisherPubKey *ecdsa.PublicKey, ref swarm.Address) (swarm.Address, error) {
+// refKeyForPublisher returns the key with which the publisher encrypts the grantee list reference.
+func (c *ControllerStruct) refKeyForPublisher(publisherPubKey *ecdsa.PublicKey) ([]byte, error) {
 	keys, err := c.access.Session.Key(publisherPubKey, [][]byte{oneByteArray})
 	if err != nil {
-		return swarm.ZeroAddress, err
+		return nil, err
 	}
-	refCipher := encryption.New(keys[0], 0, 0, hashFunc)
+	return keys[0], nil
+}
+
+func encryptRef(key []byte, ref swarm.Address) (swarm.Address, error) {
+	refCipher := encryption.New(key, 0, 0, hashFunc)
 	encryptedRef, err := refCipher.Encrypt(ref.Bytes())
 	if err != nil {
 		return swarm.ZeroAddress, fmt.Errorf("failed to encrypt reference: %w", err)
@@ -274,12 +676,8 @@ func (c *ControllerStruct) encryptRefForPublisher(publisherPubKey *ecdsa.PublicK
 	return swarm.NewAddress(encryptedRef), nil
 }
 
-func (c *ControllerStruct) decryptRefForPublisher(publisherPubKey *ecdsa.PublicKey, encryptedRef swarm.Address) (swarm.Address, error) {
-	keys, err := c.access.Session.Key(publisherPubKey, [][]byte{oneByteArray})
-	if err != nil {
-		return swarm.ZeroAddress, err
-	}
-	refCipher := encryption.New(keys[0], 0, 0, hashFunc)
+func decryptRef(key []byte, encryptedRef swarm.Address) (swarm.Address, error) {
+	refCipher := encryption.New(key, 0, 0, hashFunc)
 	ref, err := refCipher.Decrypt(encryptedRef.Bytes())
 	if err != nil {
 		return swarm.ZeroAddress, fmt.Errorf("failed to decrypt reference: %w", err)
@@ -7,12 +7,14 @@ package accesscontrol_test
 import (
 	"context"
 	"crypto/ecdsa"
+	"math"
 	"reflect"
 	"testing"
 	"time"
 
 	"github.com/ethersphere/bee/v2/pkg/accesscontrol"
 	"github.com/ethersphere/bee/v2/pkg/accesscontrol/kvs"
+	"github.com/ethersphere/bee/v2/pkg/crypto"
 	encryption "github.com/ethersphere/bee/v2/pkg/encryption"
 	"github.com/ethersphere/bee/v2/pkg/file"
 	"github.com/ethersphere/bee/v2/pkg/file/loadsave"
@@ -273,6 +275,24 @@ func TestController_UpdateHandler(t *testing.T) {
 		decRef, err = c.DownloadHandler(ctx, ls, encRef, &publisher.PublicKey, hrefUpdate2, beforeRevokeTS)
 		require.NoError(t, err)
 		assert.Equal(t, ref, decRef)
+
+		// publisher re-encrypts the reference with the access key after the revoke
+		decRef, err = c.DownloadHandler(ctx, ls, encRef, &publisher.PublicKey, hrefUpdate1, math.MaxInt64)
+		require.NoError(t, err)
+		_, _, reencRef, err := c.UploadHandler(ctx, ls, decRef, &publisher.PublicKey, hrefUpdate2)
+		require.NoError(t, err)
+		assert.NotEqual(t, encRef, reencRef)
+
+		// revoked grantee shall NOT be able to decrypt the re-encrypted reference with any timestamp
+		decRef, err = granteeCtrl.DownloadHandler(ctx, ls, reencRef, &publisher.PublicKey, hrefUpdate2, beforeRevokeTS)
+		require.NoError(t, err)
+		assert.NotEqual(t, ref, decRef)
+		_, err = granteeCtrl.DownloadHandler(ctx, ls, reencRef, &publisher.PublicKey, hrefUpdate2, time.Now().Unix())
+		require.Error(t, err)
+
+		decRef, err = c.DownloadHandler(ctx, ls, reencRef, &publisher.PublicKey, hrefUpdate2, time.Now().Unix())
+		require.NoError(t, err)
+		assert.Equal(t, ref, decRef)
 	})
 	t.Run("add twice", func(t *testing.T) {
 		addList := []*ecdsa.PublicKey{&grantee.PublicKey, &grantee.PublicKey}
@@ -296,6 +316,47 @@ func TestController_UpdateHandler(t *testing.T) {
 	})
 }
 
+func TestController_AuditHandler(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	publisher := getPrivKey(1)
+	other := getPrivKey(0)
+	grantee := getPrivKey(2)
+	c := accesscontrol.NewController(accesscontrol.NewLogic(accesscontrol.NewDefaultSession(publisher)))
+	ls := createLs()
+	gls := loadsave.New(mockStorer.ChunkStore(), mockStorer.Cache(), requestPipelineFactory(context.Background(), mockStorer.Cache(), true, redundancy.NONE))
+
+	addRevokeList := []*ecdsa.PublicKey{&grantee.PublicKey}
+	_, eglRef, hRef, _, err := c.UpdateHandler(ctx, ls, gls, swarm.ZeroAddress, swarm.ZeroAddress, &publisher.PublicKey, addRevokeList, nil)
+	require.NoError(t, err)
+	// Need to wait a second before each update call so that a new history mantaray fork is created for the new key(timestamp) entry
+	time.Sleep(1 * time.Second)
+	_, _, hRef, _, err = c.UpdateHandler(ctx, ls, gls, eglRef, hRef, &publisher.PublicKey, nil, addRevokeList)
+	require.NoError(t, err)
+
+	t.Run("by publisher", func(t *testing.T) {
+		report, err := c.AuditHandler(ctx, ls, hRef, &publisher.PublicKey, &grantee.PublicKey)
+		require.NoError(t, err)
+		require.Len(t, report, 2)
+		assert.Greater(t, report[0].Timestamp, report[1].Timestamp)
+		assert.True(t, report[0].Verified)
+		assert.False(t, report[0].Granted, "revoked grantee has access to the latest entry")
+		assert.True(t, report[1].Verified)
+		assert.True(t, report[1].Granted, "grantee has no access to the first entry")
+		assert.True(t, report[1].Publisher.Equal(&publisher.PublicKey))
+	})
+	t.Run("by other publisher", func(t *testing.T) {
+		co := accesscontrol.NewController(accesscontrol.NewLogic(accesscontrol.NewDefaultSession(other)))
+		report, err := co.AuditHandler(ctx, ls, hRef, &other.PublicKey, &grantee.PublicKey)
+		require.NoError(t, err)
+		require.Len(t, report, 2)
+		for _, access := range report {
+			assert.False(t, access.Verified)
+			assert.False(t, access.Granted)
+		}
+	})
+}
+
 func TestController_Get(t *testing.T) {
 	t.Parallel()
 	ctx := context.Background()
@@ -316,7 +377,7 @@ func TestController_Get(t *testing.T) {
 		granteeRef, eglRef, _, _, err := c1.UpdateHandler(ctx, ls, gls, swarm.ZeroAddress, swarm.ZeroAddress, &publisher.PublicKey, addList, nil)
 		assertNoError(t, "UpdateHandler", err)
 
-		grantees, err := c1.Get(ctx, ls, &publisher.PublicKey, eglRef)
+		grantees, err := c1.Get(ctx, ls, &publisher.PublicKey, eglRef, swarm.ZeroAddress)
 		assertNoError(t, "get by publisher", err)
 		assert.True(t, reflect.DeepEqual(grantees, addList))
 
@@ -328,8 +389,118 @@ func TestController_Get(t *testing.T) {
 		addList := []*ecdsa.PublicKey{&grantee.PublicKey}
 		_, eglRef, _, _, err := c1.UpdateHandler(ctx, ls, gls, swarm.ZeroAddress, swarm.ZeroAddress, &publisher.PublicKey, addList, nil)
 		assertNoError(t, "UpdateHandler", err)
-		grantees, err := c2.Get(ctx, ls, &publisher.PublicKey, eglRef)
+		grantees, err := c2.Get(ctx, ls, &publisher.PublicKey, eglRef, swarm.ZeroAddress)
 		assertError(t, "controller get by non-publisher", err)
 		assert.Nil(t, grantees)
 	})
 }
+
+func TestController_Group(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	ls := createLs()
+	gls := loadsave.New(mockStorer.ChunkStore(), mockStorer.Cache(), requestPipelineFactory(context.Background(), mockStorer.Cache(), true, redundancy.NONE))
+
+	var (
+		publisher = getPrivKey(0)
+		admin     = getPrivKey(1)
+		grantee   = getPrivKey(2)
+	)
+	newKey := func() *ecdsa.PrivateKey {
+		t.Helper()
+		k, err := crypto.GenerateSecp256k1Key()
+		require.NoError(t, err)
+		return k
+	}
+	grantee2 := newKey()
+	outsider := newKey()
+	controller := func(k *ecdsa.PrivateKey) *accesscontrol.ControllerStruct {
+		return accesscontrol.NewController(accesscontrol.NewLogic(accesscontrol.NewDefaultSession(k)))
+	}
+	pc, ac, gc, g2c, oc := controller(publisher), controller(admin), controller(grantee), controller(grantee2), controller(outsider)
+
+	_, eglRef, hRef, _, err := pc.CreateGroupHandler(ctx, ls, gls, &publisher.PublicKey, []*ecdsa.PublicKey{&admin.PublicKey}, []*ecdsa.PublicKey{&grantee.PublicKey})
+	require.NoError(t, err)
+
+	// content published by the creator of the group
+	ref1 := swarm.RandAddress(t)
+	_, href, encRef1, err := pc.UploadHandler(ctx, ls, ref1, &publisher.PublicKey, hRef)
+	require.NoError(t, err)
+	assert.Equal(t, hRef, href)
+
+	t.Run("admin lists the grantees", func(t *testing.T) {
+		grantees, err := ac.Get(ctx, ls, &admin.PublicKey, eglRef, hRef)
+		require.NoError(t, err)
+		assert.Equal(t, []*ecdsa.PublicKey{&grantee.PublicKey}, grantees)
+
+		_, err = oc.Get(ctx, ls, &outsider.PublicKey, eglRef, hRef)
+		assert.ErrorIs(t, err, accesscontrol.ErrNotAdmin)
+	})
+
+	t.Run("outsider cannot update", func(t *testing.T) {
+		_, _, _, _, err := oc.UpdateHandler(ctx, ls, gls, eglRef, hRef, &outsider.PublicKey, []*ecdsa.PublicKey{&outsider.PublicKey}, nil)
+		assert.ErrorIs(t, err, accesscontrol.ErrNotAdmin)
+	})
+
+	// the admin adds a grantee right away, the entry is still ordered after the latest one
+	_, eglRef2, hRef2, _, err := ac.UpdateHandler(ctx, ls, gls, eglRef, hRef, &admin.PublicKey, []*ecdsa.PublicKey{&grantee2.PublicKey}, nil)
+	require.NoError(t, err)
+	// the grantee list is encrypted with the same key for all admins
+	grantees, err := pc.Get(ctx, ls, &publisher.PublicKey, eglRef2, hRef2)
+	require.NoError(t, err)
+	assert.Len(t, grantees, 2)
+
+	h, err := accesscontrol.NewHistoryReference(ls, hRef2)
+	require.NoError(t, err)
+	_, ts1, err := h.Latest(ctx)
+	require.NoError(t, err)
+
+	// content published by the admin
+	ref2 := swarm.RandAddress(t)
+	_, _, encRef2, err := ac.UploadHandler(ctx, ls, ref2, &admin.PublicKey, hRef2)
+	require.NoError(t, err)
+
+	t.Run("grantees download", func(t *testing.T) {
+		// the publisher of the content is resolved from the history
+		dref, err := gc.DownloadHandler(ctx, ls, encRef1, &publisher.PublicKey, hRef2, ts1)
+		require.NoError(t, err)
+		assert.Equal(t, ref1, dref)
+
+		dref, err = g2c.DownloadHandler(ctx, ls, encRef2, &publisher.PublicKey, hRef2, ts1)
+		require.NoError(t, err)
+		assert.Equal(t, ref2, dref)
+
+		dref, err = pc.DownloadHandler(ctx, ls, encRef2, &publisher.PublicKey, hRef2, ts1)
+		require.NoError(t, err)
+		assert.Equal(t, ref2, dref)
+	})
+
+	t.Run("publisher revokes", func(t *testing.T) {
+		granteeRef, _, hRef3, _, err := pc.UpdateHandler(ctx, ls, gls, swarm.ZeroAddress, hRef2, &publisher.PublicKey, nil, []*ecdsa.PublicKey{&grantee.PublicKey})
+		require.NoError(t, err)
+		gl, err := accesscontrol.NewGranteeListReference(ctx, ls, granteeRef)
+		require.NoError(t, err)
+		assert.Equal(t, []*ecdsa.PublicKey{&grantee2.PublicKey}, gl.Get())
+
+		h, err := accesscontrol.NewHistoryReference(ls, hRef3)
+		require.NoError(t, err)
+		_, ts2, err := h.Latest(ctx)
+		require.NoError(t, err)
+		assert.Greater(t, ts2, ts1)
+
+		ref3 := swarm.RandAddress(t)
+		_, _, encRef3, err := ac.UploadHandler(ctx, ls, ref3, &admin.PublicKey, hRef3)
+		require.NoError(t, err)
+
+		_, err = gc.DownloadHandler(ctx, ls, encRef3, &publisher.PublicKey, hRef3, ts2)
+		assert.ErrorIs(t, err, accesscontrol.ErrNotFound)
+		// the content before the revocation is still available
+		dref, err := gc.DownloadHandler(ctx, ls, encRef1, &publisher.PublicKey, hRef3, ts1)
+		require.NoError(t, err)
+		assert.Equal(t, ref1, dref)
+
+		dref, err = g2c.DownloadHandler(ctx, ls, encRef3, &publisher.PublicKey, hRef3, ts2)
+		require.NoError(t, err)
+		assert.Equal(t, ref3, dref)
+	})
+}
@@ -29,12 +29,20 @@ var (
 	ErrNotFound = errors.New("access control: not found")
 )
 
+// HistoryIterFunc is called with the entries of the history and their timestamps,
+// the iteration stops if it returns true or an error.
+type HistoryIterFunc func(entry manifest.Entry, timestamp int64) (bool, error)
+
 // History represents the interface for managing access control history.
 type History interface {
 	// Add adds a new entry to the access control history with the given timestamp and metadata.
 	Add(ctx context.Context, ref swarm.Address, timestamp *int64, metadata *map[string]string) error
 	// Lookup retrieves the entry from the history based on the given timestamp or returns error if not found.
 	Lookup(ctx context.Context, timestamp int64) (manifest.Entry, error)
+	// Latest retrieves the entry with the latest timestamp and the timestamp itself.
+	Latest(ctx context.Context) (manifest.Entry, int64, error)
+	// Iterate calls fn for the entries of the history from the latest timestamp to the earliest one.
+	Iterate(ctx context.Context, fn HistoryIterFunc) error
 	// Store stores the history to the underlying storage and returns the reference.
 	Store(ctx context.Context) (swarm.Address, error)
 }
@@ -128,6 +136,59 @@ func (h *HistoryStruct) Lookup(ctx context.Context, timestamp int64) (manifest.E
 	return manifest.NewEntry(swarm.ZeroAddress, map[string]string{}), ErrNotFound
 }
 
+// Latest retrieves the entry with the latest timestamp and the timestamp itself.
+// The latest entry is the one that any later entry of the history is based on,
+// regardless of the clock of the node that added it.
+func (h *HistoryStruct) Latest(ctx context.Context) (manifest.Entry, int64, error) {
+	var (
+		latest    manifest.Entry
+		timestamp int64
+	)
+	err := h.Iterate(ctx, func(entry manifest.Entry, ts int64) (bool, error) {
+		latest, timestamp = entry, ts
+		return true, nil
+	})
+	if err != nil {
+		return manifest.NewEntry(swarm.ZeroAddress, map[string]string{}), 0, fmt.Errorf("history latest node error: %w", err)
+	}
+	if latest == nil {
+		return manifest.NewEntry(swarm.ZeroAddress, map[string]string{}), 0, ErrNotFound
+	}
+
+	return latest, timestamp, nil
+}
+
+// Iterate calls fn for the entries of the history from the latest timestamp to the earliest one,
+// until fn returns true or an error.
+func (h *HistoryStruct) Iterate(ctx context.Context, fn HistoryIterFunc) error {
+	walker := func(pathTimestamp []byte, currNode *mantaray.Node, err error) error {
+		if err != nil {
+			return err
+		}
+		if currNode.IsValueType() && len(currNode.Entry()) > 0 {
+			reversed, err := bytesToInt64(pathTimestamp)
+			if err != nil {
+				return err
+			}
+			// the keys are walked in ascending order, so the timestamps are descending
+			stop, err := fn(manifest.NewEntry(swarm.NewAddress(currNode.Entry()), currNode.Metadata()), math.MaxInt64-reversed)
+			if err != nil {
+				return err
+			}
+			if stop {
+				return ErrEndIteration
+			}
+		}
+		return nil
+	}
+
+	err := h.manifest.Root().WalkNode(ctx, []byte{}, h.ls, walker)
+	if err != nil && !errors.Is(err, ErrEndIteration) {
+		return err
+	}
+	return nil
+}
+
 func (h *HistoryStruct) lookupNode(ctx context.Context, searchedTimestamp int64) (*mantaray.Node, error) {
 	// before node's timestamp is the closest one that is less than or equal to the searched timestamp
 	// for instance: 2030, 2020, 1994 -> search for 2021 -> before is 2020
@@ -129,6 +129,36 @@ func TestMultiNodeHistoryLookup(t *testing.T) {
 	assert.True(t, reflect.DeepEqual(mtdt5, entry.Metadata()))
 }
 
+func TestHistoryLatest(t *testing.T) {
+	t.Parallel()
+	storer := mockstorer.New()
+	ctx := context.Background()
+	ls := loadsave.New(storer.ChunkStore(), storer.Cache(), pipelineFactory(storer.Cache(), false))
+
+	h, err := accesscontrol.NewHistory(ls)
+	assertNoError(t, "create history", err)
+
+	_, _, err = h.Latest(ctx)
+	assert.ErrorIs(t, err, accesscontrol.ErrNotFound)
+
+	latestRef := swarm.RandAddress(t)
+	latestTime := time.Date(2030, time.April, 1, 0, 0, 0, 0, time.UTC).Unix()
+	err = h.Add(ctx, latestRef, &latestTime, nil)
+	assertNoError(t, "1st history add", err)
+	for _, ts := range []int64{
+		time.Date(1994, time.April, 1, 0, 0, 0, 0, time.UTC).Unix(),
+		time.Date(2020, time.April, 1, 0, 0, 0, 0, time.UTC).Unix(),
+	} {
+		err = h.Add(ctx, swarm.RandAddress(t), &ts, nil)
+		assertNoError(t, "history add", err)
+	}
+
+	entry, timestamp, err := h.Latest(ctx)
+	assertNoError(t, "history latest", err)
+	assert.True(t, entry.Reference().Equal(latestRef))
+	assert.Equal(t, latestTime, timestamp)
+}
+
 func TestHistoryStore(t *testing.T) {
 	t.Parallel()
 	storer := mockstorer.New()
@@ -23,6 +23,7 @@ import (
 	"github.com/ethersphere/bee/v2/pkg/file/pipeline"
 	"github.com/ethersphere/bee/v2/pkg/file/pipeline/builder"
 	"github.com/ethersphere/bee/v2/pkg/file/redundancy"
+	"github.com/ethersphere/bee/v2/pkg/manifest"
 	"github.com/ethersphere/bee/v2/pkg/storage"
 	mockstorer "github.com/ethersphere/bee/v2/pkg/storer/mock"
 	"github.com/ethersphere/bee/v2/pkg/swarm"
@@ -155,6 +156,24 @@ func (m *mockController) UploadHandler(ctx context.Context, ls file.LoadSaver, r
 	return kvsRef, historyRef, swarm.NewAddress(encryptedRef), nil
 }
 
+func (m *mockController) AuditHandler(ctx context.Context, ls file.LoadSaver, historyRootHash swarm.Address, publisher, grantee *ecdsa.PublicKey) ([]accesscontrol.GranteeAccess, error) {
+	h, exists := m.historyMap[historyRootHash.String()]
+	if !exists {
+		return nil, accesscontrol.ErrNotFound
+	}
+	var report []accesscontrol.GranteeAccess
+	err := h.Iterate(ctx, func(_ manifest.Entry, timestamp int64) (bool, error) {
+		report = append(report, accesscontrol.GranteeAccess{
+			Timestamp: timestamp,
+			Publisher: publisher,
+			Verified:  true,
+			Granted:   m.acceptAll,
+		})
+		return false, nil
+	})
+	return report, err
+}
+
 func (m *mockController) Close() error {
 	return nil
 }
@@ -170,7 +189,15 @@ func (m *mockController) UpdateHandler(_ context.Context, ls file.LoadSaver, gls
 	return glRef, eglRef, historyRef, actref, nil
 }
 
-func (m *mockController) Get(ctx context.Context, ls file.LoadSaver, publisher *ecdsa.PublicKey, encryptedglref swarm.Address) ([]*ecdsa.PublicKey, error) {
+func (m *mockController) CreateGroupHandler(_ context.Context, ls file.LoadSaver, gls file.LoadSaver, publisher *ecdsa.PublicKey, admins, addList []*ecdsa.PublicKey) (swarm.Address, swarm.Address, swarm.Address, swarm.Address, error) {
+	historyRef, _ := swarm.ParseHexAddress("67bdf80a9bbea8eca9c8480e43fdceb485d2d74d5708e45144b8c4adacd13d9c")
+	glRef, _ := swarm.ParseHexAddress("3339613565613837623134316665343461613630396333333237656364383934")
+	eglRef, _ := swarm.ParseHexAddress("fc4e9fe978991257b897d987bc4ff13058b66ef45a53189a0b4fe84bb3346396")
+	actref, _ := swarm.ParseHexAddress("39a5ea87b141fe44aa609c3327ecd896c0e2122897f5f4bbacf74db1033c5559")
+	return glRef, eglRef, historyRef, actref, nil
+}
+
+func (m *mockController) Get(ctx context.Context, ls file.LoadSaver, publisher *ecdsa.PublicKey, encryptedglref swarm.Address, historyref swarm.Address) ([]*ecdsa.PublicKey, error) {
 	if m.publisher == "" {
 		return nil, fmt.Errorf("granteelist not found")
 	}
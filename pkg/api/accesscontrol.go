@@ -12,6 +12,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"math"
 	"net/http"
 	"time"
 
@@ -53,6 +54,9 @@ type GranteesPatchRequest struct {
 
 	// Revokelist contains the list of grantees to revoke.
 	Revokelist []string `json:"revoke"`
+
+	// Reencrypt contains the encrypted references to re-encrypt with the access key of the updated history.
+	Reencrypt []swarm.Address `json:"reencrypt,omitempty"`
 }
 
 // GranteesPatchResponse represents the response structure for patching grantees.
@@ -61,12 +65,16 @@ type GranteesPatchResponse struct {
 	Reference swarm.Address `json:"ref"`
 	// HistoryReference represents the reference to the history of an access control entry.
 	HistoryReference swarm.Address `json:"historyref"`
+	// Reencrypted represents the re-encrypted references in the order of the request.
+	Reencrypted []swarm.Address `json:"reencrypted,omitempty"`
 }
 
 // GranteesPostRequest represents the request structure for adding grantees.
 type GranteesPostRequest struct {
 	// GranteeList represents the list of grantees to be saves on Swarm.
 	GranteeList []string `json:"grantees"`
+	// Admins represents the list of publishers who can manage the grantees besides the node.
+	Admins []string `json:"admins,omitempty"`
 }
 
 // GranteesPostResponse represents the response structure for adding grantees.
@@ -77,6 +85,24 @@ type GranteesPostResponse struct {
 	HistoryReference swarm.Address `json:"historyref"`
 }
 
+// GranteeAccessResponse represents the access of a grantee to a history entry.
+type GranteeAccessResponse struct {
+	// Timestamp represents the timestamp of the history entry.
+	Timestamp int64 `json:"timestamp"`
+	// Publisher represents the public key of the publisher who wrote the ACT of the entry.
+	Publisher string `json:"publisher"`
+	// Verified represents whether the access could be checked by the node.
+	Verified bool `json:"verified"`
+	// Granted represents whether the grantee has a lookup key in the ACT of the entry.
+	Granted bool `json:"granted"`
+}
+
+// GranteeAuditResponse represents the response structure for auditing a grantee.
+type GranteeAuditResponse struct {
+	// Entries represents the access of the grantee from the latest history entry to the earliest one.
+	Entries []GranteeAccessResponse `json:"entries"`
+}
+
 // GranteesPatch represents a structure for modifying the list of grantees.
 type GranteesPatch struct {
 	// Addlist is a list of ecdsa.PublicKeys to be added to a grantee list.
@@ -183,7 +209,7 @@ func (s *Service) actEncryptionHandler(
 }
 
 // actListGranteesHandler is a middleware that decrypts the given address and returns the list of grantees,
-// only the publisher is authorized to access the list.
+// only the publisher or the admins of a group managed history are authorized to access the list.
 func (s *Service) actListGranteesHandler(w http.ResponseWriter, r *http.Request) {
 	logger := s.logger.WithName("act_list_grantees_handler").Build()
 	paths := struct {
@@ -195,7 +221,8 @@ func (s *Service) actListGranteesHandler(w http.ResponseWriter, r *http.Request)
 	}
 
 	headers := struct {
-		Cache *bool `map:"Swarm-Cache"`
+		Cache          *bool          `map:"Swarm-Cache"`
+		HistoryAddress *swarm.Address `map:"Swarm-Act-History-Address"`
 	}{}
 	if response := s.mapStructure(r.Header, &headers); response != nil {
 		response("invalid header params", logger, w)
@@ -205,13 +232,22 @@ func (s *Service) actListGranteesHandler(w http.ResponseWriter, r *http.Request)
 	if headers.Cache != nil {
 		cache = *headers.Cache
 	}
+	historyAddress := swarm.ZeroAddress
+	if headers.HistoryAddress != nil {
+		historyAddress = *headers.HistoryAddress
+	}
 	publisher := &s.publicKey
 	ls := loadsave.NewReadonly(s.storer.Download(cache))
-	grantees, err := s.accesscontrol.Get(r.Context(), ls, publisher, paths.GranteesAddress)
+	grantees, err := s.accesscontrol.Get(r.Context(), ls, publisher, paths.GranteesAddress, historyAddress)
 	if err != nil {
 		logger.Debug("could not get grantees", "error", err)
 		logger.Error(nil, "could not get grantees")
-		jsonhttp.NotFound(w, "granteelist not found")
+		switch {
+		case errors.Is(err, accesscontrol.ErrNotAdmin):
+			jsonhttp.Forbidden(w, "not an admin of the history")
+		default:
+			jsonhttp.NotFound(w, "granteelist not found")
+		}
 		return
 	}
 	granteeSlice := make([]string, len(grantees))
@@ -221,8 +257,60 @@ func (s *Service) actListGranteesHandler(w http.ResponseWriter, r *http.Request)
 	jsonhttp.OK(w, granteeSlice)
 }
 
+// actAuditGranteeHandler reports for every entry of the history whether the grantee has access to it.
+func (s *Service) actAuditGranteeHandler(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.WithName("act_audit_grantee_handler").Build()
+	paths := struct {
+		Grantee *ecdsa.PublicKey `map:"grantee" validate:"required"`
+	}{}
+	if response := s.mapStructure(mux.Vars(r), &paths); response != nil {
+		response("invalid path params", logger, w)
+		return
+	}
+
+	headers := struct {
+		Cache          *bool          `map:"Swarm-Cache"`
+		HistoryAddress *swarm.Address `map:"Swarm-Act-History-Address" validate:"required"`
+	}{}
+	if response := s.mapStructure(r.Header, &headers); response != nil {
+		response("invalid header params", logger, w)
+		return
+	}
+	cache := true
+	if headers.Cache != nil {
+		cache = *headers.Cache
+	}
+
+	ls := loadsave.NewReadonly(s.storer.Download(cache))
+	report, err := s.accesscontrol.AuditHandler(r.Context(), ls, *headers.HistoryAddress, &s.publicKey, paths.Grantee)
+	if err != nil {
+		logger.Debug("audit grantee failed", "error", err)
+		logger.Error(nil, "audit grantee failed")
+		switch {
+		case errors.Is(err, accesscontrol.ErrNotFound), errors.Is(err, storage.ErrNotFound):
+			jsonhttp.NotFound(w, "history not found")
+		case errors.Is(err, accesscontrol.ErrInvalidPublicKey):
+			jsonhttp.BadRequest(w, "invalid history")
+		default:
+			jsonhttp.InternalServerError(w, "audit grantee failed")
+		}
+		return
+	}
+
+	res := GranteeAuditResponse{Entries: make([]GranteeAccessResponse, 0, len(report))}
+	for _, access := range report {
+		res.Entries = append(res.Entries, GranteeAccessResponse{
+			Timestamp: access.Timestamp,
+			Publisher: hex.EncodeToString(crypto.EncodeSecp256k1PublicKey(access.Publisher)),
+			Verified:  access.Verified,
+			Granted:   access.Granted,
+		})
+	}
+	jsonhttp.OK(w, res)
+}
+
 // actGrantRevokeHandler is a middleware that makes updates to the list of grantees,
-// only the publisher is authorized to perform this action.
+// only the publisher or the admins of a group managed history are authorized to perform this action.
 func (s *Service) actGrantRevokeHandler(w http.ResponseWriter, r *http.Request) {
 	logger := s.logger.WithName("act_grant_revoke_handler").Build()
 
@@ -357,6 +445,8 @@ func (s *Service) actGrantRevokeHandler(w http.ResponseWriter, r *http.Request)
 			jsonhttp.NotFound(w, "act or history entry not found")
 		case errors.Is(err, accesscontrol.ErrNoGranteeFound):
 			jsonhttp.BadRequest(w, "remove from empty grantee list")
+		case errors.Is(err, accesscontrol.ErrNotAdmin):
+			jsonhttp.Forbidden(w, "not an admin of the history")
 		case errors.Is(err, accesscontrol.ErrUnexpectedType):
 			jsonhttp.BadRequest(w, "failed to create history")
 		default:
@@ -365,6 +455,28 @@ func (s *Service) actGrantRevokeHandler(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	// the references readable with the access key before the update are carried over
+	// to the new access key, so the revoked grantees cannot follow them anymore
+	reencrypted := make([]swarm.Address, 0, len(gpr.Reencrypt))
+	for _, encryptedRef := range gpr.Reencrypt {
+		// the greatest timestamp looks up the latest entry before the update
+		ref, err := s.accesscontrol.DownloadHandler(ctx, ls, encryptedRef, publisher, historyAddress, math.MaxInt64)
+		if err != nil {
+			logger.Debug("decrypt reference failed", "reference", encryptedRef, "error", err)
+			logger.Error(nil, "decrypt reference failed")
+			jsonhttp.BadRequest(w, "invalid reencrypt list")
+			return
+		}
+		_, _, newRef, err := s.accesscontrol.UploadHandler(ctx, ls, ref, publisher, historyref)
+		if err != nil {
+			logger.Debug("encrypt reference failed", "reference", encryptedRef, "error", err)
+			logger.Error(nil, "encrypt reference failed")
+			jsonhttp.InternalServerError(w, "encrypt reference failed")
+			return
+		}
+		reencrypted = append(reencrypted, newRef)
+	}
+
 	err = putter.Done(actref)
 	if err != nil {
 		logger.Debug("done split act failed", "error", err)
@@ -392,11 +504,13 @@ func (s *Service) actGrantRevokeHandler(w http.ResponseWriter, r *http.Request)
 	jsonhttp.OK(w, GranteesPatchResponse{
 		Reference:        encryptedglref,
 		HistoryReference: historyref,
+		Reencrypted:      reencrypted,
 	})
 }
 
 // actCreateGranteesHandler is a middleware that creates a new list of grantees,
-// only the publisher is authorized to perform this action.
+// only the publisher is authorized to perform this action. If admins are given,
+// the new history is managed by the group of the publisher and the admins.
 func (s *Service) actCreateGranteesHandler(w http.ResponseWriter, r *http.Request) {
 	logger := s.logger.WithName("acthandler").Build()
 
@@ -474,6 +588,18 @@ func (s *Service) actCreateGranteesHandler(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
+	admins, err := parseKeys(gpr.Admins)
+	if err != nil {
+		logger.Debug("admin list key parse failed", "error", err)
+		logger.Error(nil, "admin list key parse failed")
+		jsonhttp.BadRequest(w, "invalid admin list")
+		return
+	}
+	if len(admins) > 0 && !historyAddress.IsZero() {
+		jsonhttp.BadRequest(w, "admins can only be set for a new history")
+		return
+	}
+
 	ctx := r.Context()
 	putter, err := s.newStamperPutter(ctx, putterOptions{
 		BatchID:  headers.BatchID,
@@ -502,7 +628,12 @@ func (s *Service) actCreateGranteesHandler(w http.ResponseWriter, r *http.Reques
 	publisher := &s.publicKey
 	ls := loadsave.New(s.storer.Download(true), s.storer.Cache(), requestPipelineFactory(ctx, putter, false, redundancy.NONE))
 	gls := loadsave.New(s.storer.Download(true), s.storer.Cache(), requestPipelineFactory(ctx, putter, granteeListEncrypt, redundancy.NONE))
-	granteeref, encryptedglref, historyref, actref, err := s.accesscontrol.UpdateHandler(ctx, ls, gls, swarm.ZeroAddress, historyAddress, publisher, list, nil)
+	var granteeref, encryptedglref, historyref, actref swarm.Address
+	if len(admins) > 0 {
+		granteeref, encryptedglref, historyref, actref, err = s.accesscontrol.CreateGroupHandler(ctx, ls, gls, publisher, admins, list)
+	} else {
+		granteeref, encryptedglref, historyref, actref, err = s.accesscontrol.UpdateHandler(ctx, ls, gls, swarm.ZeroAddress, historyAddress, publisher, list, nil)
+	}
 	if err != nil {
 		logger.Debug("failed to create grantee list", "error", err)
 		logger.Error(nil, "failed to create grantee list")
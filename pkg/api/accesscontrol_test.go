@@ -992,6 +992,70 @@ func TestAccessLogicGrantees(t *testing.T) {
 		)
 	})
 
+	t.Run("revoke-grantee-reencrypt", func(t *testing.T) {
+		clientAcceptAll, _, _, _ := newTestServer(t, testServerOptions{
+			Storer:        storerMock,
+			Logger:        logger,
+			Post:          mockpost.New(mockpost.WithAcceptAll()),
+			PublicKey:     pk.PublicKey,
+			AccessControl: mockac.New(mockac.WithAcceptAll()),
+		})
+		encryptedRef, _ := swarm.ParseHexAddress("fc4e9fe978991257b897d987bc4ff13058b66ef45a53189a0b4fe84bb3346396")
+		body := api.GranteesPatchRequest{
+			Revokelist: []string{"02ab7473879005929d10ce7d4f626412dad9fe56b0a6622038931d26bd79abf0a4"},
+			Reencrypt:  []swarm.Address{addr},
+		}
+		jsonhttptest.Request(t, clientAcceptAll, http.MethodPatch, "/grantee/"+addr.String(), http.StatusOK,
+			jsonhttptest.WithRequestHeader(api.SwarmPostageBatchIdHeader, batchOkStr),
+			jsonhttptest.WithRequestHeader(api.SwarmActHistoryAddressHeader, addr.String()),
+			jsonhttptest.WithJSONRequestBody(body),
+			jsonhttptest.WithExpectedJSONResponse(api.GranteesPatchResponse{
+				Reference:        encryptedRef,
+				HistoryReference: swarm.MustParseHexAddress("67bdf80a9bbea8eca9c8480e43fdceb485d2d74d5708e45144b8c4adacd13d9c"),
+				Reencrypted:      []swarm.Address{encryptedRef},
+			}),
+		)
+	})
+
+	t.Run("audit-grantee", func(t *testing.T) {
+		publisher := hex.EncodeToString(crypto.EncodeSecp256k1PublicKey(&pk.PublicKey))
+		grantee := "02ab7473879005929d10ce7d4f626412dad9fe56b0a6622038931d26bd79abf0a4"
+		expected := api.GranteeAuditResponse{}
+		for _, year := range []int{2030, 2020, 2015, 2000, 1994} {
+			expected.Entries = append(expected.Entries, api.GranteeAccessResponse{
+				Timestamp: time.Date(year, time.April, 1, 0, 0, 0, 0, time.UTC).Unix(),
+				Publisher: publisher,
+				Verified:  true,
+			})
+		}
+		jsonhttptest.Request(t, client, http.MethodGet, "/grantee/"+grantee+"/access", http.StatusOK,
+			jsonhttptest.WithRequestHeader(api.SwarmActHistoryAddressHeader, fixtureHref.String()),
+			jsonhttptest.WithExpectedJSONResponse(expected),
+		)
+		jsonhttptest.Request(t, client, http.MethodGet, "/grantee/"+grantee+"/access", http.StatusNotFound,
+			jsonhttptest.WithRequestHeader(api.SwarmActHistoryAddressHeader, addr.String()),
+			jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
+				Message: "history not found",
+				Code:    http.StatusNotFound,
+			}),
+		)
+		jsonhttptest.Request(t, client, http.MethodGet, "/grantee/"+grantee+"/access", http.StatusBadRequest,
+			jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
+				Message: "invalid header params",
+				Code:    http.StatusBadRequest,
+				Reasons: []jsonhttp.Reason{
+					{
+						Field: "swarm-act-history-address",
+						Error: "want required:",
+					},
+				},
+			}),
+		)
+		jsonhttptest.Request(t, client, http.MethodGet, "/grantee/asd/access", http.StatusBadRequest,
+			jsonhttptest.WithRequestHeader(api.SwarmActHistoryAddressHeader, fixtureHref.String()),
+		)
+	})
+
 	t.Run("create-granteelist", func(t *testing.T) {
 		body := api.GranteesPostRequest{
 			GranteeList: []string{
@@ -1038,4 +1102,47 @@ func TestAccessLogicGrantees(t *testing.T) {
 			jsonhttptest.WithJSONRequestBody(body),
 		)
 	})
+	t.Run("create-group-granteelist", func(t *testing.T) {
+		body := api.GranteesPostRequest{
+			GranteeList: []string{
+				"02ab7473879005929d10ce7d4f626412dad9fe56b0a6622038931d26bd79abf0a4",
+			},
+			Admins: []string{
+				"03d7660772cc3142f8a7a2dfac46ce34d12eac1718720cef0e3d94347902aa96a2",
+			},
+		}
+		jsonhttptest.Request(t, client, http.MethodPost, "/grantee", http.StatusCreated,
+			jsonhttptest.WithRequestHeader(api.SwarmPostageBatchIdHeader, batchOkStr),
+			jsonhttptest.WithJSONRequestBody(body),
+		)
+	})
+	t.Run("create-group-granteelist-invalid-admins", func(t *testing.T) {
+		body := api.GranteesPostRequest{
+			Admins: []string{"random-string"},
+		}
+		jsonhttptest.Request(t, client, http.MethodPost, "/grantee", http.StatusBadRequest,
+			jsonhttptest.WithRequestHeader(api.SwarmPostageBatchIdHeader, batchOkStr),
+			jsonhttptest.WithJSONRequestBody(body),
+			jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
+				Message: "invalid admin list",
+				Code:    http.StatusBadRequest,
+			}),
+		)
+	})
+	t.Run("create-group-granteelist-with-history", func(t *testing.T) {
+		body := api.GranteesPostRequest{
+			Admins: []string{
+				"03d7660772cc3142f8a7a2dfac46ce34d12eac1718720cef0e3d94347902aa96a2",
+			},
+		}
+		jsonhttptest.Request(t, client, http.MethodPost, "/grantee", http.StatusBadRequest,
+			jsonhttptest.WithRequestHeader(api.SwarmPostageBatchIdHeader, batchOkStr),
+			jsonhttptest.WithRequestHeader(api.SwarmActHistoryAddressHeader, swarm.RandAddress(t).String()),
+			jsonhttptest.WithJSONRequestBody(body),
+			jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
+				Message: "admins can only be set for a new history",
+				Code:    http.StatusBadRequest,
+			}),
+		)
+	})
 }
@@ -28,6 +28,7 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethersphere/bee/v2/pkg/accesscontrol"
 	"github.com/ethersphere/bee/v2/pkg/accounting"
+	"github.com/ethersphere/bee/v2/pkg/auth"
 	"github.com/ethersphere/bee/v2/pkg/crypto"
 	"github.com/ethersphere/bee/v2/pkg/feeds"
 	"github.com/ethersphere/bee/v2/pkg/file/pipeline"
@@ -38,6 +39,7 @@ import (
 	"github.com/ethersphere/bee/v2/pkg/log"
 	"github.com/ethersphere/bee/v2/pkg/p2p"
 	"github.com/ethersphere/bee/v2/pkg/pingpong"
+	"github.com/ethersphere/bee/v2/pkg/pinner"
 	"github.com/ethersphere/bee/v2/pkg/postage"
 	"github.com/ethersphere/bee/v2/pkg/postage/postagecontract"
 	"github.com/ethersphere/bee/v2/pkg/pss"
@@ -100,6 +102,7 @@ const (
 	ETagHeader      = "ETag"
 
 	AuthorizationHeader      = "Authorization"
+	AcceptHeader             = "Accept"
 	AcceptEncodingHeader     = "Accept-Encoding"
 	ContentTypeHeader        = "Content-Type"
 	ContentDispositionHeader = "Content-Disposition"
@@ -111,6 +114,7 @@ const (
 const (
 	multiPartFormData  = "multipart/form-data"
 	contentTypeTar     = "application/x-tar"
+	contentTypeZip     = "application/zip"
 	boolHeaderSetValue = "true"
 )
 
@@ -155,11 +159,13 @@ type Service struct {
 	pss             pss.Interface
 	gsoc            gsoc.Listener
 	steward         steward.Interface
+	pinner          pinner.Interface
 	logger          log.Logger
 	loggerV1        log.Logger
 	tracer          *tracing.Tracer
 	feedFactory     feeds.Factory
 	signer          crypto.Signer
+	auth            auth.Authenticator
 	post            postage.Service
 	accesscontrol   accesscontrol.Controller
 	postageContract postagecontract.Interface
@@ -176,6 +182,9 @@ type Service struct {
 	wsWg sync.WaitGroup // wait for all websockets to close on exit
 	quit chan struct{}
 
+	gsocPollers   map[[32]byte]*gsocPoller
+	gsocPollersMu sync.Mutex
+
 	overlay           *swarm.Address
 	publicKey         ecdsa.PublicKey
 	pssPublicKey      ecdsa.PublicKey
@@ -206,6 +215,7 @@ type Service struct {
 	postageSem       *semaphore.Weighted
 	stakingSem       *semaphore.Weighted
 	cashOutChequeSem *semaphore.Weighted
+	zipUploadSem     *semaphore.Weighted
 	beeMode          BeeNodeMode
 
 	chainBackend transaction.Backend
@@ -264,6 +274,7 @@ type ExtraOptions struct {
 	PostageContract postagecontract.Interface
 	Staking         staking.Contract
 	Steward         steward.Interface
+	Pinner          pinner.Interface
 	SyncStatus      func() (bool, error)
 	NodeStatus      *status.Service
 	PinIntegrity    PinIntegrity
@@ -347,6 +358,7 @@ func (s *Service) Configure(signer crypto.Signer, tracer *tracing.Tracer, o Opti
 	s.accesscontrol = e.AccessControl
 	s.postageContract = e.PostageContract
 	s.steward = e.Steward
+	s.pinner = e.Pinner
 	s.stakingContract = e.Staking
 
 	s.pingpong = e.Pingpong
@@ -362,6 +374,7 @@ func (s *Service) Configure(signer crypto.Signer, tracer *tracing.Tracer, o Opti
 	s.postageSem = semaphore.NewWeighted(1)
 	s.stakingSem = semaphore.NewWeighted(1)
 	s.cashOutChequeSem = semaphore.NewWeighted(1)
+	s.zipUploadSem = semaphore.NewWeighted(zipUploadMaxConcurrent)
 
 	s.chainID = chainID
 	s.erc20Service = erc20
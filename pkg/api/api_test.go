@@ -27,6 +27,7 @@ import (
 	mockac "github.com/ethersphere/bee/v2/pkg/accesscontrol/mock"
 	accountingmock "github.com/ethersphere/bee/v2/pkg/accounting/mock"
 	"github.com/ethersphere/bee/v2/pkg/api"
+	"github.com/ethersphere/bee/v2/pkg/auth"
 	"github.com/ethersphere/bee/v2/pkg/crypto"
 	"github.com/ethersphere/bee/v2/pkg/feeds"
 	"github.com/ethersphere/bee/v2/pkg/file/pipeline"
@@ -37,6 +38,7 @@ import (
 	"github.com/ethersphere/bee/v2/pkg/log"
 	p2pmock "github.com/ethersphere/bee/v2/pkg/p2p/mock"
 	"github.com/ethersphere/bee/v2/pkg/pingpong"
+	"github.com/ethersphere/bee/v2/pkg/pinner"
 	"github.com/ethersphere/bee/v2/pkg/postage"
 	mockbatchstore "github.com/ethersphere/bee/v2/pkg/postage/batchstore/mock"
 	mockpost "github.com/ethersphere/bee/v2/pkg/postage/mock"
@@ -106,9 +108,11 @@ type testServerOptions struct {
 	Post               postage.Service
 	AccessControl      accesscontrol.Controller
 	Steward            steward.Interface
+	Pinner             pinner.Interface
 	WsHeaders          http.Header
 	DirectUpload       bool
 	Probe              *api.Probe
+	Signer             crypto.Signer
 
 	Overlay         swarm.Address
 	PublicKey       ecdsa.PublicKey
@@ -134,14 +138,18 @@ type testServerOptions struct {
 	PinIntegrity        api.PinIntegrity
 	WhitelistedAddr     string
 	FullAPIDisabled     bool
+	Authenticator       auth.Authenticator
 	ChequebookDisabled  bool
 	SwapDisabled        bool
 }
 
 func newTestServer(t *testing.T, o testServerOptions) (*http.Client, *websocket.Conn, string, *chanStorer) {
 	t.Helper()
-	pk, _ := crypto.GenerateSecp256k1Key()
-	signer := crypto.NewDefaultSigner(pk)
+	signer := o.Signer
+	if signer == nil {
+		pk, _ := crypto.GenerateSecp256k1Key()
+		signer = crypto.NewDefaultSigner(pk)
+	}
 
 	if o.Logger == nil {
 		o.Logger = log.Noop
@@ -202,6 +210,7 @@ func newTestServer(t *testing.T, o testServerOptions) (*http.Client, *websocket.
 		AccessControl:   o.AccessControl,
 		PostageContract: o.PostageContract,
 		Steward:         o.Steward,
+		Pinner:          o.Pinner,
 		SyncStatus:      o.SyncStatus,
 		Staking:         o.StakingContract,
 		NodeStatus:      o.NodeStatus,
@@ -226,6 +235,7 @@ func newTestServer(t *testing.T, o testServerOptions) (*http.Client, *websocket.
 
 	s.SetSwarmAddress(&o.Overlay)
 	s.SetProbe(o.Probe)
+	s.SetAuthenticator(o.Authenticator)
 
 	noOpTracer, tracerCloser, _ := tracing.NewTracer(&tracing.Options{
 		Enabled: false,
@@ -0,0 +1,212 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/ethersphere/bee/v2/pkg/file/joiner"
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp"
+	"github.com/ethersphere/bee/v2/pkg/log"
+	"github.com/ethersphere/bee/v2/pkg/manifest"
+	storage "github.com/ethersphere/bee/v2/pkg/storage"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+	"github.com/ethersphere/langos"
+)
+
+const (
+	archiveFormatTar = "tar"
+	archiveFormatZip = "zip"
+)
+
+var errInvalidArchiveFormat = errors.New("invalid archive format")
+
+// archiveFormat returns the archive format requested by the archive query
+// parameter. It returns an empty string if the request does not ask for an
+// archive.
+func archiveFormat(r *http.Request) (string, error) {
+	switch v := r.URL.Query().Get("archive"); v {
+	case "", archiveFormatTar, archiveFormatZip:
+		return v, nil
+	default:
+		return "", errInvalidArchiveFormat
+	}
+}
+
+// acceptedArchiveFormat returns the archive format requested by the Accept
+// header. It returns an empty string if no archive media type is accepted.
+func acceptedArchiveFormat(r *http.Request) string {
+	for _, v := range strings.Split(r.Header.Get(AcceptHeader), ",") {
+		mediaType, _, err := mime.ParseMediaType(strings.TrimSpace(v))
+		if err != nil {
+			continue
+		}
+		switch mediaType {
+		case contentTypeTar:
+			return archiveFormatTar
+		case contentTypeZip:
+			return archiveFormatZip
+		}
+	}
+	return ""
+}
+
+// isDirectoryPath reports whether the path of the manifest m is a directory
+// prefix rather than a file entry, so that the archive can be negotiated by
+// the Accept header without shadowing the stored tar or zip files.
+func isDirectoryPath(ctx context.Context, m manifest.Interface, pathVar string) bool {
+	if pathVar == "" || strings.HasSuffix(pathVar, "/") {
+		return true
+	}
+	_, err := m.Lookup(ctx, pathVar)
+	return errors.Is(err, manifest.ErrNotFound)
+}
+
+// errArchiveHeaderOnly stops the iteration of the archive entries once the
+// headers of a HEAD request are written.
+var errArchiveHeaderOnly = errors.New("archive header only")
+
+// serveArchive streams all the files of the manifest m under the given path
+// as a tar or zip archive. The paths of the files in the archive are relative
+// to the given path.
+func (s *Service) serveArchive(
+	ctx context.Context,
+	logger log.Logger,
+	w http.ResponseWriter,
+	m manifest.Interface,
+	address swarm.Address,
+	pathVar string,
+	format string,
+	g storage.Getter,
+	headerOnly bool,
+) {
+	prefix := pathVar
+	if prefix != "" && !strings.HasSuffix(prefix, "/") {
+		prefix += "/"
+	}
+
+	// the archive is started with the first entry, the status is sent with
+	// the first write, so the errors from then on can only be logged and the
+	// archive is truncated
+	var aw archiveWriter
+	err := m.IterateEntries(ctx, prefix, func(path string, e manifest.Entry) error {
+		// skip the entries that carry only metadata, like the root entry
+		if e.Reference().IsZero() || e.Reference().IsEmpty() {
+			return nil
+		}
+
+		if aw == nil {
+			contentType := contentTypeTar
+			if format == archiveFormatZip {
+				contentType = contentTypeZip
+			}
+			w.Header().Set(ContentTypeHeader, contentType)
+			w.Header().Set(ContentDispositionHeader, fmt.Sprintf("attachment; filename=\"%s.%s\"", address, format))
+			w.Header().Set("Access-Control-Expose-Headers", ContentDispositionHeader)
+			if headerOnly {
+				w.WriteHeader(http.StatusOK)
+				return errArchiveHeaderOnly
+			}
+
+			if format == archiveFormatZip {
+				aw = &zipArchiveWriter{zip.NewWriter(w)}
+			} else {
+				aw = &tarArchiveWriter{tar.NewWriter(w)}
+			}
+		}
+
+		path = strings.TrimPrefix(path, prefix)
+		reader, size, err := joiner.New(ctx, g, s.storer.Cache(), e.Reference())
+		if err != nil {
+			return fmt.Errorf("join %s: %w", path, err)
+		}
+		rd := io.Reader(reader)
+		if bufSize := lookaheadBufferSize(size); size > int64(bufSize) {
+			rd = langos.NewBufferedLangos(reader, bufSize)
+		}
+		if err := aw.WriteFile(path, size, rd); err != nil {
+			return fmt.Errorf("write %s: %w", path, err)
+		}
+		return nil
+	})
+	switch {
+	case errors.Is(err, errArchiveHeaderOnly):
+		return
+	case err != nil && aw == nil:
+		logger.Debug("bzz download: iterate manifest entries failed", "address", address, "error", err)
+		logger.Error(nil, "bzz download: iterate manifest entries failed")
+		jsonhttp.NotFound(w, "manifest not found")
+		return
+	case err != nil:
+		logger.Debug("bzz download: archive write failed", "address", address, "error", err)
+		logger.Error(nil, "bzz download: archive write failed")
+		return
+	case aw == nil:
+		jsonhttp.NotFound(w, "path address not found")
+		return
+	}
+
+	if err := aw.Close(); err != nil {
+		logger.Debug("bzz download: archive close failed", "address", address, "error", err)
+		logger.Error(nil, "bzz download: archive close failed")
+	}
+}
+
+// archiveWriter writes the files of a collection into an archive.
+type archiveWriter interface {
+	WriteFile(path string, size int64, r io.Reader) error
+	Close() error
+}
+
+type tarArchiveWriter struct {
+	w *tar.Writer
+}
+
+func (t *tarArchiveWriter) WriteFile(path string, size int64, r io.Reader) error {
+	hdr := &tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     path,
+		Mode:     0644,
+		Size:     size,
+	}
+	if err := t.w.WriteHeader(hdr); err != nil {
+		return err
+	}
+	_, err := io.CopyN(t.w, r, size)
+	return err
+}
+
+func (t *tarArchiveWriter) Close() error {
+	return t.w.Close()
+}
+
+type zipArchiveWriter struct {
+	w *zip.Writer
+}
+
+func (z *zipArchiveWriter) WriteFile(path string, size int64, r io.Reader) error {
+	hdr := &zip.FileHeader{
+		Name:   path,
+		Method: zip.Deflate,
+	}
+	fw, err := z.w.CreateHeader(hdr)
+	if err != nil {
+		return err
+	}
+	_, err = io.CopyN(fw, r, size)
+	return err
+}
+
+func (z *zipArchiveWriter) Close() error {
+	return z.w.Close()
+}
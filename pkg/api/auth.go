@@ -0,0 +1,191 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ethersphere/bee/v2/pkg/auth"
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp"
+	"github.com/gorilla/mux"
+)
+
+const authBearerPrefix = "Bearer "
+
+var errAuthDisabled = errors.New("authentication is not enabled")
+
+type authTokenRequest struct {
+	Scopes []auth.Scope `json:"scopes"`
+	Expiry int64        `json:"expiry"` // seconds
+}
+
+type authTokenResponse struct {
+	Key    string       `json:"key,omitempty"`
+	ID     string       `json:"id"`
+	Scopes []auth.Scope `json:"scopes"`
+	Expiry time.Time    `json:"expiry"`
+}
+
+type authTokensResponse struct {
+	Tokens []authTokenResponse `json:"tokens"`
+}
+
+// SetAuthenticator enables the permission checks on the API routes.
+func (s *Service) SetAuthenticator(a auth.Authenticator) {
+	if s != nil {
+		s.auth = a
+	}
+}
+
+// authorize checks that the bearer token of the request grants the scope.
+func (s *Service) authorize(r *http.Request, scope auth.Scope) (*auth.Token, error) {
+	h := r.Header.Get(AuthorizationHeader)
+	if !strings.HasPrefix(h, authBearerPrefix) {
+		return nil, auth.ErrInvalidToken
+	}
+	return s.auth.Authorize(strings.TrimPrefix(h, authBearerPrefix), scope)
+}
+
+// authErrorResponse writes the response for the error returned by authorize.
+func (s *Service) authErrorResponse(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, auth.ErrForbidden):
+		jsonhttp.Forbidden(w, "insufficient permissions")
+	case errors.Is(err, auth.ErrInvalidToken),
+		errors.Is(err, auth.ErrExpiredToken),
+		errors.Is(err, auth.ErrRevokedToken):
+		w.Header().Set("WWW-Authenticate", "Bearer")
+		jsonhttp.Unauthorized(w, err.Error())
+	default:
+		s.logger.Debug("authorize failed", "error", err)
+		s.logger.Error(nil, "authorize failed")
+		jsonhttp.InternalServerError(w, "authorize failed")
+	}
+}
+
+// authTokenPostHandler issues a new token. The request is authenticated
+// either with the admin password as basic auth or with an admin token.
+func (s *Service) authTokenPostHandler(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.WithName("post_auth").Build()
+
+	if s.auth == nil {
+		jsonhttp.NotImplemented(w, errAuthDisabled)
+		return
+	}
+
+	if _, password, ok := r.BasicAuth(); ok {
+		if !s.auth.Authenticate(password) {
+			w.Header().Set("WWW-Authenticate", "Basic")
+			jsonhttp.Unauthorized(w, "invalid password")
+			return
+		}
+	} else if _, err := s.authorize(r, auth.ScopeAdmin); err != nil {
+		s.authErrorResponse(w, err)
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		if jsonhttp.HandleBodyReadError(err, w) {
+			return
+		}
+		logger.Debug("read request body failed", "error", err)
+		logger.Error(nil, "read request body failed")
+		jsonhttp.InternalServerError(w, "cannot read request")
+		return
+	}
+
+	var req authTokenRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		logger.Debug("unmarshal body failed", "error", err)
+		logger.Error(nil, "unmarshal body failed")
+		jsonhttp.BadRequest(w, errInvalidRequest)
+		return
+	}
+	if req.Expiry <= 0 {
+		jsonhttp.BadRequest(w, "invalid expiry")
+		return
+	}
+
+	key, t, err := s.auth.Issue(req.Scopes, time.Duration(req.Expiry)*time.Second)
+	if err != nil {
+		if errors.Is(err, auth.ErrUnknownScope) {
+			jsonhttp.BadRequest(w, err.Error())
+			return
+		}
+		logger.Debug("issue token failed", "error", err)
+		logger.Error(nil, "issue token failed")
+		jsonhttp.InternalServerError(w, "issue token failed")
+		return
+	}
+
+	jsonhttp.Created(w, authTokenResponse{
+		Key:    key,
+		ID:     t.ID,
+		Scopes: t.Scopes,
+		Expiry: t.Expiry,
+	})
+}
+
+func (s *Service) authTokensHandler(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.WithName("get_auth").Build()
+
+	if s.auth == nil {
+		jsonhttp.NotImplemented(w, errAuthDisabled)
+		return
+	}
+
+	tokens, err := s.auth.Tokens()
+	if err != nil {
+		logger.Debug("list tokens failed", "error", err)
+		logger.Error(nil, "list tokens failed")
+		jsonhttp.InternalServerError(w, "list tokens failed")
+		return
+	}
+
+	res := authTokensResponse{Tokens: make([]authTokenResponse, 0, len(tokens))}
+	for _, t := range tokens {
+		res.Tokens = append(res.Tokens, authTokenResponse{
+			ID:     t.ID,
+			Scopes: t.Scopes,
+			Expiry: t.Expiry,
+		})
+	}
+	jsonhttp.OK(w, res)
+}
+
+func (s *Service) authTokenDeleteHandler(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.WithName("delete_auth").Build()
+
+	if s.auth == nil {
+		jsonhttp.NotImplemented(w, errAuthDisabled)
+		return
+	}
+
+	paths := struct {
+		ID string `map:"id" validate:"required,hexadecimal"`
+	}{}
+	if response := s.mapStructure(mux.Vars(r), &paths); response != nil {
+		response("invalid path params", logger, w)
+		return
+	}
+
+	if err := s.auth.Revoke(paths.ID); err != nil {
+		if errors.Is(err, auth.ErrNotFound) {
+			jsonhttp.NotFound(w, "token not found")
+			return
+		}
+		logger.Debug("revoke token failed", "id", paths.ID, "error", err)
+		logger.Error(nil, "revoke token failed", "id", paths.ID)
+		jsonhttp.InternalServerError(w, "revoke token failed")
+		return
+	}
+	jsonhttp.NoContent(w)
+}
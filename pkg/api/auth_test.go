@@ -0,0 +1,158 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api_test
+
+import (
+	"encoding/base64"
+	"net/http"
+	"testing"
+
+	"github.com/ethersphere/bee/v2/pkg/api"
+	"github.com/ethersphere/bee/v2/pkg/auth"
+	"github.com/ethersphere/bee/v2/pkg/crypto"
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp"
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp/jsonhttptest"
+	statestore "github.com/ethersphere/bee/v2/pkg/statestore/mock"
+	mockstorer "github.com/ethersphere/bee/v2/pkg/storer/mock"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// nolint:paralleltest
+func TestAuth(t *testing.T) {
+	const password = "secret"
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.MinCost)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pk, err := crypto.GenerateSecp256k1Key()
+	if err != nil {
+		t.Fatal(err)
+	}
+	authenticator, err := auth.New(crypto.NewDefaultSigner(pk), string(hash), statestore.NewStateStore())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client, _, _, _ := newTestServer(t, testServerOptions{
+		Storer:        mockstorer.New(),
+		Authenticator: authenticator,
+	})
+
+	basic := "Basic " + base64.StdEncoding.EncodeToString([]byte(":"+password))
+	issue := func(t *testing.T, scopes ...auth.Scope) api.AuthTokenResponse {
+		t.Helper()
+
+		var res api.AuthTokenResponse
+		jsonhttptest.Request(t, client, http.MethodPost, "/auth", http.StatusCreated,
+			jsonhttptest.WithRequestHeader(api.AuthorizationHeader, basic),
+			jsonhttptest.WithJSONRequestBody(api.AuthTokenRequest{Scopes: scopes, Expiry: 3600}),
+			jsonhttptest.WithUnmarshalJSONResponse(&res),
+		)
+		return res
+	}
+	bearer := func(token string) jsonhttptest.Option {
+		return jsonhttptest.WithRequestHeader(api.AuthorizationHeader, "Bearer "+token)
+	}
+
+	t.Run("public", func(t *testing.T) {
+		jsonhttptest.Request(t, client, http.MethodGet, "/health", http.StatusOK)
+	})
+
+	t.Run("no token", func(t *testing.T) {
+		jsonhttptest.Request(t, client, http.MethodGet, "/tags", http.StatusUnauthorized,
+			jsonhttptest.WithExpectedResponseHeader("WWW-Authenticate", "Bearer"),
+		)
+	})
+
+	t.Run("invalid token", func(t *testing.T) {
+		jsonhttptest.Request(t, client, http.MethodGet, "/tags", http.StatusUnauthorized,
+			bearer("invalid"),
+		)
+	})
+
+	t.Run("wrong password", func(t *testing.T) {
+		jsonhttptest.Request(t, client, http.MethodPost, "/auth", http.StatusUnauthorized,
+			jsonhttptest.WithRequestHeader(api.AuthorizationHeader, "Basic "+base64.StdEncoding.EncodeToString([]byte(":wrong"))),
+			jsonhttptest.WithJSONRequestBody(api.AuthTokenRequest{Scopes: []auth.Scope{auth.ScopeAdmin}, Expiry: 3600}),
+		)
+	})
+
+	t.Run("unknown scope", func(t *testing.T) {
+		jsonhttptest.Request(t, client, http.MethodPost, "/auth", http.StatusBadRequest,
+			jsonhttptest.WithRequestHeader(api.AuthorizationHeader, basic),
+			jsonhttptest.WithJSONRequestBody(api.AuthTokenRequest{Scopes: []auth.Scope{"root"}, Expiry: 3600}),
+		)
+	})
+
+	t.Run("scopes", func(t *testing.T) {
+		upload := issue(t, auth.ScopeUpload)
+
+		jsonhttptest.Request(t, client, http.MethodGet, "/tags", http.StatusOK,
+			bearer(upload.Key),
+		)
+		jsonhttptest.Request(t, client, http.MethodGet, "/v1/tags", http.StatusOK,
+			bearer(upload.Key),
+		)
+		jsonhttptest.Request(t, client, http.MethodGet, "/wallet", http.StatusForbidden,
+			bearer(upload.Key),
+			jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
+				Message: "insufficient permissions",
+				Code:    http.StatusForbidden,
+			}),
+		)
+		jsonhttptest.Request(t, client, http.MethodGet, "/node", http.StatusForbidden,
+			bearer(upload.Key),
+		)
+		jsonhttptest.Request(t, client, http.MethodGet, "/auth", http.StatusForbidden,
+			bearer(upload.Key),
+		)
+		// tokens can only be issued with the admin scope
+		jsonhttptest.Request(t, client, http.MethodPost, "/auth", http.StatusForbidden,
+			bearer(upload.Key),
+			jsonhttptest.WithJSONRequestBody(api.AuthTokenRequest{Scopes: []auth.Scope{auth.ScopeUpload}, Expiry: 3600}),
+		)
+
+		admin := issue(t, auth.ScopeAdmin)
+		jsonhttptest.Request(t, client, http.MethodGet, "/node", http.StatusOK,
+			bearer(admin.Key),
+		)
+		jsonhttptest.Request(t, client, http.MethodPost, "/auth", http.StatusCreated,
+			bearer(admin.Key),
+			jsonhttptest.WithJSONRequestBody(api.AuthTokenRequest{Scopes: []auth.Scope{auth.ScopeDownload}, Expiry: 3600}),
+		)
+	})
+
+	t.Run("revoke", func(t *testing.T) {
+		admin := issue(t, auth.ScopeAdmin)
+		upload := issue(t, auth.ScopeUpload)
+
+		var res api.AuthTokensResponse
+		jsonhttptest.Request(t, client, http.MethodGet, "/auth", http.StatusOK,
+			bearer(admin.Key),
+			jsonhttptest.WithUnmarshalJSONResponse(&res),
+		)
+		found := false
+		for _, tk := range res.Tokens {
+			if tk.Key != "" {
+				t.Fatal("listed token exposes the key")
+			}
+			found = found || tk.ID == upload.ID
+		}
+		if !found {
+			t.Fatalf("token %s not listed", upload.ID)
+		}
+
+		jsonhttptest.Request(t, client, http.MethodDelete, "/auth/"+upload.ID, http.StatusNoContent,
+			bearer(admin.Key),
+		)
+		jsonhttptest.Request(t, client, http.MethodGet, "/tags", http.StatusUnauthorized,
+			bearer(upload.Key),
+		)
+		jsonhttptest.Request(t, client, http.MethodDelete, "/auth/"+upload.ID, http.StatusNotFound,
+			bearer(admin.Key),
+		)
+	})
+}
@@ -60,6 +60,22 @@ func lookaheadBufferSize(size int64) int {
 	return largeFileBufferSize
 }
 
+// isBoundedRangeRequest reports whether the request has a Range header
+// in which every byte range has both its first and last byte position set.
+func isBoundedRangeRequest(r *http.Request) bool {
+	ranges, ok := strings.CutPrefix(r.Header.Get(RangeHeader), "bytes=")
+	if !ok {
+		return false
+	}
+	for _, ra := range strings.Split(ranges, ",") {
+		start, end, ok := strings.Cut(strings.TrimSpace(ra), "-")
+		if !ok || start == "" || end == "" {
+			return false
+		}
+	}
+	return true
+}
+
 func (s *Service) bzzUploadHandler(w http.ResponseWriter, r *http.Request) {
 	span, logger, ctx := s.tracer.StartSpanFromContext(r.Context(), "post_bzz", s.logger.WithName("post_bzz").Build())
 	defer span.Finish()
@@ -182,6 +198,8 @@ func (s *Service) fileUploadHandler(
 		switch {
 		case errors.Is(err, postage.ErrBucketFull):
 			jsonhttp.PaymentRequired(w, "batch is overissued")
+		case errors.Is(err, storer.ErrPinQuotaExceeded):
+			jsonhttp.RequestEntityTooLarge(w, "pin quota exceeded")
 		default:
 			jsonhttp.InternalServerError(w, errFileStore)
 		}
@@ -261,6 +279,8 @@ func (s *Service) fileUploadHandler(
 		switch {
 		case errors.Is(err, postage.ErrBucketFull):
 			jsonhttp.PaymentRequired(w, "batch is overissued")
+		case errors.Is(err, storer.ErrPinQuotaExceeded):
+			jsonhttp.RequestEntityTooLarge(w, "pin quota exceeded")
 		default:
 			jsonhttp.InternalServerError(w, "manifest store failed")
 		}
@@ -389,6 +409,7 @@ func (s *Service) serveReference(logger log.Logger, address swarm.Address, pathV
 		jsonhttp.BadRequest(w, "could not parse headers")
 		return
 	}
+	ctx = getter.SetProximity(ctx, s.proximity)
 	if headers.RLevel != nil {
 		ctx = redundancy.SetLevelInContext(ctx, *headers.RLevel)
 	}
@@ -411,7 +432,7 @@ FETCH:
 	// unmarshal as mantaray first and possibly resolve the feed, otherwise
 	// go on normally.
 	if !feedDereferenced {
-		if l, err := s.manifestFeed(ctx, m); err == nil {
+		if t, l, err := s.manifestFeed(ctx, m); err == nil {
 			//we have a feed manifest here
 			ch, cur, _, err := l.At(ctx, time.Now().Unix(), 0)
 			if err != nil {
@@ -426,7 +447,7 @@ FETCH:
 				jsonhttp.NotFound(w, "no update found")
 				return
 			}
-			wc, err := feeds.GetWrappedChunk(ctx, s.storer.ChunkStore(), ch)
+			wc, err := feedWrappedChunk(ctx, s.storer.ChunkStore(), t, ch)
 			if err != nil {
 				logger.Debug("bzz download: mapStructure feed update failed", "error", err)
 				logger.Error(nil, "bzz download: mapStructure feed update failed")
@@ -456,6 +477,21 @@ FETCH:
 		}
 	}
 
+	format, err := archiveFormat(r)
+	if err != nil {
+		logger.Debug("bzz download: invalid archive format", "error", err)
+		logger.Error(nil, "bzz download: invalid archive format")
+		jsonhttp.BadRequest(w, errInvalidArchiveFormat)
+		return
+	}
+	if format == "" && isDirectoryPath(ctx, m, pathVar) {
+		format = acceptedArchiveFormat(r)
+	}
+	if format != "" {
+		s.serveArchive(ctx, logger, w, m, address, pathVar, format, s.storer.Download(cache), headerOnly)
+		return
+	}
+
 	if pathVar == "" {
 		loggerV1.Debug("bzz download: handle empty path", "address", address)
 
@@ -588,6 +624,7 @@ func (s *Service) downloadHandler(logger log.Logger, w http.ResponseWriter, r *h
 		jsonhttp.BadRequest(w, "could not parse headers")
 		return
 	}
+	ctx = getter.SetProximity(ctx, s.proximity)
 	if headers.RLevel != nil {
 		ctx = redundancy.SetLevelInContext(ctx, *headers.RLevel)
 	}
@@ -630,6 +667,10 @@ func (s *Service) downloadHandler(logger log.Logger, w http.ResponseWriter, r *h
 	}
 
 	bufSize := lookaheadBufferSize(l)
+	if isBoundedRangeRequest(r) {
+		// the lookahead would fetch chunks beyond the requested ranges
+		bufSize = 0
+	}
 	if headers.LookaheadBufferSize != nil {
 		bufSize = *(headers.LookaheadBufferSize)
 	}
@@ -664,10 +705,10 @@ func manifestMetadataLoad(
 func (s *Service) manifestFeed(
 	ctx context.Context,
 	m manifest.Interface,
-) (feeds.Lookup, error) {
+) (feeds.Type, feeds.Lookup, error) {
 	e, err := m.Lookup(ctx, "/")
 	if err != nil {
-		return nil, fmt.Errorf("node lookup: %w", err)
+		return 0, nil, fmt.Errorf("node lookup: %w", err)
 	}
 	var (
 		owner, topic []byte
@@ -677,24 +718,38 @@ func (s *Service) manifestFeed(
 	if e := meta[feedMetadataEntryOwner]; e != "" {
 		owner, err = hex.DecodeString(e)
 		if err != nil {
-			return nil, err
+			return 0, nil, err
 		}
 	}
 	if e := meta[feedMetadataEntryTopic]; e != "" {
 		topic, err = hex.DecodeString(e)
 		if err != nil {
-			return nil, err
+			return 0, nil, err
 		}
 	}
 	if e := meta[feedMetadataEntryType]; e != "" {
 		err := t.FromString(e)
 		if err != nil {
-			return nil, err
+			return 0, nil, err
 		}
 	}
 	if len(owner) == 0 || len(topic) == 0 {
-		return nil, fmt.Errorf("node lookup: %s", "feed metadata absent")
+		return 0, nil, fmt.Errorf("node lookup: %s", "feed metadata absent")
 	}
 	f := feeds.New(topic, common.BytesToAddress(owner))
-	return s.feedFactory.NewLookup(*t, f)
+	l, err := s.feedFactory.NewLookup(*t, f)
+	return *t, l, err
+}
+
+// proximity returns the proximity order of the closest known node,
+// either this node or one of its connected peers, to the given chunk address.
+func (s *Service) proximity(addr swarm.Address) uint8 {
+	closest, err := s.topologyDriver.ClosestPeer(addr, true, topology.Select{})
+	switch {
+	case errors.Is(err, topology.ErrWantSelf) && s.overlay != nil:
+		closest = *s.overlay
+	case err != nil:
+		return 0
+	}
+	return swarm.Proximity(closest.Bytes(), addr.Bytes())
 }
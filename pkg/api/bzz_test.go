@@ -5,6 +5,8 @@
 package api_test
 
 import (
+	"archive/tar"
+	"archive/zip"
 	"bytes"
 	"context"
 	"errors"
@@ -13,6 +15,7 @@ import (
 	"mime"
 	"mime/multipart"
 	"net/http"
+	"path"
 	"strconv"
 	"strings"
 	"testing"
@@ -30,6 +33,7 @@ import (
 	mockstorer "github.com/ethersphere/bee/v2/pkg/storer/mock"
 	"github.com/ethersphere/bee/v2/pkg/swarm"
 	"github.com/ethersphere/bee/v2/pkg/util/testutil/pseudorand"
+	"github.com/google/go-cmp/cmp"
 )
 
 // nolint:paralleltest,tparallel,thelper
@@ -1098,3 +1102,155 @@ func TestDirectUploadBzz(t *testing.T) {
 		}),
 	)
 }
+
+func TestBzzDownloadArchive(t *testing.T) {
+	t.Parallel()
+
+	var (
+		storer          = mockstorer.New()
+		client, _, _, _ = newTestServer(t, testServerOptions{
+			Storer: storer,
+			Logger: log.Noop,
+			Post:   mockpost.New(mockpost.WithAcceptAll()),
+		})
+		files = []f{
+			{data: []byte("robots text"), name: "robots.txt"},
+			{data: []byte("<h1>Swarm"), name: "index.html"},
+			{data: []byte("image 1"), name: "1.png", dir: "img"},
+			{data: []byte("image 2"), name: "2.png", dir: "img"},
+			{data: []byte("zip content"), name: "backup.zip"},
+		}
+	)
+
+	var resp api.BzzUploadResponse
+	jsonhttptest.Request(t, client, http.MethodPost, "/bzz", http.StatusCreated,
+		jsonhttptest.WithRequestHeader(api.SwarmDeferredUploadHeader, "true"),
+		jsonhttptest.WithRequestHeader(api.SwarmPostageBatchIdHeader, batchOkStr),
+		jsonhttptest.WithRequestBody(tarFiles(t, files)),
+		jsonhttptest.WithRequestHeader(api.SwarmCollectionHeader, "true"),
+		jsonhttptest.WithRequestHeader(api.SwarmIndexDocumentHeader, "index.html"),
+		jsonhttptest.WithRequestHeader(api.ContentTypeHeader, api.ContentTypeTar),
+		jsonhttptest.WithUnmarshalJSONResponse(&resp),
+	)
+
+	want := make(map[string][]byte)
+	for _, file := range files {
+		want[path.Join(file.dir, file.name)] = file.data
+	}
+
+	readTar := func(t *testing.T, body []byte) map[string][]byte {
+		t.Helper()
+		got := make(map[string][]byte)
+		tr := tar.NewReader(bytes.NewReader(body))
+		for {
+			hdr, err := tr.Next()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			data, err := io.ReadAll(tr)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got[hdr.Name] = data
+		}
+		return got
+	}
+
+	readZip := func(t *testing.T, body []byte) map[string][]byte {
+		t.Helper()
+		got := make(map[string][]byte)
+		zr, err := zip.NewReader(bytes.NewReader(body), int64(len(body)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, zf := range zr.File {
+			rc, err := zf.Open()
+			if err != nil {
+				t.Fatal(err)
+			}
+			data, err := io.ReadAll(rc)
+			_ = rc.Close()
+			if err != nil {
+				t.Fatal(err)
+			}
+			got[zf.Name] = data
+		}
+		return got
+	}
+
+	t.Run("tar by accept header", func(t *testing.T) {
+		t.Parallel()
+
+		var body []byte
+		jsonhttptest.Request(t, client, http.MethodGet, "/bzz/"+resp.Reference.String()+"/", http.StatusOK,
+			jsonhttptest.WithRequestHeader(api.AcceptHeader, api.ContentTypeTar),
+			jsonhttptest.WithExpectedResponseHeader(api.ContentTypeHeader, api.ContentTypeTar),
+			jsonhttptest.WithExpectedResponseHeader(api.ContentDispositionHeader, fmt.Sprintf(`attachment; filename="%s.tar"`, resp.Reference)),
+			jsonhttptest.WithPutResponseBody(&body),
+		)
+
+		if diff := cmp.Diff(want, readTar(t, body)); diff != "" {
+			t.Fatalf("archive content mismatch (-want +have):\n%s", diff)
+		}
+	})
+
+	t.Run("stored zip file by accept header", func(t *testing.T) {
+		t.Parallel()
+
+		jsonhttptest.Request(t, client, http.MethodGet, "/bzz/"+resp.Reference.String()+"/backup.zip", http.StatusOK,
+			jsonhttptest.WithRequestHeader(api.AcceptHeader, api.ContentTypeZip),
+			jsonhttptest.WithExpectedResponse([]byte("zip content")),
+		)
+	})
+
+	t.Run("zip by query parameter", func(t *testing.T) {
+		t.Parallel()
+
+		var body []byte
+		jsonhttptest.Request(t, client, http.MethodGet, "/bzz/"+resp.Reference.String()+"/?archive=zip", http.StatusOK,
+			jsonhttptest.WithExpectedResponseHeader(api.ContentTypeHeader, api.ContentTypeZip),
+			jsonhttptest.WithPutResponseBody(&body),
+		)
+
+		if diff := cmp.Diff(want, readZip(t, body)); diff != "" {
+			t.Fatalf("archive content mismatch (-want +have):\n%s", diff)
+		}
+	})
+
+	t.Run("sub directory", func(t *testing.T) {
+		t.Parallel()
+
+		var body []byte
+		jsonhttptest.Request(t, client, http.MethodGet, "/bzz/"+resp.Reference.String()+"/img/?archive=tar", http.StatusOK,
+			jsonhttptest.WithPutResponseBody(&body),
+		)
+
+		wantDir := map[string][]byte{
+			"1.png": []byte("image 1"),
+			"2.png": []byte("image 2"),
+		}
+		if diff := cmp.Diff(wantDir, readTar(t, body)); diff != "" {
+			t.Fatalf("archive content mismatch (-want +have):\n%s", diff)
+		}
+	})
+
+	t.Run("path not found", func(t *testing.T) {
+		t.Parallel()
+
+		jsonhttptest.Request(t, client, http.MethodGet, "/bzz/"+resp.Reference.String()+"/css/?archive=tar", http.StatusNotFound)
+	})
+
+	t.Run("invalid format", func(t *testing.T) {
+		t.Parallel()
+
+		jsonhttptest.Request(t, client, http.MethodGet, "/bzz/"+resp.Reference.String()+"/?archive=rar", http.StatusBadRequest,
+			jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
+				Message: api.InvalidArchiveFormat.Error(),
+				Code:    http.StatusBadRequest,
+			}),
+		)
+	})
+}
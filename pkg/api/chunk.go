@@ -196,6 +196,8 @@ func (s *Service) chunkUploadHandler(w http.ResponseWriter, r *http.Request) {
 		switch {
 		case errors.Is(err, postage.ErrBucketFull):
 			jsonhttp.PaymentRequired(ow, "batch is overissued")
+		case errors.Is(err, storer.ErrPinQuotaExceeded):
+			jsonhttp.RequestEntityTooLarge(ow, "pin quota exceeded")
 		case errors.Is(err, postage.ErrInvalidBatchSignature):
 			jsonhttp.BadRequest(ow, "stamp signature is invalid")
 		default:
@@ -26,7 +26,8 @@ func TestDebugStorage(t *testing.T) {
 			},
 			Cache: storer.CacheStat{
 				Size:     50,
-				Capacity: 100,
+				Bytes:    50 * 4104,
+				Capacity: 100 * 4104,
 			},
 			ChunkStore: storer.ChunkStoreStat{
 				TotalChunks: 100,
@@ -6,6 +6,7 @@ package api
 
 import (
 	"archive/tar"
+	"archive/zip"
 	"context"
 	"errors"
 	"fmt"
@@ -13,6 +14,7 @@ import (
 	"mime"
 	"mime/multipart"
 	"net/http"
+	"os"
 	"path/filepath"
 	"runtime"
 	"strconv"
@@ -34,9 +36,22 @@ import (
 	olog "github.com/opentracing/opentracing-go/log"
 )
 
-var errEmptyDir = errors.New("no files in root directory")
+var (
+	errEmptyDir    = errors.New("no files in root directory")
+	errInvalidZip  = errors.New("invalid zip archive")
+	errZipTooLarge = errors.New("zip archive too large")
+)
+
+const (
+	// zipUploadMaxSize is the maximum size of a zip archive buffered for the
+	// collection upload.
+	zipUploadMaxSize = 1 << 30 // 1 GiB
+	// zipUploadMaxConcurrent is the number of zip archives buffered at the
+	// same time, so the temporary files take at most 4 GiB of disk space.
+	zipUploadMaxConcurrent = 4
+)
 
-// dirUploadHandler uploads a directory supplied as a tar in an HTTP request
+// dirUploadHandler uploads a directory supplied as a tar, zip or multipart in an HTTP request
 func (s *Service) dirUploadHandler(
 	ctx context.Context,
 	logger log.Logger,
@@ -66,6 +81,31 @@ func (s *Service) dirUploadHandler(
 		dReader = &tarReader{r: tar.NewReader(r.Body), logger: s.logger}
 	case multiPartFormData:
 		dReader = &multipartReader{r: multipart.NewReader(r.Body, params["boundary"])}
+	case contentTypeZip:
+		if r.ContentLength > zipUploadMaxSize {
+			logger.Error(nil, "zip archive too large", "size", r.ContentLength)
+			jsonhttp.RequestEntityTooLarge(w, errZipTooLarge)
+			return
+		}
+		if !s.zipUploadSem.TryAcquire(1) {
+			logger.Error(nil, "too many simultaneous zip uploads")
+			jsonhttp.TooManyRequests(w, "too many simultaneous zip uploads")
+			return
+		}
+		defer s.zipUploadSem.Release(1)
+		zReader, err := newZipReader(r.Body, zipUploadMaxSize, s.logger)
+		if err != nil {
+			logger.Debug("read zip archive failed", "error", err)
+			logger.Error(nil, "read zip archive failed")
+			if errors.Is(err, errZipTooLarge) {
+				jsonhttp.RequestEntityTooLarge(w, errZipTooLarge)
+				return
+			}
+			jsonhttp.BadRequest(w, errInvalidZip)
+			return
+		}
+		defer zReader.Close()
+		dReader = zReader
 	default:
 		logger.Error(nil, "invalid content-type for directory upload")
 		jsonhttp.BadRequest(w, errInvalidContentType)
@@ -90,6 +130,8 @@ func (s *Service) dirUploadHandler(
 		switch {
 		case errors.Is(err, postage.ErrBucketFull):
 			jsonhttp.PaymentRequired(w, "batch is overissued")
+		case errors.Is(err, storer.ErrPinQuotaExceeded):
+			jsonhttp.RequestEntityTooLarge(w, "pin quota exceeded")
 		case errors.Is(err, errEmptyDir):
 			jsonhttp.BadRequest(w, errEmptyDir)
 		case errors.Is(err, tar.ErrHeader):
@@ -140,7 +182,7 @@ func (s *Service) dirUploadHandler(
 	})
 }
 
-// storeDir stores all files recursively contained in the directory given as a tar/multipart
+// storeDir stores all files recursively contained in the directory given as a tar/zip/multipart
 // it returns the hash for the uploaded manifest corresponding to the uploaded dir
 func storeDir(
 	ctx context.Context,
@@ -283,6 +325,95 @@ func (t *tarReader) Next() (*FileInfo, error) {
 	}
 }
 
+// zipReader returns the files of a zip archive. As the central directory of
+// a zip archive is located at its end, the archive is first buffered into a
+// temporary file of at most the maximum size.
+type zipReader struct {
+	files  []*zip.File
+	file   *os.File
+	cur    io.ReadCloser
+	logger log.Logger
+}
+
+func newZipReader(r io.Reader, maxSize int64, logger log.Logger) (*zipReader, error) {
+	f, err := os.CreateTemp("", "bee-zip-upload-*")
+	if err != nil {
+		return nil, fmt.Errorf("create temporary file: %w", err)
+	}
+	z := &zipReader{file: f, logger: logger}
+
+	size, err := io.Copy(f, io.LimitReader(r, maxSize+1))
+	if err != nil {
+		_ = z.Close()
+		return nil, fmt.Errorf("buffer zip archive: %w", err)
+	}
+	if size > maxSize {
+		_ = z.Close()
+		return nil, errZipTooLarge
+	}
+	zr, err := zip.NewReader(f, size)
+	if err != nil {
+		_ = z.Close()
+		return nil, err
+	}
+	z.files = zr.File
+	return z, nil
+}
+
+func (z *zipReader) Next() (*FileInfo, error) {
+	if z.cur != nil {
+		_ = z.cur.Close()
+		z.cur = nil
+	}
+	for len(z.files) > 0 {
+		zf := z.files[0]
+		z.files = z.files[1:]
+
+		fileName := zf.FileInfo().Name()
+		contentType := mime.TypeByExtension(filepath.Ext(zf.Name))
+		fileSize := zf.FileInfo().Size()
+		filePath := filepath.Clean(zf.Name)
+
+		if filePath == "." {
+			z.logger.Warning("skipping file upload empty path")
+			continue
+		}
+		if runtime.GOOS == "windows" {
+			// always use Unix path separator
+			filePath = filepath.ToSlash(filePath)
+		}
+		// only store regular files
+		if !zf.FileInfo().Mode().IsRegular() {
+			z.logger.Warning("bzz upload dir: skipping file upload as it is not a regular file", "file_path", filePath)
+			continue
+		}
+
+		rc, err := zf.Open()
+		if err != nil {
+			return nil, err
+		}
+		z.cur = rc
+
+		return &FileInfo{
+			Path:        filePath,
+			Name:        fileName,
+			ContentType: contentType,
+			Size:        fileSize,
+			Reader:      rc,
+		}, nil
+	}
+	return nil, io.EOF
+}
+
+// Close releases the currently open file and removes the temporary archive.
+func (z *zipReader) Close() error {
+	if z.cur != nil {
+		_ = z.cur.Close()
+		z.cur = nil
+	}
+	return errors.Join(z.file.Close(), os.Remove(z.file.Name()))
+}
+
 // multipart reader returns files added as a multipart form. We will ensure all the
 // part headers are passed correctly
 type multipartReader struct {
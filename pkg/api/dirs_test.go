@@ -6,8 +6,10 @@ package api_test
 
 import (
 	"archive/tar"
+	"archive/zip"
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"mime/multipart"
@@ -15,7 +17,9 @@ import (
 	"net/textproto"
 	"path"
 	"strconv"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/ethersphere/bee/v2/pkg/api"
 	"github.com/ethersphere/bee/v2/pkg/file/loadsave"
@@ -23,6 +27,7 @@ import (
 	"github.com/ethersphere/bee/v2/pkg/jsonhttp/jsonhttptest"
 	"github.com/ethersphere/bee/v2/pkg/manifest"
 	mockpost "github.com/ethersphere/bee/v2/pkg/postage/mock"
+	"github.com/ethersphere/bee/v2/pkg/spinlock"
 	mockstorer "github.com/ethersphere/bee/v2/pkg/storer/mock"
 	"github.com/ethersphere/bee/v2/pkg/swarm"
 )
@@ -72,6 +77,23 @@ func TestDirs(t *testing.T) {
 		)
 	})
 
+	t.Run("non zip file", func(t *testing.T) {
+		file := bytes.NewReader([]byte("some data"))
+
+		jsonhttptest.Request(t, client, http.MethodPost, dirUploadResource,
+			http.StatusBadRequest,
+			jsonhttptest.WithRequestHeader(api.SwarmDeferredUploadHeader, "true"),
+			jsonhttptest.WithRequestHeader(api.SwarmPostageBatchIdHeader, batchOkStr),
+			jsonhttptest.WithRequestBody(file),
+			jsonhttptest.WithRequestHeader(api.SwarmCollectionHeader, "True"),
+			jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
+				Message: api.InvalidZip.Error(),
+				Code:    http.StatusBadRequest,
+			}),
+			jsonhttptest.WithRequestHeader(api.ContentTypeHeader, api.ContentTypeZip),
+		)
+	})
+
 	t.Run("wrong content type", func(t *testing.T) {
 		tarReader := tarFiles(t, []f{{
 			data: []byte("some data"),
@@ -407,6 +429,39 @@ func TestDirs(t *testing.T) {
 
 				verify(t, resp)
 			})
+			t.Run("zip_upload", func(t *testing.T) {
+				// zip all the test case files
+				zipReader := zipFiles(t, tc.files)
+
+				var resp api.BzzUploadResponse
+
+				options := []jsonhttptest.Option{
+					jsonhttptest.WithRequestHeader(api.SwarmDeferredUploadHeader, "true"),
+					jsonhttptest.WithRequestHeader(api.SwarmPostageBatchIdHeader, batchOkStr),
+					jsonhttptest.WithRequestBody(zipReader),
+					jsonhttptest.WithRequestHeader(api.SwarmCollectionHeader, "True"),
+					jsonhttptest.WithRequestHeader(api.ContentTypeHeader, api.ContentTypeZip),
+					jsonhttptest.WithUnmarshalJSONResponse(&resp),
+				}
+				if tc.indexFilenameOption != nil {
+					options = append(options, tc.indexFilenameOption)
+				}
+				if tc.errorFilenameOption != nil {
+					options = append(options, tc.errorFilenameOption)
+				}
+				if tc.encrypt {
+					options = append(options, jsonhttptest.WithRequestHeader(api.SwarmEncryptHeader, "true"))
+				}
+
+				// verify directory zip upload response
+				jsonhttptest.Request(t, client, http.MethodPost, dirUploadResource, http.StatusCreated, options...)
+
+				if resp.Reference.String() == "" {
+					t.Fatalf("expected file reference, did not got any")
+				}
+
+				verify(t, resp)
+			})
 			if tc.doMultipart {
 				t.Run("multipart_upload", func(t *testing.T) {
 					// tar all the test case files
@@ -525,6 +580,89 @@ func TestDirsEmtpyDir(t *testing.T) {
 	)
 }
 
+func TestDirsZipMaxSize(t *testing.T) {
+	t.Parallel()
+
+	zipReader := zipFiles(t, []f{{
+		data: bytes.Repeat([]byte("a"), 1024),
+		name: "file",
+	}})
+	size := int64(zipReader.Len())
+
+	if err := api.NewZipReader(bytes.NewReader(zipReader.Bytes()), size); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := api.NewZipReader(bytes.NewReader(zipReader.Bytes()), size-1); !errors.Is(err, api.ZipTooLarge) {
+		t.Fatalf("got error %v, want %v", err, api.ZipTooLarge)
+	}
+}
+
+func TestDirsZipMaxConcurrent(t *testing.T) {
+	t.Parallel()
+
+	client, _, _, _ := newTestServer(t, testServerOptions{
+		Storer:          mockstorer.New(),
+		PreventRedirect: true,
+		Post:            mockpost.New(mockpost.WithAcceptAll()),
+	})
+
+	newRequest := func(t *testing.T, body io.Reader) *http.Request {
+		t.Helper()
+		req, err := http.NewRequest(http.MethodPost, "/bzz", body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Header.Set(api.SwarmPostageBatchIdHeader, batchOkStr)
+		req.Header.Set(api.SwarmCollectionHeader, "true")
+		req.Header.Set(api.ContentTypeHeader, api.ContentTypeZip)
+		return req
+	}
+
+	// the uploads are buffering until their bodies are closed
+	var (
+		writers = make([]*io.PipeWriter, api.ZipUploadMaxConcurrent)
+		wg      sync.WaitGroup
+	)
+	for i := range writers {
+		pr, pw := io.Pipe()
+		writers[i] = pw
+		req := newRequest(t, pr)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := client.Do(req)
+			if err == nil {
+				resp.Body.Close()
+			}
+		}()
+	}
+
+	data := zipFiles(t, []f{{data: []byte("some data"), name: "file"}}).Bytes()
+	err := spinlock.Wait(5*time.Second, func() bool {
+		resp, err := client.Do(newRequest(t, bytes.NewReader(data)))
+		if err != nil {
+			return false
+		}
+		defer resp.Body.Close()
+		return resp.StatusCode == http.StatusTooManyRequests
+	})
+	if err != nil {
+		t.Fatal("zip upload over the limit was not refused")
+	}
+
+	for _, pw := range writers {
+		_ = pw.CloseWithError(errors.New("closed"))
+	}
+	wg.Wait()
+
+	jsonhttptest.Request(t, client, http.MethodPost, "/bzz", http.StatusCreated,
+		jsonhttptest.WithRequestHeader(api.SwarmPostageBatchIdHeader, batchOkStr),
+		jsonhttptest.WithRequestBody(bytes.NewReader(data)),
+		jsonhttptest.WithRequestHeader(api.SwarmCollectionHeader, "true"),
+		jsonhttptest.WithRequestHeader(api.ContentTypeHeader, api.ContentTypeZip),
+	)
+}
+
 // tarFiles receives an array of test case files and creates a new tar with those files as a collection
 // it returns a bytes.Buffer which can be used to read the created tar
 func tarFiles(t *testing.T, files []f) *bytes.Buffer {
@@ -563,6 +701,39 @@ func tarFiles(t *testing.T, files []f) *bytes.Buffer {
 	return &buf
 }
 
+// zipFiles receives an array of test case files and creates a new zip archive with those files as a collection
+// it returns a bytes.Buffer which can be used to read the created zip archive
+func zipFiles(t *testing.T, files []f) *bytes.Buffer {
+	t.Helper()
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+
+	for _, file := range files {
+		filePath := path.Join(file.dir, file.name)
+		if file.filePath != "" {
+			filePath = file.filePath
+		}
+
+		w, err := zw.Create(filePath)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// write the file data to the zip archive
+		if _, err := w.Write(file.data); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// finally close the zip writer
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	return &buf
+}
+
 func tarEmptyDir(t *testing.T) *bytes.Buffer {
 	t.Helper()
 
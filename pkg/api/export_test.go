@@ -5,6 +5,8 @@
 package api
 
 import (
+	"io"
+
 	"github.com/ethersphere/bee/v2/pkg/log"
 	"github.com/ethersphere/bee/v2/pkg/swarm"
 )
@@ -14,21 +16,50 @@ type (
 	ChunkAddressResponse  = chunkAddressResponse
 	SocPostResponse       = socPostResponse
 	FeedReferenceResponse = feedReferenceResponse
+	FeedHistoryResponse   = feedHistoryResponse
+	FeedUpdateResponse    = feedUpdateResponse
 	BzzUploadResponse     = bzzUploadResponse
+	ManifestListResponse  = manifestListResponse
+	ManifestPatchRequest  = manifestPatchRequest
+	ManifestOperation     = manifestOperation
+	ManifestDiffResponse  = manifestDiffResponse
 	TagRequest            = tagRequest
 	ListTagsResponse      = listTagsResponse
 	IsRetrievableResponse = isRetrievableResponse
+	AuthTokenRequest      = authTokenRequest
+	AuthTokenResponse     = authTokenResponse
+	AuthTokensResponse    = authTokensResponse
+	PssSendResponse       = pssSendResponse
+	PssMessageResponse    = pssMessageResponse
+	PssInboxMessage       = pssInboxMessage
+	PssInboxResponse      = pssInboxResponse
+	PssGroupMember        = pssGroupMember
+	GsocMineResponse      = gsocMineResponse
+	GsocPollMessage       = gsocPollMessage
+	GsocPollResponse      = gsocPollResponse
+	PssGroupRequest       = pssGroupRequest
+	PssGroupPatchRequest  = pssGroupPatchRequest
+	PssGroupResponse      = pssGroupResponse
 )
 
 var (
-	InvalidContentType  = errInvalidContentType
-	InvalidRequest      = errInvalidRequest
-	DirectoryStoreError = errDirectoryStore
-	EmptyDir            = errEmptyDir
+	InvalidContentType   = errInvalidContentType
+	InvalidRequest       = errInvalidRequest
+	DirectoryStoreError  = errDirectoryStore
+	EmptyDir             = errEmptyDir
+	InvalidZip           = errInvalidZip
+	ZipTooLarge          = errZipTooLarge
+	InvalidArchiveFormat = errInvalidArchiveFormat
+)
+
+const (
+	GsocPollMaxPollers     = gsocPollMaxPollers
+	ZipUploadMaxConcurrent = zipUploadMaxConcurrent
 )
 
 var (
 	ContentTypeTar = contentTypeTar
+	ContentTypeZip = contentTypeZip
 )
 
 var (
@@ -40,6 +71,14 @@ var (
 	ErrActUpload                        = errActUpload
 )
 
+func NewZipReader(r io.Reader, maxSize int64) error {
+	z, err := newZipReader(r, maxSize, log.Noop)
+	if err != nil {
+		return err
+	}
+	return z.Close()
+}
+
 var (
 	FeedMetadataEntryOwner = feedMetadataEntryOwner
 	FeedMetadataEntryTopic = feedMetadataEntryTopic
@@ -61,6 +100,10 @@ type (
 	HealthStatusResponse              = healthStatusResponse
 	NodeResponse                      = nodeResponse
 	PingpongResponse                  = pingpongResponse
+	PinResponse                       = pinResponse
+	PinsResponse                      = pinsResponse
+	PinJobResponse                    = pinJobResponse
+	PinJobsResponse                   = pinJobsResponse
 	PeerConnectResponse               = peerConnectResponse
 	PeersResponse                     = peersResponse
 	BlockedListedPeersResponse        = blockListedPeersResponse
@@ -6,6 +6,7 @@ package api
 
 import (
 	"bytes"
+	"context"
 	"encoding/hex"
 	"errors"
 	"io"
@@ -17,6 +18,7 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethersphere/bee/v2/pkg/accesscontrol"
 	"github.com/ethersphere/bee/v2/pkg/feeds"
+	"github.com/ethersphere/bee/v2/pkg/feeds/chain"
 	"github.com/ethersphere/bee/v2/pkg/file/loadsave"
 	"github.com/ethersphere/bee/v2/pkg/jsonhttp"
 	"github.com/ethersphere/bee/v2/pkg/manifest"
@@ -55,6 +57,7 @@ func (s *Service) feedGetHandler(w http.ResponseWriter, r *http.Request) {
 	queries := struct {
 		At    int64  `map:"at"`
 		After uint64 `map:"after"`
+		Type  string `map:"type"`
 	}{}
 	if response := s.mapStructure(r.URL.Query(), &queries); response != nil {
 		response("invalid query params", logger, w)
@@ -63,6 +66,15 @@ func (s *Service) feedGetHandler(w http.ResponseWriter, r *http.Request) {
 	if queries.At == 0 {
 		queries.At = time.Now().Unix()
 	}
+	feedType := feeds.Sequence
+	if queries.Type != "" {
+		if err := feedType.FromString(queries.Type); err != nil {
+			logger.Debug("invalid feed type", "type", queries.Type, "error", err)
+			logger.Error(nil, "invalid feed type")
+			jsonhttp.BadRequest(w, "invalid feed type")
+			return
+		}
+	}
 
 	headers := struct {
 		OnlyRootChunk bool `map:"Swarm-Only-Root-Chunk"`
@@ -73,7 +85,7 @@ func (s *Service) feedGetHandler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	f := feeds.New(paths.Topic, paths.Owner)
-	lookup, err := s.feedFactory.NewLookup(feeds.Sequence, f)
+	lookup, err := s.feedFactory.NewLookup(feedType, f)
 	if err != nil {
 		logger.Debug("new lookup failed", "owner", paths.Owner, "error", err)
 		logger.Error(nil, "new lookup failed")
@@ -90,7 +102,12 @@ func (s *Service) feedGetHandler(w http.ResponseWriter, r *http.Request) {
 	if err != nil {
 		logger.Debug("lookup at failed", "at", queries.At, "error", err)
 		logger.Error(nil, "lookup at failed")
-		jsonhttp.NotFound(w, "lookup at failed")
+		switch {
+		case errors.Is(err, chain.ErrBroken):
+			jsonhttp.Conflict(w, "feed chain is broken")
+		default:
+			jsonhttp.NotFound(w, "lookup at failed")
+		}
 		return
 	}
 
@@ -102,7 +119,7 @@ func (s *Service) feedGetHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	wc, err := feeds.GetWrappedChunk(r.Context(), s.storer.ChunkStore(), ch)
+	wc, err := feedWrappedChunk(r.Context(), s.storer.ChunkStore(), feedType, ch)
 	if err != nil {
 		logger.Error(nil, "wrapped chunk cannot be retrieved")
 		jsonhttp.NotFound(w, "wrapped chunk cannot be retrieved")
@@ -166,6 +183,23 @@ func (s *Service) feedPostHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	queries := struct {
+		Type string `map:"type"`
+	}{}
+	if response := s.mapStructure(r.URL.Query(), &queries); response != nil {
+		response("invalid query params", logger, w)
+		return
+	}
+	feedType := feeds.Sequence
+	if queries.Type != "" {
+		if err := feedType.FromString(queries.Type); err != nil {
+			logger.Debug("invalid feed type", "type", queries.Type, "error", err)
+			logger.Error(nil, "invalid feed type")
+			jsonhttp.BadRequest(w, "invalid feed type")
+			return
+		}
+	}
+
 	headers := struct {
 		BatchID        []byte        `map:"Swarm-Postage-Batch-Id" validate:"required"`
 		Pin            bool          `map:"Swarm-Pin"`
@@ -244,7 +278,7 @@ func (s *Service) feedPostHandler(w http.ResponseWriter, r *http.Request) {
 	meta := map[string]string{
 		feedMetadataEntryOwner: hex.EncodeToString(paths.Owner.Bytes()),
 		feedMetadataEntryTopic: hex.EncodeToString(paths.Topic),
-		feedMetadataEntryType:  feeds.Sequence.String(), // only sequence allowed for now
+		feedMetadataEntryType:  feedType.String(),
 	}
 
 	emptyAddr := make([]byte, 32)
@@ -307,3 +341,124 @@ func (s *Service) feedPostHandler(w http.ResponseWriter, r *http.Request) {
 
 	jsonhttp.Created(w, feedReferenceResponse{Reference: encryptedReference})
 }
+
+const feedHistoryDefaultLimit = 100
+
+type feedUpdateResponse struct {
+	Index     string        `json:"index,omitempty"`
+	Timestamp uint64        `json:"timestamp,omitempty"`
+	Reference swarm.Address `json:"reference"`
+	Address   swarm.Address `json:"address"`
+}
+
+type feedHistoryResponse struct {
+	Updates []feedUpdateResponse `json:"updates"`
+}
+
+// feedHistoryHandler lists the past updates of a feed published in the given
+// time range, starting from the latest one.
+func (s *Service) feedHistoryHandler(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.WithName("get_feed_history").Build()
+
+	paths := struct {
+		Owner common.Address `map:"owner" validate:"required"`
+		Topic []byte         `map:"topic" validate:"required"`
+	}{}
+	if response := s.mapStructure(mux.Vars(r), &paths); response != nil {
+		response("invalid path params", logger, w)
+		return
+	}
+
+	queries := struct {
+		Type  string `map:"type"`
+		From  uint64 `map:"from"`
+		To    int64  `map:"to"`
+		Limit int    `map:"limit" validate:"min=0,max=1000"`
+	}{}
+	if response := s.mapStructure(r.URL.Query(), &queries); response != nil {
+		response("invalid query params", logger, w)
+		return
+	}
+	if queries.To == 0 {
+		queries.To = time.Now().Unix()
+	}
+	if queries.Limit == 0 {
+		queries.Limit = feedHistoryDefaultLimit
+	}
+	feedType := feeds.Sequence
+	if queries.Type != "" {
+		if err := feedType.FromString(queries.Type); err != nil {
+			logger.Debug("invalid feed type", "type", queries.Type, "error", err)
+			logger.Error(nil, "invalid feed type")
+			jsonhttp.BadRequest(w, "invalid feed type")
+			return
+		}
+	}
+
+	f := feeds.New(paths.Topic, paths.Owner)
+	history, err := s.feedFactory.NewHistory(feedType, f)
+	if err != nil {
+		logger.Debug("new history failed", "owner", paths.Owner, "error", err)
+		logger.Error(nil, "new history failed")
+		switch {
+		case errors.Is(err, feeds.ErrFeedTypeNotFound):
+			jsonhttp.NotFound(w, "feed type not found")
+		default:
+			jsonhttp.InternalServerError(w, "new history failed")
+		}
+		return
+	}
+
+	resp := feedHistoryResponse{Updates: make([]feedUpdateResponse, 0)}
+	err = history.Iterate(r.Context(), queries.To, func(u *feeds.HistoryUpdate) (bool, error) {
+		if u.Timestamp != 0 && u.Timestamp < queries.From {
+			return true, nil
+		}
+		update, err := newFeedUpdateResponse(u)
+		if err != nil {
+			return false, err
+		}
+		resp.Updates = append(resp.Updates, update)
+		return len(resp.Updates) == queries.Limit, nil
+	})
+	if err != nil {
+		logger.Debug("iterate history failed", "owner", paths.Owner, "error", err)
+		logger.Error(nil, "iterate history failed")
+		switch {
+		case errors.Is(err, chain.ErrBroken):
+			jsonhttp.Conflict(w, "feed chain is broken")
+		default:
+			jsonhttp.InternalServerError(w, "iterate history failed")
+		}
+		return
+	}
+
+	jsonhttp.OK(w, resp)
+}
+
+// newFeedUpdateResponse creates the response representation of a feed update.
+func newFeedUpdateResponse(u *feeds.HistoryUpdate) (feedUpdateResponse, error) {
+	resp := feedUpdateResponse{
+		Timestamp: u.Timestamp,
+		Reference: u.Reference,
+	}
+	if u.Chunk != nil {
+		resp.Address = u.Chunk.Address()
+	}
+	if u.Index != nil {
+		b, err := u.Index.MarshalBinary()
+		if err != nil {
+			return resp, err
+		}
+		resp.Index = hex.EncodeToString(b)
+	}
+	return resp, nil
+}
+
+// feedWrappedChunk returns the wrapped chunk of the update ch of a feed of type t.
+func feedWrappedChunk(ctx context.Context, getter storage.Getter, t feeds.Type, ch swarm.Chunk) (swarm.Chunk, error) {
+	if t == feeds.Chain {
+		return chain.GetWrappedChunk(ctx, getter, ch)
+	}
+	return feeds.GetWrappedChunk(ctx, getter, ch)
+}
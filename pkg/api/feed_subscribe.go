@@ -0,0 +1,267 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethersphere/bee/v2/pkg/cac"
+	"github.com/ethersphere/bee/v2/pkg/feeds"
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp"
+	"github.com/ethersphere/bee/v2/pkg/storage"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+	"github.com/gorilla/mux"
+	"github.com/gorilla/websocket"
+)
+
+const (
+	// feedPollMinInterval is the polling interval for the next feed update
+	// after an update was found, it doubles with every unsuccessful poll
+	// up to feedPollMaxInterval.
+	feedPollMinInterval = time.Second
+	feedPollMaxInterval = time.Minute
+	feedPollTimeout     = 10 * time.Second
+)
+
+// feedArrival is a feed update received by the GSOC listener.
+type feedArrival struct {
+	index   feeds.Index
+	address swarm.Address
+	payload []byte
+}
+
+// feedSubscribeHandler upgrades the connection to a websocket on which
+// the updates of a sequence feed are sent as they appear.
+func (s *Service) feedSubscribeHandler(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.WithName("feed_subscribe").Build()
+
+	paths := struct {
+		Owner common.Address `map:"owner" validate:"required"`
+		Topic []byte         `map:"topic" validate:"required"`
+	}{}
+	if response := s.mapStructure(mux.Vars(r), &paths); response != nil {
+		response("invalid path params", logger, w)
+		return
+	}
+
+	f := feeds.New(paths.Topic, paths.Owner)
+	lookup, err := s.feedFactory.NewLookup(feeds.Sequence, f)
+	if err != nil {
+		logger.Debug("new lookup failed", "owner", paths.Owner, "error", err)
+		logger.Error(nil, "new lookup failed")
+		switch {
+		case errors.Is(err, feeds.ErrFeedTypeNotFound):
+			jsonhttp.NotFound(w, "feed type not found")
+		default:
+			jsonhttp.InternalServerError(w, "new lookup failed")
+		}
+		return
+	}
+
+	upgrader := websocket.Upgrader{
+		ReadBufferSize:  swarm.ChunkSize,
+		WriteBufferSize: swarm.ChunkSize,
+		CheckOrigin:     s.checkOrigin,
+	}
+
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		logger.Debug("upgrade failed", "error", err)
+		logger.Error(nil, "upgrade failed")
+		jsonhttp.InternalServerError(w, "upgrade failed")
+		return
+	}
+
+	s.wsWg.Add(1)
+	go s.feedListeningWs(conn, f, lookup)
+}
+
+// feedListeningWs sends the latest update of the feed and then every newer
+// update to the websocket. The next update is polled for with an interval
+// that grows while the feed is not updated, and it is also sent as soon as
+// it arrives to the node if a GSOC listener is available.
+func (s *Service) feedListeningWs(conn *websocket.Conn, f *feeds.Feed, lookup feeds.Lookup) {
+	defer s.wsWg.Done()
+
+	var (
+		arrivalC = make(chan feedArrival)
+		gone     = make(chan struct{})
+		ticker   = time.NewTicker(s.WsPingPeriod)
+		poll     = time.NewTimer(feedPollMinInterval)
+		interval = feedPollMinInterval
+		getter   = feeds.NewGetter(s.storer.Download(true), f)
+		cleanup  = func() {}
+		next     feeds.Index
+	)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer func() {
+		cancel()
+		cleanup()
+		ticker.Stop()
+		poll.Stop()
+		_ = conn.Close()
+	}()
+	go func() {
+		select {
+		case <-gone:
+		case <-s.quit:
+		case <-ctx.Done():
+		}
+		cancel()
+	}()
+
+	conn.SetCloseHandler(func(code int, text string) error {
+		s.logger.Debug("feed ws: client gone", "code", code, "message", text)
+		close(gone)
+		return nil
+	})
+
+	send := func(u *feeds.HistoryUpdate, address swarm.Address) error {
+		resp, err := newFeedUpdateResponse(u)
+		if err != nil {
+			return err
+		}
+		resp.Address = address
+		if err := conn.SetWriteDeadline(time.Now().Add(writeDeadline)); err != nil {
+			return err
+		}
+		return conn.WriteJSON(resp)
+	}
+
+	// listen registers a GSOC handler on the address of the next update.
+	listen := func() error {
+		cleanup()
+		if s.gsoc == nil {
+			return nil
+		}
+		index := next
+		address, err := f.Update(index).Address()
+		if err != nil {
+			return err
+		}
+		cleanup = s.gsoc.Subscribe([32]byte(address.Bytes()), func(m []byte) {
+			select {
+			case arrivalC <- feedArrival{index, address, m}:
+			case <-ctx.Done():
+			}
+		})
+		return nil
+	}
+
+	lookupCtx, lookupCancel := context.WithTimeout(ctx, feedPollTimeout)
+	ch, cur, next, err := lookup.At(lookupCtx, time.Now().Unix(), 0)
+	lookupCancel()
+	if err != nil || next == nil {
+		s.logger.Debug("feed ws: lookup failed", "error", err)
+		return
+	}
+	if ch != nil {
+		u, err := feeds.NewHistoryUpdate(cur, ch)
+		if err == nil {
+			err = send(u, ch.Address())
+		}
+		if err != nil {
+			s.logger.Debug("feed ws: send update failed", "error", err)
+			return
+		}
+	}
+	if err := listen(); err != nil {
+		s.logger.Debug("feed ws: listen failed", "error", err)
+		return
+	}
+
+	for {
+		select {
+		case a := <-arrivalC:
+			if a.index.String() != next.String() {
+				// the update was already found by polling
+				continue
+			}
+			// feed updates wrap a single content addressed chunk
+			wc, err := cac.New(a.payload)
+			if err != nil {
+				s.logger.Debug("feed ws: invalid update payload", "error", err)
+				continue
+			}
+			u := &feeds.HistoryUpdate{Index: a.index, Reference: wc.Address()}
+			if at, ref, err := feeds.LegacyPayload(wc); err == nil {
+				u.Timestamp, u.Reference = at, ref
+			}
+			if err := send(u, a.address); err != nil {
+				s.logger.Debug("feed ws: send update failed", "error", err)
+				return
+			}
+			next = next.Next(0, 0)
+			if err := listen(); err != nil {
+				s.logger.Debug("feed ws: listen failed", "error", err)
+				return
+			}
+			interval = feedPollMinInterval
+			poll.Reset(interval)
+
+		case <-poll.C:
+			pollCtx, pollCancel := context.WithTimeout(ctx, feedPollTimeout)
+			ch, err := getter.Get(pollCtx, next)
+			pollCancel()
+			if err != nil {
+				if ctx.Err() != nil {
+					return
+				}
+				if !errors.Is(err, storage.ErrNotFound) {
+					s.logger.Debug("feed ws: poll failed", "error", err)
+				}
+				interval = min(2*interval, feedPollMaxInterval)
+				poll.Reset(interval)
+				continue
+			}
+			u, err := feeds.NewHistoryUpdate(next, ch)
+			if err == nil {
+				err = send(u, ch.Address())
+			}
+			if err != nil {
+				s.logger.Debug("feed ws: send update failed", "error", err)
+				return
+			}
+			next = next.Next(0, 0)
+			if err := listen(); err != nil {
+				s.logger.Debug("feed ws: listen failed", "error", err)
+				return
+			}
+			// look for a following update right away
+			interval = feedPollMinInterval
+			poll.Reset(0)
+
+		case <-s.quit:
+			// shutdown
+			err := conn.SetWriteDeadline(time.Now().Add(writeDeadline))
+			if err != nil {
+				s.logger.Debug("feed ws: set write deadline failed", "error", err)
+				return
+			}
+			err = conn.WriteMessage(websocket.CloseMessage, []byte{})
+			if err != nil {
+				s.logger.Debug("feed ws: write close message failed", "error", err)
+			}
+			return
+		case <-gone:
+			// client gone
+			return
+		case <-ticker.C:
+			err := conn.SetWriteDeadline(time.Now().Add(writeDeadline))
+			if err != nil {
+				s.logger.Debug("feed ws: set write deadline failed", "error", err)
+				return
+			}
+			if err = conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				// error encountered while pinging client. client probably gone
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,140 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api_test
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/ethersphere/bee/v2/pkg/api"
+	"github.com/ethersphere/bee/v2/pkg/crypto"
+	"github.com/ethersphere/bee/v2/pkg/feeds"
+	"github.com/ethersphere/bee/v2/pkg/feeds/factory"
+	"github.com/ethersphere/bee/v2/pkg/feeds/sequence"
+	"github.com/ethersphere/bee/v2/pkg/gsoc"
+	"github.com/ethersphere/bee/v2/pkg/log"
+	"github.com/ethersphere/bee/v2/pkg/soc"
+	"github.com/ethersphere/bee/v2/pkg/storage"
+	mockstorer "github.com/ethersphere/bee/v2/pkg/storer/mock"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+	"github.com/ethersphere/bee/v2/pkg/util/testutil"
+	"github.com/gorilla/websocket"
+)
+
+func TestFeedSubscribe(t *testing.T) {
+	t.Parallel()
+
+	topic := []byte("testtopic")
+	pk, err := crypto.GenerateSecp256k1Key()
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer := crypto.NewDefaultSigner(pk)
+	owner, err := signer.EthereumAddress()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	newFeedSubscribeTest := func(t *testing.T) (gsoc.Listener, *websocket.Conn, storage.Putter) {
+		t.Helper()
+
+		mockStorer := mockstorer.New()
+		g := gsoc.New(log.Noop)
+		testutil.CleanupCloser(t, g)
+		putter, err := mockStorer.Upload(context.Background(), false, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		updater, err := sequence.NewUpdater(putter, signer, topic)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// the subscription starts with the latest update of the feed
+		publishFeedUpdate(t, updater, 1)
+
+		_, cl, _, _ := newTestServer(t, testServerOptions{
+			Storer: mockStorer,
+			Feeds:  factory.New(mockStorer.ChunkStore()),
+			Gsoc:   g,
+			WsPath: fmt.Sprintf("/feeds/%s/%s/subscribe", hex.EncodeToString(owner.Bytes()), hex.EncodeToString(topic)),
+		})
+		expectFeedUpdate(t, cl, 0, 1)
+		return g, cl, putter
+	}
+
+	t.Run("poll", func(t *testing.T) {
+		t.Parallel()
+
+		_, cl, putter := newFeedSubscribeTest(t)
+		updater, err := sequence.NewUpdater(putter, signer, topic)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// the updater starts from the first index
+		publishFeedUpdate(t, updater, 1)
+		publishFeedUpdate(t, updater, 2)
+		publishFeedUpdate(t, updater, 3)
+
+		expectFeedUpdate(t, cl, 1, 2)
+		expectFeedUpdate(t, cl, 2, 3)
+	})
+
+	t.Run("gsoc arrival", func(t *testing.T) {
+		t.Parallel()
+
+		g, cl, _ := newFeedSubscribeTest(t)
+		// the update is not stored, so it can only be received from the listener
+		var chunks []swarm.Chunk
+		updater, err := sequence.NewUpdater(storage.PutterFunc(func(_ context.Context, ch swarm.Chunk) error {
+			chunks = append(chunks, ch)
+			return nil
+		}), signer, topic)
+		if err != nil {
+			t.Fatal(err)
+		}
+		publishFeedUpdate(t, updater, 1)
+		publishFeedUpdate(t, updater, 2)
+
+		socCh, err := soc.FromChunk(chunks[1])
+		if err != nil {
+			t.Fatal(err)
+		}
+		g.Handle(socCh)
+
+		expectFeedUpdate(t, cl, 1, 2)
+	})
+}
+
+func publishFeedUpdate(t *testing.T, updater feeds.Updater, at uint64) {
+	t.Helper()
+
+	payload := make([]byte, 8, 8+swarm.HashSize)
+	binary.BigEndian.PutUint64(payload, at)
+	payload = append(payload, make([]byte, swarm.HashSize)...)
+	if err := updater.Update(context.Background(), int64(at), payload); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func expectFeedUpdate(t *testing.T, cl *websocket.Conn, index, at uint64) {
+	t.Helper()
+
+	if err := cl.SetReadDeadline(time.Now().Add(5 * time.Second)); err != nil {
+		t.Fatal(err)
+	}
+	var u api.FeedUpdateResponse
+	if err := cl.ReadJSON(&u); err != nil {
+		t.Fatal(err)
+	}
+	want := make([]byte, 8)
+	binary.BigEndian.PutUint64(want, index)
+	if u.Index != hex.EncodeToString(want) || u.Timestamp != at {
+		t.Fatalf("got update %s at %d, want %x at %d", u.Index, u.Timestamp, want, at)
+	}
+}
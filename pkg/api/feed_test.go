@@ -17,7 +17,11 @@ import (
 	"testing"
 
 	"github.com/ethersphere/bee/v2/pkg/api"
+	"github.com/ethersphere/bee/v2/pkg/crypto"
 	"github.com/ethersphere/bee/v2/pkg/feeds"
+	"github.com/ethersphere/bee/v2/pkg/feeds/chain"
+	"github.com/ethersphere/bee/v2/pkg/feeds/factory"
+	"github.com/ethersphere/bee/v2/pkg/feeds/sequence"
 	"github.com/ethersphere/bee/v2/pkg/file/loadsave"
 	"github.com/ethersphere/bee/v2/pkg/file/splitter"
 	"github.com/ethersphere/bee/v2/pkg/jsonhttp"
@@ -27,6 +31,7 @@ import (
 	"github.com/ethersphere/bee/v2/pkg/postage"
 	mockpost "github.com/ethersphere/bee/v2/pkg/postage/mock"
 	testingsoc "github.com/ethersphere/bee/v2/pkg/soc/testing"
+	"github.com/ethersphere/bee/v2/pkg/storage"
 	testingc "github.com/ethersphere/bee/v2/pkg/storage/testing"
 	mockstorer "github.com/ethersphere/bee/v2/pkg/storer/mock"
 	"github.com/ethersphere/bee/v2/pkg/swarm"
@@ -294,6 +299,226 @@ func TestFeedDirectUpload(t *testing.T) {
 	)
 }
 
+func TestFeed_History(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mockStorer      = mockstorer.New()
+		client, _, _, _ = newTestServer(t, testServerOptions{
+			Storer: mockStorer,
+			Feeds:  factory.New(mockStorer.ChunkStore()),
+		})
+		topic = []byte("testtopic")
+	)
+
+	putter, err := mockStorer.Upload(context.Background(), false, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pk, err := crypto.GenerateSecp256k1Key()
+	if err != nil {
+		t.Fatal(err)
+	}
+	updater, err := sequence.NewUpdater(putter, crypto.NewDefaultSigner(pk), topic)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ats := []uint64{10, 20, 30, 40}
+	refs := make([]swarm.Address, len(ats))
+	for i, at := range ats {
+		refs[i] = swarm.RandAddress(t)
+		payload := make([]byte, 8, 8+swarm.HashSize)
+		binary.BigEndian.PutUint64(payload, at)
+		if err := updater.Update(context.Background(), int64(at), append(payload, refs[i].Bytes()...)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	historyResource := func(query string) string {
+		return fmt.Sprintf("/feeds/%s/%s/history%s", hex.EncodeToString(updater.Feed().Owner.Bytes()), hex.EncodeToString(topic), query)
+	}
+
+	t.Run("all updates", func(t *testing.T) {
+		t.Parallel()
+
+		var resp api.FeedHistoryResponse
+		jsonhttptest.Request(t, client, http.MethodGet, historyResource(""), http.StatusOK,
+			jsonhttptest.WithUnmarshalJSONResponse(&resp),
+		)
+		if len(resp.Updates) != len(ats) {
+			t.Fatalf("got %d updates, want %d", len(resp.Updates), len(ats))
+		}
+		for i, u := range resp.Updates {
+			j := len(ats) - 1 - i
+			if u.Timestamp != ats[j] || !u.Reference.Equal(refs[j]) {
+				t.Fatalf("update %d: got %d %s, want %d %s", i, u.Timestamp, u.Reference, ats[j], refs[j])
+			}
+			index := make([]byte, 8)
+			binary.BigEndian.PutUint64(index, uint64(j))
+			if u.Index != hex.EncodeToString(index) {
+				t.Fatalf("update %d: got index %s, want %x", i, u.Index, index)
+			}
+		}
+	})
+
+	t.Run("time range and limit", func(t *testing.T) {
+		t.Parallel()
+
+		var resp api.FeedHistoryResponse
+		jsonhttptest.Request(t, client, http.MethodGet, historyResource("?from=15&to=35&limit=1"), http.StatusOK,
+			jsonhttptest.WithUnmarshalJSONResponse(&resp),
+		)
+		if len(resp.Updates) != 1 || resp.Updates[0].Timestamp != 30 {
+			t.Fatalf("got updates %+v, want the update at 30", resp.Updates)
+		}
+
+		jsonhttptest.Request(t, client, http.MethodGet, historyResource("?from=15&to=35"), http.StatusOK,
+			jsonhttptest.WithUnmarshalJSONResponse(&resp),
+		)
+		if len(resp.Updates) != 2 || resp.Updates[0].Timestamp != 30 || resp.Updates[1].Timestamp != 20 {
+			t.Fatalf("got updates %+v, want the updates at 30 and 20", resp.Updates)
+		}
+	})
+
+	t.Run("no updates", func(t *testing.T) {
+		t.Parallel()
+
+		var resp api.FeedHistoryResponse
+		jsonhttptest.Request(t, client, http.MethodGet, fmt.Sprintf("/feeds/%s/aabbcc/history", ownerString), http.StatusOK,
+			jsonhttptest.WithUnmarshalJSONResponse(&resp),
+		)
+		if len(resp.Updates) != 0 {
+			t.Fatalf("got updates %+v, want none", resp.Updates)
+		}
+	})
+
+	t.Run("invalid type", func(t *testing.T) {
+		t.Parallel()
+
+		jsonhttptest.Request(t, client, http.MethodGet, historyResource("?type=unknown"), http.StatusBadRequest)
+	})
+}
+
+func TestFeed_Chain(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mockStorer      = mockstorer.New()
+		client, _, _, _ = newTestServer(t, testServerOptions{
+			Storer: mockStorer,
+			Feeds:  factory.New(mockStorer.ChunkStore()),
+			Post:   mockpost.New(mockpost.WithIssuer(postage.NewStampIssuer("", "", batchOk, big.NewInt(3), 11, 10, 1000, true))),
+		})
+		topic = []byte("testtopic")
+	)
+
+	putter, err := mockStorer.Upload(context.Background(), false, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pk, err := crypto.GenerateSecp256k1Key()
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer := crypto.NewDefaultSigner(pk)
+	updater, err := chain.NewUpdater(putter, signer, topic)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, payload := range []string{"first", "second"} {
+		if err := updater.Update(context.Background(), 0, []byte(payload)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	owner := hex.EncodeToString(updater.Feed().Owner.Bytes())
+
+	t.Run("get", func(t *testing.T) {
+		t.Parallel()
+
+		jsonhttptest.Request(t, client, http.MethodGet, fmt.Sprintf("/feeds/%s/%s?type=chain", owner, hex.EncodeToString(topic)), http.StatusOK,
+			jsonhttptest.WithExpectedResponse([]byte("second")),
+			jsonhttptest.WithExpectedResponseHeader(api.SwarmFeedIndexHeader, "0000000000000001"),
+		)
+	})
+
+	t.Run("history", func(t *testing.T) {
+		t.Parallel()
+
+		var resp api.FeedHistoryResponse
+		jsonhttptest.Request(t, client, http.MethodGet, fmt.Sprintf("/feeds/%s/%s/history?type=chain", owner, hex.EncodeToString(topic)), http.StatusOK,
+			jsonhttptest.WithUnmarshalJSONResponse(&resp),
+		)
+		if len(resp.Updates) != 2 {
+			t.Fatalf("got %d updates, want 2", len(resp.Updates))
+		}
+	})
+
+	t.Run("broken", func(t *testing.T) {
+		t.Parallel()
+
+		// the second update of the feed on the other topic does not commit to the first one
+		topic := []byte("brokentopic")
+		updater, err := chain.NewUpdater(putter, signer, topic)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var forked []swarm.Chunk
+		forkUpdater, err := chain.NewUpdater(storage.PutterFunc(func(_ context.Context, ch swarm.Chunk) error {
+			forked = append(forked, ch)
+			return nil
+		}), signer, topic)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := updater.Update(context.Background(), 0, []byte("first")); err != nil {
+			t.Fatal(err)
+		}
+		for _, payload := range []string{"fork first", "fork second"} {
+			if err := forkUpdater.Update(context.Background(), 0, []byte(payload)); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := putter.Put(context.Background(), forked[1]); err != nil {
+			t.Fatal(err)
+		}
+
+		jsonhttptest.Request(t, client, http.MethodGet, fmt.Sprintf("/feeds/%s/%s?type=chain", owner, hex.EncodeToString(topic)), http.StatusConflict)
+		jsonhttptest.Request(t, client, http.MethodGet, fmt.Sprintf("/feeds/%s/%s/history?type=chain", owner, hex.EncodeToString(topic)), http.StatusConflict)
+	})
+
+	t.Run("post", func(t *testing.T) {
+		t.Parallel()
+
+		var resp api.FeedReferenceResponse
+		jsonhttptest.Request(t, client, http.MethodPost, fmt.Sprintf("/feeds/%s/%s?type=chain", owner, hex.EncodeToString(topic)), http.StatusCreated,
+			jsonhttptest.WithRequestHeader(api.SwarmDeferredUploadHeader, "true"),
+			jsonhttptest.WithRequestHeader(api.SwarmPostageBatchIdHeader, batchOkStr),
+			jsonhttptest.WithUnmarshalJSONResponse(&resp),
+		)
+
+		m, err := manifest.NewMantarayManifestReference(resp.Reference, loadsave.NewReadonly(mockStorer.ChunkStore()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		e, err := m.Lookup(context.Background(), "/")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if typ := e.Metadata()[api.FeedMetadataEntryType]; typ != "Chain" {
+			t.Fatalf("type mismatch. got %s want %s", typ, "Chain")
+		}
+	})
+
+	t.Run("post invalid type", func(t *testing.T) {
+		t.Parallel()
+
+		jsonhttptest.Request(t, client, http.MethodPost, fmt.Sprintf("/feeds/%s/%s?type=unknown", owner, hex.EncodeToString(topic)), http.StatusBadRequest,
+			jsonhttptest.WithRequestHeader(api.SwarmPostageBatchIdHeader, batchOkStr),
+		)
+	})
+}
+
 type factoryMock struct {
 	sequenceCalled bool
 	epochCalled    bool
@@ -316,6 +541,10 @@ func (f *factoryMock) NewLookup(t feeds.Type, feed *feeds.Feed) (feeds.Lookup, e
 	return f.lookup, nil
 }
 
+func (f *factoryMock) NewHistory(t feeds.Type, feed *feeds.Feed) (feeds.History, error) {
+	return nil, feeds.ErrFeedTypeNotFound
+}
+
 type mockLookup struct {
 	at        int64
 	after     uint64
@@ -5,15 +5,338 @@
 package api
 
 import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"io"
 	"net/http"
+	"sync"
 	"time"
 
+	"github.com/ethersphere/bee/v2/pkg/cac"
 	"github.com/ethersphere/bee/v2/pkg/jsonhttp"
+	"github.com/ethersphere/bee/v2/pkg/pss"
+	"github.com/ethersphere/bee/v2/pkg/soc"
 	"github.com/ethersphere/bee/v2/pkg/swarm"
 	"github.com/gorilla/mux"
 	"github.com/gorilla/websocket"
 )
 
+const (
+	gsocPollBufferSize     = 100              // messages kept for the pollers of an address
+	gsocPollIdleTimeout    = 2 * time.Minute  // subscription of the pollers is dropped after no polls
+	gsocPollDefaultTimeout = 30 * time.Second // time a poll waits for messages
+	gsocPollMaxTimeout     = 60 * time.Second
+	gsocPollMaxPollers     = 100 // addresses polled at the same time
+)
+
+var errTooManyGsocPollers = errors.New("too many gsoc pollers")
+
+type gsocMineResponse struct {
+	ID      string        `json:"id"`
+	Address swarm.Address `json:"address"`
+}
+
+type gsocPollMessage struct {
+	Seq     uint64 `json:"seq"`
+	Payload []byte `json:"payload"`
+}
+
+type gsocPollResponse struct {
+	Messages []gsocPollMessage `json:"messages"`
+	Cursor   uint64            `json:"cursor"`
+}
+
+func (s *Service) gsocPostHandler(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.WithName("post_gsoc").Build()
+
+	paths := struct {
+		Owner []byte `map:"owner" validate:"required,len=20"`
+		ID    []byte `map:"id" validate:"required,len=32"`
+	}{}
+	if response := s.mapStructure(mux.Vars(r), &paths); response != nil {
+		response("invalid path params", logger, w)
+		return
+	}
+
+	queries := struct {
+		Sig []byte `map:"sig"`
+	}{}
+	if response := s.mapStructure(r.URL.Query(), &queries); response != nil {
+		response("invalid query params", logger, w)
+		return
+	}
+
+	headers := struct {
+		BatchID []byte `map:"Swarm-Postage-Batch-Id" validate:"required"`
+	}{}
+	if response := s.mapStructure(r.Header, &headers); response != nil {
+		response("invalid header params", logger, w)
+		return
+	}
+
+	payload, err := io.ReadAll(r.Body)
+	if err != nil {
+		if jsonhttp.HandleBodyReadError(err, w) {
+			return
+		}
+		logger.Debug("read body failed", "error", err)
+		logger.Error(nil, "read body failed")
+		jsonhttp.InternalServerError(w, "cannot read payload")
+		return
+	}
+	if len(payload) > swarm.ChunkSize {
+		jsonhttp.RequestEntityTooLarge(w, "payload too large")
+		return
+	}
+
+	ch, err := cac.New(payload)
+	if err != nil {
+		logger.Debug("create content addressed chunk failed", "error", err)
+		logger.Error(nil, "create content addressed chunk failed")
+		jsonhttp.BadRequest(w, "chunk data error")
+		return
+	}
+
+	// without a signature the update is signed by the node, so it has to be the owner
+	var sch swarm.Chunk
+	if len(queries.Sig) > 0 {
+		ss, err := soc.NewSigned(paths.ID, ch, paths.Owner, queries.Sig)
+		if err == nil {
+			sch, err = ss.Chunk()
+		}
+		if err != nil || !soc.Valid(sch) {
+			logger.Debug("create soc failed", "id", paths.ID, "owner", paths.Owner, "error", err)
+			jsonhttp.Unauthorized(w, "invalid signature")
+			return
+		}
+	} else {
+		owner, err := s.signer.EthereumAddress()
+		if err != nil {
+			logger.Debug("get ethereum address failed", "error", err)
+			logger.Error(nil, "get ethereum address failed")
+			jsonhttp.InternalServerError(w, "get ethereum address failed")
+			return
+		}
+		if !bytes.Equal(owner.Bytes(), paths.Owner) {
+			jsonhttp.BadRequest(w, "signature required for owner other than the node")
+			return
+		}
+		sch, err = soc.New(paths.ID, ch).Sign(s.signer)
+		if err != nil {
+			logger.Debug("sign soc failed", "error", err)
+			logger.Error(nil, "sign soc failed")
+			jsonhttp.InternalServerError(w, "sign soc failed")
+			return
+		}
+	}
+
+	putter, ok := s.newSOCPutter(w, r, logger, headers.BatchID, nil, false)
+	if !ok {
+		return
+	}
+
+	ow := &cleanupOnErrWriter{
+		ResponseWriter: w,
+		onErr:          putter.Cleanup,
+		logger:         logger,
+	}
+
+	if !s.putSOC(r.Context(), ow, logger, putter, sch) {
+		return
+	}
+
+	jsonhttp.Created(w, socPostResponse{Reference: sch.Address()})
+}
+
+// gsocMineHandler mines an identifier for the owner so that the address of
+// the GSOC falls in the neighbourhood of one of the targets.
+func (s *Service) gsocMineHandler(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.WithName("get_gsoc_mine").Build()
+
+	paths := struct {
+		Owner   []byte `map:"owner" validate:"required,len=20"`
+		Targets string `map:"targets" validate:"required"`
+	}{}
+	if response := s.mapStructure(mux.Vars(r), &paths); response != nil {
+		response("invalid path params", logger, w)
+		return
+	}
+	targets, err := parseTargets(paths.Targets)
+	if err != nil {
+		logger.Debug("parse targets failed", "error", err)
+		jsonhttp.BadRequest(w, "invalid targets")
+		return
+	}
+
+	id, err := pss.MineID(r.Context(), targets, func(id []byte) (swarm.Address, error) {
+		return soc.CreateAddress(id, paths.Owner)
+	})
+	if err != nil {
+		logger.Debug("mine gsoc id failed", "error", err)
+		logger.Error(nil, "mine gsoc id failed")
+		jsonhttp.InternalServerError(w, "mine gsoc id failed")
+		return
+	}
+	addr, err := soc.CreateAddress(id, paths.Owner)
+	if err != nil {
+		logger.Debug("create soc address failed", "error", err)
+		logger.Error(nil, "create soc address failed")
+		jsonhttp.InternalServerError(w, "mine gsoc id failed")
+		return
+	}
+
+	jsonhttp.OK(w, gsocMineResponse{
+		ID:      hex.EncodeToString(id),
+		Address: addr,
+	})
+}
+
+// gsocPoller buffers the messages of a GSOC address for the long-poll subscribers.
+type gsocPoller struct {
+	mu       sync.Mutex
+	messages []gsocPollMessage
+	next     uint64
+	notify   chan struct{}
+	lastPoll time.Time
+	cleanup  func()
+}
+
+func (p *gsocPoller) add(m []byte) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.messages = append(p.messages, gsocPollMessage{Seq: p.next, Payload: m})
+	if len(p.messages) > gsocPollBufferSize {
+		p.messages = p.messages[len(p.messages)-gsocPollBufferSize:]
+	}
+	p.next++
+	close(p.notify)
+	p.notify = make(chan struct{})
+}
+
+// since returns the buffered messages from the cursor on, and the channel
+// closed on the next message.
+func (p *gsocPoller) since(cursor uint64) ([]gsocPollMessage, uint64, <-chan struct{}) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.lastPoll = time.Now()
+	msgs := make([]gsocPollMessage, 0)
+	for _, m := range p.messages {
+		if m.Seq >= cursor {
+			msgs = append(msgs, m)
+		}
+	}
+	return msgs, p.next, p.notify
+}
+
+func (p *gsocPoller) idle() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return time.Since(p.lastPoll) > gsocPollIdleTimeout
+}
+
+// gsocPoller returns the poller of the address, subscribing to it on the first
+// poll. A new address is refused while the maximum number of addresses is polled.
+func (s *Service) gsocPoller(address [32]byte) (*gsocPoller, error) {
+	s.gsocPollersMu.Lock()
+	defer s.gsocPollersMu.Unlock()
+
+	if s.gsocPollers == nil {
+		s.gsocPollers = make(map[[32]byte]*gsocPoller)
+	}
+	if p, ok := s.gsocPollers[address]; ok {
+		return p, nil
+	}
+	if len(s.gsocPollers) >= gsocPollMaxPollers {
+		return nil, errTooManyGsocPollers
+	}
+
+	p := &gsocPoller{
+		notify:   make(chan struct{}),
+		lastPoll: time.Now(),
+	}
+	p.cleanup = s.gsoc.Subscribe(address, p.add)
+	s.gsocPollers[address] = p
+
+	s.wsWg.Add(1)
+	go func() {
+		defer s.wsWg.Done()
+
+		ticker := time.NewTicker(gsocPollIdleTimeout / 2)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-s.quit:
+			case <-ticker.C:
+				if !p.idle() {
+					continue
+				}
+			}
+			s.gsocPollersMu.Lock()
+			delete(s.gsocPollers, address)
+			s.gsocPollersMu.Unlock()
+			p.cleanup()
+			return
+		}
+	}()
+
+	return p, nil
+}
+
+// gsocPollHandler is the long-poll alternative of the websocket subscription.
+// It returns the messages received since the cursor, waiting for the next
+// one if there is none yet.
+func (s *Service) gsocPollHandler(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.WithName("get_gsoc_poll").Build()
+
+	paths := struct {
+		Address []byte `map:"address" validate:"required,len=32"`
+	}{}
+	if response := s.mapStructure(mux.Vars(r), &paths); response != nil {
+		response("invalid path params", logger, w)
+		return
+	}
+
+	queries := struct {
+		Cursor  uint64 `map:"cursor"`
+		Timeout int64  `map:"timeout" validate:"min=0"`
+	}{}
+	if response := s.mapStructure(r.URL.Query(), &queries); response != nil {
+		response("invalid query params", logger, w)
+		return
+	}
+	timeout := gsocPollDefaultTimeout
+	if queries.Timeout > 0 {
+		timeout = min(time.Duration(queries.Timeout)*time.Second, gsocPollMaxTimeout)
+	}
+
+	p, err := s.gsocPoller([32]byte(paths.Address))
+	if err != nil {
+		logger.Debug("subscribe gsoc poller failed", "address", paths.Address, "error", err)
+		jsonhttp.TooManyRequests(w, "too many polled addresses")
+		return
+	}
+	msgs, cursor, notify := p.since(queries.Cursor)
+	if len(msgs) == 0 {
+		select {
+		case <-notify:
+			msgs, cursor, _ = p.since(queries.Cursor)
+		case <-time.After(timeout):
+		case <-r.Context().Done():
+			return
+		case <-s.quit:
+		}
+	}
+
+	jsonhttp.OK(w, gsocPollResponse{
+		Messages: msgs,
+		Cursor:   cursor,
+	})
+}
+
 func (s *Service) gsocWsHandler(w http.ResponseWriter, r *http.Request) {
 	logger := s.logger.WithName("gsoc_subscribe").Build()
 
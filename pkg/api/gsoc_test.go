@@ -5,23 +5,31 @@
 package api_test
 
 import (
+	"bytes"
 	"encoding/hex"
 	"fmt"
+	"net/http"
 	"net/url"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/ethersphere/bee/v2/pkg/api"
 	"github.com/ethersphere/bee/v2/pkg/cac"
 	"github.com/ethersphere/bee/v2/pkg/crypto"
 	"github.com/ethersphere/bee/v2/pkg/gsoc"
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp"
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp/jsonhttptest"
 	"github.com/ethersphere/bee/v2/pkg/log"
 	mockbatchstore "github.com/ethersphere/bee/v2/pkg/postage/batchstore/mock"
 	"github.com/ethersphere/bee/v2/pkg/soc"
+	testingsoc "github.com/ethersphere/bee/v2/pkg/soc/testing"
+	"github.com/ethersphere/bee/v2/pkg/spinlock"
 	mockstorer "github.com/ethersphere/bee/v2/pkg/storer/mock"
 	"github.com/ethersphere/bee/v2/pkg/swarm"
 	"github.com/ethersphere/bee/v2/pkg/util/testutil"
 	"github.com/gorilla/websocket"
+	"golang.org/x/sync/errgroup"
 )
 
 // TestGsocWebsocketSingleHandler creates a single websocket handler on a chunk address, and receives a message
@@ -169,3 +177,206 @@ func newGsocTest(t *testing.T, socId []byte, pingPeriod time.Duration) (gsoc.Lis
 
 	return gsoc, cl, signer, listener
 }
+
+func TestGsocPost(t *testing.T) {
+	t.Parallel()
+
+	privKey, err := crypto.GenerateSecp256k1Key()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var (
+		signer                   = crypto.NewDefaultSigner(privKey)
+		id                       = make([]byte, swarm.HashSize)
+		payload                  = []byte("gsoc payload")
+		client, _, _, chanStorer = newTestServer(t, testServerOptions{
+			Storer:       mockstorer.New(),
+			Post:         newTestPostService(),
+			DirectUpload: true,
+			Signer:       signer,
+		})
+	)
+	owner, err := signer.EthereumAddress()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("signed by node", func(t *testing.T) {
+		addr, err := soc.CreateAddress(id, owner.Bytes())
+		if err != nil {
+			t.Fatal(err)
+		}
+		jsonhttptest.Request(t, client, http.MethodPost, fmt.Sprintf("/gsoc/%x/%x", owner.Bytes(), id), http.StatusCreated,
+			jsonhttptest.WithRequestHeader(api.SwarmPostageBatchIdHeader, batchOkStr),
+			jsonhttptest.WithRequestBody(bytes.NewReader(payload)),
+			jsonhttptest.WithExpectedJSONResponse(api.SocPostResponse{Reference: addr}),
+		)
+		if err := spinlock.Wait(time.Second, func() bool { return chanStorer.Has(addr) }); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("signed by owner", func(t *testing.T) {
+		s := testingsoc.GenerateMockSOC(t, payload)
+		jsonhttptest.Request(t, client, http.MethodPost, fmt.Sprintf("/gsoc/%x/%x?sig=%x", s.Owner, s.ID, s.Signature), http.StatusCreated,
+			jsonhttptest.WithRequestHeader(api.SwarmPostageBatchIdHeader, batchOkStr),
+			jsonhttptest.WithRequestBody(bytes.NewReader(payload)),
+			jsonhttptest.WithExpectedJSONResponse(api.SocPostResponse{Reference: s.Address()}),
+		)
+	})
+
+	t.Run("invalid signature", func(t *testing.T) {
+		s := testingsoc.GenerateMockSOC(t, payload)
+		jsonhttptest.Request(t, client, http.MethodPost, fmt.Sprintf("/gsoc/%x/%x?sig=%x", owner.Bytes(), s.ID, s.Signature), http.StatusUnauthorized,
+			jsonhttptest.WithRequestHeader(api.SwarmPostageBatchIdHeader, batchOkStr),
+			jsonhttptest.WithRequestBody(bytes.NewReader(payload)),
+		)
+	})
+
+	t.Run("signature required", func(t *testing.T) {
+		other := make([]byte, 20)
+		jsonhttptest.Request(t, client, http.MethodPost, fmt.Sprintf("/gsoc/%x/%x", other, id), http.StatusBadRequest,
+			jsonhttptest.WithRequestHeader(api.SwarmPostageBatchIdHeader, batchOkStr),
+			jsonhttptest.WithRequestBody(bytes.NewReader(payload)),
+			jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
+				Code:    http.StatusBadRequest,
+				Message: "signature required for owner other than the node",
+			}),
+		)
+	})
+}
+
+func TestGsocMine(t *testing.T) {
+	t.Parallel()
+
+	client, _, _, _ := newTestServer(t, testServerOptions{})
+	owner := make([]byte, 20)
+
+	var res api.GsocMineResponse
+	jsonhttptest.Request(t, client, http.MethodGet, fmt.Sprintf("/gsoc/mine/%x/ab", owner), http.StatusOK,
+		jsonhttptest.WithUnmarshalJSONResponse(&res),
+	)
+	id, err := hex.DecodeString(res.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr, err := soc.CreateAddress(id, owner)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !addr.Equal(res.Address) || addr.Bytes()[0] != 0xab {
+		t.Fatalf("mined address %s, want %s with prefix ab", res.Address, addr)
+	}
+
+	jsonhttptest.Request(t, client, http.MethodGet, fmt.Sprintf("/gsoc/mine/%x/abcdef01", owner), http.StatusBadRequest,
+		jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
+			Code:    http.StatusBadRequest,
+			Message: "invalid targets",
+		}),
+	)
+}
+
+func TestGsocPoll(t *testing.T) {
+	t.Parallel()
+
+	var (
+		id       = make([]byte, swarm.HashSize)
+		listener = gsoc.New(log.Noop)
+	)
+	testutil.CleanupCloser(t, listener)
+	privKey, err := crypto.GenerateSecp256k1Key()
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer := crypto.NewDefaultSigner(privKey)
+	owner, err := signer.EthereumAddress()
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr, err := soc.CreateAddress(id, owner.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	client, _, _, _ := newTestServer(t, testServerOptions{Gsoc: listener})
+	path := "/gsoc/poll/" + addr.String()
+
+	newGsoc := func(t *testing.T, payload []byte) *soc.SOC {
+		t.Helper()
+		ch, err := cac.New(payload)
+		if err != nil {
+			t.Fatal(err)
+		}
+		s := soc.New(id, ch)
+		if _, err := s.Sign(signer); err != nil {
+			t.Fatal(err)
+		}
+		return s
+	}
+
+	// the first poll subscribes to the address
+	jsonhttptest.Request(t, client, http.MethodGet, path+"?timeout=1", http.StatusOK,
+		jsonhttptest.WithExpectedJSONResponse(api.GsocPollResponse{Messages: []api.GsocPollMessage{}, Cursor: 0}),
+	)
+
+	listener.Handle(newGsoc(t, []byte("first")))
+	jsonhttptest.Request(t, client, http.MethodGet, path+"?timeout=5", http.StatusOK,
+		jsonhttptest.WithExpectedJSONResponse(api.GsocPollResponse{
+			Messages: []api.GsocPollMessage{{Seq: 0, Payload: []byte("first")}},
+			Cursor:   1,
+		}),
+	)
+
+	// the poll waits for the next message
+	second := newGsoc(t, []byte("second"))
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		listener.Handle(second)
+	}()
+	jsonhttptest.Request(t, client, http.MethodGet, path+"?timeout=5&cursor=1", http.StatusOK,
+		jsonhttptest.WithExpectedJSONResponse(api.GsocPollResponse{
+			Messages: []api.GsocPollMessage{{Seq: 1, Payload: []byte("second")}},
+			Cursor:   2,
+		}),
+	)
+}
+
+func TestGsocPollMaxPollers(t *testing.T) {
+	t.Parallel()
+
+	listener := gsoc.New(log.Noop)
+	testutil.CleanupCloser(t, listener)
+	client, _, _, _ := newTestServer(t, testServerOptions{Gsoc: listener})
+
+	addrs := make([]swarm.Address, api.GsocPollMaxPollers)
+	var eg errgroup.Group
+	for i := range addrs {
+		addrs[i] = swarm.RandAddress(t)
+		path := "/gsoc/poll/" + addrs[i].String() + "?timeout=1"
+		eg.Go(func() error {
+			resp, err := client.Get(path)
+			if err != nil {
+				return err
+			}
+			defer resp.Body.Close()
+			if resp.StatusCode != http.StatusOK {
+				return fmt.Errorf("poll %s: status %d", path, resp.StatusCode)
+			}
+			return nil
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		t.Fatal(err)
+	}
+
+	jsonhttptest.Request(t, client, http.MethodGet, "/gsoc/poll/"+swarm.RandAddress(t).String()+"?timeout=1", http.StatusTooManyRequests,
+		jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
+			Message: "too many polled addresses",
+			Code:    http.StatusTooManyRequests,
+		}),
+	)
+
+	// the addresses already polled are served
+	jsonhttptest.Request(t, client, http.MethodGet, "/gsoc/poll/"+addrs[0].String()+"?timeout=1", http.StatusOK,
+		jsonhttptest.WithExpectedJSONResponse(api.GsocPollResponse{Messages: []api.GsocPollMessage{}, Cursor: 0}),
+	)
+}
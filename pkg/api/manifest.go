@@ -0,0 +1,462 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/ethersphere/bee/v2/pkg/file/loadsave"
+	"github.com/ethersphere/bee/v2/pkg/file/redundancy"
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp"
+	"github.com/ethersphere/bee/v2/pkg/manifest"
+	"github.com/ethersphere/bee/v2/pkg/postage"
+	storage "github.com/ethersphere/bee/v2/pkg/storage"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+	"github.com/ethersphere/bee/v2/pkg/tracing"
+	"github.com/gorilla/mux"
+)
+
+const (
+	manifestListDefaultLimit = 100
+
+	// manifestPatchMaxRequestSize is the maximum size of the body of a
+	// manifest patch request.
+	manifestPatchMaxRequestSize = 1 << 20 // 1 MiB
+)
+
+// errManifestListLimit stops the manifest iteration once a page is full.
+var errManifestListLimit = errors.New("manifest list limit reached")
+
+type manifestEntryResponse struct {
+	Path      string            `json:"path"`
+	Reference swarm.Address     `json:"reference"`
+	Metadata  map[string]string `json:"metadata,omitempty"`
+}
+
+type manifestListResponse struct {
+	Entries []manifestEntryResponse `json:"entries"`
+	Next    string                  `json:"next,omitempty"`
+}
+
+// manifestListHandler lists the entries of a manifest whose path starts with
+// the given prefix. The entries are returned in lexicographical order of their
+// paths, beginning after the given cursor. When there are more entries than
+// the limit, the response contains the cursor for the next page.
+func (s *Service) manifestListHandler(w http.ResponseWriter, r *http.Request) {
+	logger := tracing.NewLoggerWithTraceID(r.Context(), s.logger.WithName("get_manifest").Build())
+
+	paths := struct {
+		Address swarm.Address `map:"address,resolve" validate:"required"`
+	}{}
+	if response := s.mapStructure(mux.Vars(r), &paths); response != nil {
+		response("invalid path params", logger, w)
+		return
+	}
+
+	queries := struct {
+		Prefix string `map:"prefix"`
+		Cursor string `map:"cursor"`
+		Limit  int    `map:"limit" validate:"min=0,max=1000"`
+	}{}
+	if response := s.mapStructure(r.URL.Query(), &queries); response != nil {
+		response("invalid query params", logger, w)
+		return
+	}
+	if queries.Limit == 0 {
+		queries.Limit = manifestListDefaultLimit
+	}
+
+	headers := struct {
+		Cache *bool `map:"Swarm-Cache"`
+	}{}
+	if response := s.mapStructure(r.Header, &headers); response != nil {
+		response("invalid header params", logger, w)
+		return
+	}
+	cache := true
+	if headers.Cache != nil {
+		cache = *headers.Cache
+	}
+
+	address := paths.Address
+	if v := getAddressFromContext(r.Context()); !v.IsZero() {
+		address = v
+	}
+
+	m, err := manifest.NewDefaultManifestReference(address, loadsave.NewReadonly(s.storer.Download(cache)))
+	if err != nil {
+		logger.Debug("manifest list: not manifest", "address", address, "error", err)
+		logger.Error(nil, "manifest list: not manifest")
+		jsonhttp.NotFound(w, nil)
+		return
+	}
+
+	resp := manifestListResponse{Entries: make([]manifestEntryResponse, 0)}
+	err = m.IterateEntriesAfter(r.Context(), queries.Prefix, queries.Cursor, func(path string, e manifest.Entry) error {
+		if len(resp.Entries) == queries.Limit {
+			resp.Next = resp.Entries[len(resp.Entries)-1].Path
+			return errManifestListLimit
+		}
+		resp.Entries = append(resp.Entries, manifestEntryResponse{
+			Path:      path,
+			Reference: e.Reference(),
+			Metadata:  e.Metadata(),
+		})
+		return nil
+	})
+	if err != nil && !errors.Is(err, errManifestListLimit) {
+		logger.Debug("manifest list: iterate entries failed", "address", address, "error", err)
+		logger.Error(nil, "manifest list: iterate entries failed")
+		jsonhttp.NotFound(w, "manifest not found")
+		return
+	}
+
+	jsonhttp.OK(w, resp)
+}
+
+const (
+	manifestOpAdd         = "add"
+	manifestOpRemove      = "remove"
+	manifestOpMove        = "move"
+	manifestOpSetMetadata = "setMetadata"
+)
+
+var errInvalidManifestOperation = errors.New("invalid manifest operation")
+
+// manifestOperation is a single change to be applied on a manifest.
+type manifestOperation struct {
+	Op        string            `json:"op"`
+	Path      string            `json:"path,omitempty"`
+	From      string            `json:"from,omitempty"`
+	To        string            `json:"to,omitempty"`
+	Reference swarm.Address     `json:"reference"`
+	Metadata  map[string]string `json:"metadata,omitempty"`
+}
+
+type manifestPatchRequest struct {
+	Operations []manifestOperation `json:"operations"`
+}
+
+// manifestPatchHandler applies the requested operations on an existing
+// manifest and stores the resulting one. Only the manifest nodes changed by
+// the operations are stamped and uploaded.
+func (s *Service) manifestPatchHandler(w http.ResponseWriter, r *http.Request) {
+	logger := tracing.NewLoggerWithTraceID(r.Context(), s.logger.WithName("patch_manifest").Build())
+
+	paths := struct {
+		Address swarm.Address `map:"address,resolve" validate:"required"`
+	}{}
+	if response := s.mapStructure(mux.Vars(r), &paths); response != nil {
+		response("invalid path params", logger, w)
+		return
+	}
+
+	headers := struct {
+		BatchID  []byte           `map:"Swarm-Postage-Batch-Id" validate:"required"`
+		SwarmTag uint64           `map:"Swarm-Tag"`
+		Pin      bool             `map:"Swarm-Pin"`
+		Deferred *bool            `map:"Swarm-Deferred-Upload"`
+		RLevel   redundancy.Level `map:"Swarm-Redundancy-Level"`
+	}{}
+	if response := s.mapStructure(r.Header, &headers); response != nil {
+		response("invalid header params", logger, w)
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		if jsonhttp.HandleBodyReadError(err, w) {
+			return
+		}
+		logger.Debug("read request body failed", "error", err)
+		logger.Error(nil, "read request body failed")
+		jsonhttp.InternalServerError(w, "cannot read request")
+		return
+	}
+
+	var req manifestPatchRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		logger.Debug("unmarshal body failed", "error", err)
+		logger.Error(nil, "unmarshal body failed")
+		jsonhttp.BadRequest(w, errInvalidRequest)
+		return
+	}
+	if len(req.Operations) == 0 {
+		jsonhttp.BadRequest(w, "no operations")
+		return
+	}
+
+	var (
+		tag      uint64
+		deferred = defaultUploadMethod(headers.Deferred)
+	)
+
+	if deferred || headers.Pin {
+		tag, err = s.getOrCreateSessionID(headers.SwarmTag)
+		if err != nil {
+			logger.Debug("get or create tag failed", "error", err)
+			logger.Error(nil, "get or create tag failed")
+			switch {
+			case errors.Is(err, storage.ErrNotFound):
+				jsonhttp.NotFound(w, "tag not found")
+			default:
+				jsonhttp.InternalServerError(w, "cannot get or create tag")
+			}
+			return
+		}
+	}
+
+	ctx := redundancy.SetLevelInContext(r.Context(), headers.RLevel)
+	putter, err := s.newStamperPutter(ctx, putterOptions{
+		BatchID:  headers.BatchID,
+		TagID:    tag,
+		Pin:      headers.Pin,
+		Deferred: deferred,
+	})
+	if err != nil {
+		logger.Debug("putter failed", "error", err)
+		logger.Error(nil, "putter failed")
+		switch {
+		case errors.Is(err, errBatchUnusable) || errors.Is(err, postage.ErrNotUsable):
+			jsonhttp.UnprocessableEntity(w, "batch not usable yet or does not exist")
+		case errors.Is(err, postage.ErrNotFound):
+			jsonhttp.NotFound(w, "batch with id not found")
+		case errors.Is(err, errInvalidPostageBatch):
+			jsonhttp.BadRequest(w, "invalid batch id")
+		case errors.Is(err, errUnsupportedDevNodeOperation):
+			jsonhttp.BadRequest(w, errUnsupportedDevNodeOperation)
+		default:
+			jsonhttp.BadRequest(w, nil)
+		}
+		return
+	}
+
+	ow := &cleanupOnErrWriter{
+		ResponseWriter: w,
+		onErr:          putter.Cleanup,
+		logger:         logger,
+	}
+
+	encrypt := len(paths.Address.Bytes()) == swarm.HashSize*2
+	ls := loadsave.New(s.storer.Download(true), putter, requestPipelineFactory(ctx, putter, encrypt, headers.RLevel))
+	m, err := manifest.NewDefaultManifestReference(paths.Address, ls)
+	if err != nil {
+		logger.Debug("manifest patch: not manifest", "address", paths.Address, "error", err)
+		logger.Error(nil, "manifest patch: not manifest")
+		jsonhttp.NotFound(ow, nil)
+		return
+	}
+
+	for i, op := range req.Operations {
+		if err := applyManifestOperation(ctx, m, op); err != nil {
+			logger.Debug("manifest patch: operation failed", "address", paths.Address, "operation", i, "error", err)
+			logger.Error(nil, "manifest patch: operation failed")
+			switch {
+			case errors.Is(err, errInvalidManifestOperation):
+				jsonhttp.BadRequest(ow, fmt.Sprintf("operation %d: %v", i, err))
+			case errors.Is(err, manifest.ErrNotFound):
+				jsonhttp.NotFound(ow, fmt.Sprintf("operation %d: path not found", i))
+			default:
+				jsonhttp.InternalServerError(ow, "manifest patch failed")
+			}
+			return
+		}
+	}
+
+	reference, err := m.Store(ctx)
+	if err != nil {
+		logger.Debug("manifest patch: store failed", "address", paths.Address, "error", err)
+		logger.Error(nil, "manifest patch: store failed")
+		switch {
+		case errors.Is(err, postage.ErrBucketFull):
+			jsonhttp.PaymentRequired(ow, "batch is overissued")
+		default:
+			jsonhttp.InternalServerError(ow, "manifest store failed")
+		}
+		return
+	}
+
+	err = putter.Done(reference)
+	if err != nil {
+		logger.Debug("manifest patch: done split failed", "error", err)
+		logger.Error(nil, "manifest patch: done split failed")
+		jsonhttp.InternalServerError(ow, "done split failed")
+		return
+	}
+
+	if tag != 0 {
+		w.Header().Set(SwarmTagHeader, fmt.Sprint(tag))
+	}
+	w.Header().Set("Access-Control-Expose-Headers", SwarmTagHeader)
+	jsonhttp.OK(w, bzzUploadResponse{
+		Reference: reference,
+	})
+}
+
+// applyManifestOperation applies a single operation on the manifest m.
+func applyManifestOperation(ctx context.Context, m manifest.Interface, op manifestOperation) error {
+	switch op.Op {
+	case manifestOpAdd:
+		if op.Path == "" || op.Reference.IsZero() {
+			return fmt.Errorf("%w: add requires path and reference", errInvalidManifestOperation)
+		}
+		return m.Add(ctx, op.Path, manifest.NewEntry(op.Reference, op.Metadata))
+	case manifestOpRemove:
+		if op.Path == "" {
+			return fmt.Errorf("%w: remove requires path", errInvalidManifestOperation)
+		}
+		return m.Remove(ctx, op.Path)
+	case manifestOpMove:
+		if op.From == "" || op.To == "" {
+			return fmt.Errorf("%w: move requires from and to", errInvalidManifestOperation)
+		}
+		return moveManifestPrefix(ctx, m, op.From, op.To)
+	case manifestOpSetMetadata:
+		if op.Path == "" || len(op.Metadata) == 0 {
+			return fmt.Errorf("%w: setMetadata requires path and metadata", errInvalidManifestOperation)
+		}
+		reference := swarm.ZeroAddress
+		e, err := m.Lookup(ctx, op.Path)
+		switch {
+		case err == nil:
+			reference = e.Reference()
+		case errors.Is(err, manifest.ErrNotFound) && op.Path == manifest.RootPath:
+			// the root entry holds only the website metadata
+		default:
+			return err
+		}
+		return m.Add(ctx, op.Path, manifest.NewEntry(reference, op.Metadata))
+	default:
+		return fmt.Errorf("%w: unknown operation %q", errInvalidManifestOperation, op.Op)
+	}
+}
+
+// moveManifestPrefix moves all the entries whose path starts with from
+// to the paths where the from prefix is replaced with to.
+func moveManifestPrefix(ctx context.Context, m manifest.Interface, from, to string) error {
+	type entry struct {
+		path  string
+		entry manifest.Entry
+	}
+	var entries []entry
+	err := m.IterateEntries(ctx, from, func(path string, e manifest.Entry) error {
+		entries = append(entries, entry{path: path, entry: e})
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if len(entries) == 0 {
+		return manifest.ErrNotFound
+	}
+
+	for _, e := range entries {
+		if err := m.Remove(ctx, e.path); err != nil {
+			return err
+		}
+	}
+	for _, e := range entries {
+		path := to + strings.TrimPrefix(e.path, from)
+		if err := m.Add(ctx, path, e.entry); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type manifestEntryValue struct {
+	Reference swarm.Address     `json:"reference"`
+	Metadata  map[string]string `json:"metadata,omitempty"`
+}
+
+type manifestChangeResponse struct {
+	Path string             `json:"path"`
+	Old  manifestEntryValue `json:"old"`
+	New  manifestEntryValue `json:"new"`
+}
+
+type manifestDiffResponse struct {
+	Added   []manifestEntryResponse  `json:"added"`
+	Removed []manifestEntryResponse  `json:"removed"`
+	Changed []manifestChangeResponse `json:"changed"`
+}
+
+// manifestDiffHandler reports the entries added, removed and changed
+// in the other manifest with respect to the manifest on address.
+func (s *Service) manifestDiffHandler(w http.ResponseWriter, r *http.Request) {
+	logger := tracing.NewLoggerWithTraceID(r.Context(), s.logger.WithName("get_manifest_diff").Build())
+
+	paths := struct {
+		Address swarm.Address `map:"address,resolve" validate:"required"`
+		Other   swarm.Address `map:"other,resolve" validate:"required"`
+	}{}
+	if response := s.mapStructure(mux.Vars(r), &paths); response != nil {
+		response("invalid path params", logger, w)
+		return
+	}
+
+	headers := struct {
+		Cache *bool `map:"Swarm-Cache"`
+	}{}
+	if response := s.mapStructure(r.Header, &headers); response != nil {
+		response("invalid header params", logger, w)
+		return
+	}
+	cache := true
+	if headers.Cache != nil {
+		cache = *headers.Cache
+	}
+
+	ls := loadsave.NewReadonly(s.storer.Download(cache))
+	a, err := manifest.NewDefaultManifestReference(paths.Address, ls)
+	if err != nil {
+		logger.Debug("manifest diff: not manifest", "address", paths.Address, "error", err)
+		logger.Error(nil, "manifest diff: not manifest")
+		jsonhttp.NotFound(w, nil)
+		return
+	}
+	b, err := manifest.NewDefaultManifestReference(paths.Other, ls)
+	if err != nil {
+		logger.Debug("manifest diff: not manifest", "address", paths.Other, "error", err)
+		logger.Error(nil, "manifest diff: not manifest")
+		jsonhttp.NotFound(w, nil)
+		return
+	}
+
+	resp := manifestDiffResponse{
+		Added:   make([]manifestEntryResponse, 0),
+		Removed: make([]manifestEntryResponse, 0),
+		Changed: make([]manifestChangeResponse, 0),
+	}
+	err = manifest.Diff(r.Context(), a, b, func(path string, ea, eb manifest.Entry) error {
+		switch {
+		case ea == nil:
+			resp.Added = append(resp.Added, manifestEntryResponse{Path: path, Reference: eb.Reference(), Metadata: eb.Metadata()})
+		case eb == nil:
+			resp.Removed = append(resp.Removed, manifestEntryResponse{Path: path, Reference: ea.Reference(), Metadata: ea.Metadata()})
+		default:
+			resp.Changed = append(resp.Changed, manifestChangeResponse{
+				Path: path,
+				Old:  manifestEntryValue{Reference: ea.Reference(), Metadata: ea.Metadata()},
+				New:  manifestEntryValue{Reference: eb.Reference(), Metadata: eb.Metadata()},
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		logger.Debug("manifest diff: compare failed", "address", paths.Address, "other", paths.Other, "error", err)
+		logger.Error(nil, "manifest diff: compare failed")
+		jsonhttp.NotFound(w, "manifest not found")
+		return
+	}
+
+	jsonhttp.OK(w, resp)
+}
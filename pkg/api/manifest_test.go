@@ -0,0 +1,367 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api_test
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/ethersphere/bee/v2/pkg/api"
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp/jsonhttptest"
+	"github.com/ethersphere/bee/v2/pkg/log"
+	"github.com/ethersphere/bee/v2/pkg/manifest"
+	mockpost "github.com/ethersphere/bee/v2/pkg/postage/mock"
+	mockstorer "github.com/ethersphere/bee/v2/pkg/storer/mock"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+)
+
+func TestManifestList(t *testing.T) {
+	t.Parallel()
+
+	var (
+		storer          = mockstorer.New()
+		client, _, _, _ = newTestServer(t, testServerOptions{
+			Storer: storer,
+			Logger: log.Noop,
+			Post:   mockpost.New(mockpost.WithAcceptAll()),
+		})
+		files = []f{
+			{data: []byte("robots text"), name: "robots.txt"},
+			{data: []byte("<h1>Swarm"), name: "index.html"},
+			{data: []byte("image 1"), name: "1.png", dir: "img"},
+			{data: []byte("image 2"), name: "2.png", dir: "img"},
+			{data: []byte("image 3"), name: "3.png", dir: "img"},
+		}
+	)
+
+	var upload api.BzzUploadResponse
+	jsonhttptest.Request(t, client, http.MethodPost, "/bzz", http.StatusCreated,
+		jsonhttptest.WithRequestHeader(api.SwarmDeferredUploadHeader, "true"),
+		jsonhttptest.WithRequestHeader(api.SwarmPostageBatchIdHeader, batchOkStr),
+		jsonhttptest.WithRequestBody(tarFiles(t, files)),
+		jsonhttptest.WithRequestHeader(api.SwarmCollectionHeader, "true"),
+		jsonhttptest.WithRequestHeader(api.SwarmIndexDocumentHeader, "index.html"),
+		jsonhttptest.WithRequestHeader(api.ContentTypeHeader, api.ContentTypeTar),
+		jsonhttptest.WithUnmarshalJSONResponse(&upload),
+	)
+
+	list := func(t *testing.T, query string) api.ManifestListResponse {
+		t.Helper()
+
+		var resp api.ManifestListResponse
+		jsonhttptest.Request(t, client, http.MethodGet, "/manifest/"+upload.Reference.String()+query, http.StatusOK,
+			jsonhttptest.WithUnmarshalJSONResponse(&resp),
+		)
+		return resp
+	}
+
+	paths := func(resp api.ManifestListResponse) []string {
+		var p []string
+		for _, e := range resp.Entries {
+			p = append(p, e.Path)
+		}
+		return p
+	}
+
+	t.Run("all entries", func(t *testing.T) {
+		t.Parallel()
+
+		resp := list(t, "")
+
+		want := []string{"/", "img/1.png", "img/2.png", "img/3.png", "index.html", "robots.txt"}
+		if got := paths(resp); !slices.Equal(got, want) {
+			t.Fatalf("got paths %v, want %v", got, want)
+		}
+		if resp.Next != "" {
+			t.Fatalf("got next cursor %q, want none", resp.Next)
+		}
+
+		root := resp.Entries[0]
+		if !root.Reference.Equal(swarm.NewAddress(make([]byte, swarm.HashSize))) {
+			t.Fatalf("got root reference %s, want zero address", root.Reference)
+		}
+		if root.Metadata[manifest.WebsiteIndexDocumentSuffixKey] != "index.html" {
+			t.Fatalf("got root metadata %v, want index document", root.Metadata)
+		}
+
+		index := resp.Entries[4]
+		if index.Reference.IsZero() {
+			t.Fatal("got zero reference for index.html")
+		}
+		if index.Metadata[manifest.EntryMetadataFilenameKey] != "index.html" {
+			t.Fatalf("got filename %q, want %q", index.Metadata[manifest.EntryMetadataFilenameKey], "index.html")
+		}
+		if index.Metadata[manifest.EntryMetadataContentTypeKey] != "text/html; charset=utf-8" {
+			t.Fatalf("got content type %q, want %q", index.Metadata[manifest.EntryMetadataContentTypeKey], "text/html; charset=utf-8")
+		}
+	})
+
+	t.Run("prefix", func(t *testing.T) {
+		t.Parallel()
+
+		want := []string{"img/1.png", "img/2.png", "img/3.png"}
+		if got := paths(list(t, "?prefix=img/")); !slices.Equal(got, want) {
+			t.Fatalf("got paths %v, want %v", got, want)
+		}
+	})
+
+	t.Run("pagination", func(t *testing.T) {
+		t.Parallel()
+
+		resp := list(t, "?prefix=img/&limit=2")
+		if got, want := paths(resp), []string{"img/1.png", "img/2.png"}; !slices.Equal(got, want) {
+			t.Fatalf("got paths %v, want %v", got, want)
+		}
+		if resp.Next != "img/2.png" {
+			t.Fatalf("got next cursor %q, want %q", resp.Next, "img/2.png")
+		}
+
+		resp = list(t, "?prefix=img/&limit=2&cursor="+resp.Next)
+		if got, want := paths(resp), []string{"img/3.png"}; !slices.Equal(got, want) {
+			t.Fatalf("got paths %v, want %v", got, want)
+		}
+		if resp.Next != "" {
+			t.Fatalf("got next cursor %q, want none", resp.Next)
+		}
+	})
+
+	t.Run("no matching prefix", func(t *testing.T) {
+		t.Parallel()
+
+		if resp := list(t, "?prefix=css/"); len(resp.Entries) != 0 {
+			t.Fatalf("got %d entries, want none", len(resp.Entries))
+		}
+	})
+
+	t.Run("invalid limit", func(t *testing.T) {
+		t.Parallel()
+
+		jsonhttptest.Request(t, client, http.MethodGet, "/manifest/"+upload.Reference.String()+"?limit=1001", http.StatusBadRequest)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		t.Parallel()
+
+		jsonhttptest.Request(t, client, http.MethodGet, "/manifest/"+swarm.RandAddress(t).String(), http.StatusNotFound)
+	})
+}
+
+func TestManifestPatch(t *testing.T) {
+	t.Parallel()
+
+	var (
+		storer          = mockstorer.New()
+		client, _, _, _ = newTestServer(t, testServerOptions{
+			Storer: storer,
+			Logger: log.Noop,
+			Post:   mockpost.New(mockpost.WithAcceptAll()),
+		})
+		files = []f{
+			{data: []byte("robots text"), name: "robots.txt"},
+			{data: []byte("<h1>Swarm"), name: "index.html"},
+			{data: []byte("image 1"), name: "1.png", dir: "img"},
+			{data: []byte("image 2"), name: "2.png", dir: "img"},
+		}
+	)
+
+	var upload api.BzzUploadResponse
+	jsonhttptest.Request(t, client, http.MethodPost, "/bzz", http.StatusCreated,
+		jsonhttptest.WithRequestHeader(api.SwarmDeferredUploadHeader, "true"),
+		jsonhttptest.WithRequestHeader(api.SwarmPostageBatchIdHeader, batchOkStr),
+		jsonhttptest.WithRequestBody(tarFiles(t, files)),
+		jsonhttptest.WithRequestHeader(api.SwarmCollectionHeader, "true"),
+		jsonhttptest.WithRequestHeader(api.SwarmIndexDocumentHeader, "index.html"),
+		jsonhttptest.WithRequestHeader(api.ContentTypeHeader, api.ContentTypeTar),
+		jsonhttptest.WithUnmarshalJSONResponse(&upload),
+	)
+
+	var newFile api.BytesPostResponse
+	jsonhttptest.Request(t, client, http.MethodPost, "/bytes", http.StatusCreated,
+		jsonhttptest.WithRequestHeader(api.SwarmDeferredUploadHeader, "true"),
+		jsonhttptest.WithRequestHeader(api.SwarmPostageBatchIdHeader, batchOkStr),
+		jsonhttptest.WithRequestBody(strings.NewReader("image 3")),
+		jsonhttptest.WithUnmarshalJSONResponse(&newFile),
+	)
+
+	patch := func(t *testing.T, status int, ops ...api.ManifestOperation) swarm.Address {
+		t.Helper()
+
+		var resp api.BzzUploadResponse
+		jsonhttptest.Request(t, client, http.MethodPatch, "/manifest/"+upload.Reference.String(), status,
+			jsonhttptest.WithRequestHeader(api.SwarmDeferredUploadHeader, "true"),
+			jsonhttptest.WithRequestHeader(api.SwarmPostageBatchIdHeader, batchOkStr),
+			jsonhttptest.WithJSONRequestBody(api.ManifestPatchRequest{Operations: ops}),
+			jsonhttptest.WithUnmarshalJSONResponse(&resp),
+		)
+		return resp.Reference
+	}
+
+	t.Run("apply operations", func(t *testing.T) {
+		t.Parallel()
+
+		reference := patch(t, http.StatusOK,
+			api.ManifestOperation{
+				Op:        "add",
+				Path:      "img/3.png",
+				Reference: newFile.Reference,
+				Metadata:  map[string]string{manifest.EntryMetadataContentTypeKey: "image/png"},
+			},
+			api.ManifestOperation{Op: "remove", Path: "robots.txt"},
+			api.ManifestOperation{Op: "move", From: "img/", To: "images/"},
+			api.ManifestOperation{
+				Op:       "setMetadata",
+				Path:     manifest.RootPath,
+				Metadata: map[string]string{manifest.WebsiteIndexDocumentSuffixKey: "images/1.png"},
+			},
+		)
+		if reference.Equal(upload.Reference) {
+			t.Fatal("expected a new manifest reference")
+		}
+
+		var resp api.ManifestListResponse
+		jsonhttptest.Request(t, client, http.MethodGet, "/manifest/"+reference.String(), http.StatusOK,
+			jsonhttptest.WithUnmarshalJSONResponse(&resp),
+		)
+		var got []string
+		for _, e := range resp.Entries {
+			got = append(got, e.Path)
+		}
+		want := []string{"/", "images/1.png", "images/2.png", "images/3.png", "index.html"}
+		if !slices.Equal(got, want) {
+			t.Fatalf("got paths %v, want %v", got, want)
+		}
+		if index := resp.Entries[0].Metadata[manifest.WebsiteIndexDocumentSuffixKey]; index != "images/1.png" {
+			t.Fatalf("got index document %q, want %q", index, "images/1.png")
+		}
+
+		jsonhttptest.Request(t, client, http.MethodGet, "/bzz/"+reference.String()+"/images/3.png", http.StatusOK,
+			jsonhttptest.WithExpectedResponse([]byte("image 3")),
+			jsonhttptest.WithExpectedContentLength(len("image 3")),
+			jsonhttptest.WithExpectedResponseHeader(api.ContentTypeHeader, "image/png"),
+		)
+		jsonhttptest.Request(t, client, http.MethodGet, "/bzz/"+reference.String()+"/", http.StatusOK,
+			jsonhttptest.WithExpectedResponse([]byte("image 1")),
+		)
+	})
+
+	t.Run("path not found", func(t *testing.T) {
+		t.Parallel()
+
+		patch(t, http.StatusNotFound, api.ManifestOperation{Op: "remove", Path: "css/main.css"})
+		patch(t, http.StatusNotFound, api.ManifestOperation{Op: "move", From: "css/", To: "style/"})
+	})
+
+	t.Run("invalid operation", func(t *testing.T) {
+		t.Parallel()
+
+		patch(t, http.StatusBadRequest, api.ManifestOperation{Op: "copy", Path: "index.html"})
+		patch(t, http.StatusBadRequest, api.ManifestOperation{Op: "add", Path: "index.html"})
+		patch(t, http.StatusBadRequest)
+	})
+
+	t.Run("request too large", func(t *testing.T) {
+		t.Parallel()
+
+		ops := make([]api.ManifestOperation, 0, 50000)
+		for i := 0; i < cap(ops); i++ {
+			ops = append(ops, api.ManifestOperation{Op: "remove", Path: fmt.Sprintf("file-%d", i)})
+		}
+		patch(t, http.StatusRequestEntityTooLarge, ops...)
+	})
+
+	t.Run("missing batch", func(t *testing.T) {
+		t.Parallel()
+
+		jsonhttptest.Request(t, client, http.MethodPatch, "/manifest/"+upload.Reference.String(), http.StatusBadRequest,
+			jsonhttptest.WithJSONRequestBody(api.ManifestPatchRequest{
+				Operations: []api.ManifestOperation{{Op: "remove", Path: "robots.txt"}},
+			}),
+		)
+	})
+}
+
+func TestManifestDiff(t *testing.T) {
+	t.Parallel()
+
+	var (
+		storer          = mockstorer.New()
+		client, _, _, _ = newTestServer(t, testServerOptions{
+			Storer: storer,
+			Logger: log.Noop,
+			Post:   mockpost.New(mockpost.WithAcceptAll()),
+		})
+	)
+
+	upload := func(t *testing.T, files []f) swarm.Address {
+		t.Helper()
+
+		var resp api.BzzUploadResponse
+		jsonhttptest.Request(t, client, http.MethodPost, "/bzz", http.StatusCreated,
+			jsonhttptest.WithRequestHeader(api.SwarmDeferredUploadHeader, "true"),
+			jsonhttptest.WithRequestHeader(api.SwarmPostageBatchIdHeader, batchOkStr),
+			jsonhttptest.WithRequestBody(tarFiles(t, files)),
+			jsonhttptest.WithRequestHeader(api.SwarmCollectionHeader, "true"),
+			jsonhttptest.WithRequestHeader(api.ContentTypeHeader, api.ContentTypeTar),
+			jsonhttptest.WithUnmarshalJSONResponse(&resp),
+		)
+		return resp.Reference
+	}
+
+	v1 := upload(t, []f{
+		{data: []byte("robots text"), name: "robots.txt"},
+		{data: []byte("<h1>Swarm"), name: "index.html"},
+		{data: []byte("image 1"), name: "1.png", dir: "img"},
+		{data: []byte("image 2"), name: "2.png", dir: "img"},
+	})
+	v2 := upload(t, []f{
+		{data: []byte("<h1>Swarm v2"), name: "index.html"},
+		{data: []byte("image 1"), name: "1.png", dir: "img"},
+		{data: []byte("image 2"), name: "2.png", dir: "img"},
+		{data: []byte("image 3"), name: "3.png", dir: "img"},
+	})
+
+	t.Run("changes", func(t *testing.T) {
+		t.Parallel()
+
+		var resp api.ManifestDiffResponse
+		jsonhttptest.Request(t, client, http.MethodGet, "/manifest/"+v1.String()+"/diff/"+v2.String(), http.StatusOK,
+			jsonhttptest.WithUnmarshalJSONResponse(&resp),
+		)
+
+		if len(resp.Added) != 1 || resp.Added[0].Path != "img/3.png" || resp.Added[0].Reference.IsZero() {
+			t.Fatalf("got added %+v, want img/3.png", resp.Added)
+		}
+		if len(resp.Removed) != 1 || resp.Removed[0].Path != "robots.txt" {
+			t.Fatalf("got removed %+v, want robots.txt", resp.Removed)
+		}
+		if len(resp.Changed) != 1 || resp.Changed[0].Path != "index.html" {
+			t.Fatalf("got changed %+v, want index.html", resp.Changed)
+		}
+		if resp.Changed[0].Old.Reference.Equal(resp.Changed[0].New.Reference) {
+			t.Fatal("expected the changed entry references to differ")
+		}
+	})
+
+	t.Run("same manifest", func(t *testing.T) {
+		t.Parallel()
+
+		var resp api.ManifestDiffResponse
+		jsonhttptest.Request(t, client, http.MethodGet, "/manifest/"+v1.String()+"/diff/"+v1.String(), http.StatusOK,
+			jsonhttptest.WithUnmarshalJSONResponse(&resp),
+		)
+		if len(resp.Added)+len(resp.Removed)+len(resp.Changed) != 0 {
+			t.Fatalf("got differences %+v, want none", resp)
+		}
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		t.Parallel()
+
+		jsonhttptest.Request(t, client, http.MethodGet, "/manifest/"+v1.String()+"/diff/"+swarm.RandAddress(t).String(), http.StatusNotFound)
+	})
+}
@@ -5,12 +5,18 @@
 package api
 
 import (
+	"bytes"
+	"cmp"
 	"encoding/json"
 	"errors"
 	"net/http"
+	"slices"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/ethersphere/bee/v2/pkg/jsonhttp"
+	"github.com/ethersphere/bee/v2/pkg/pinner"
 	"github.com/ethersphere/bee/v2/pkg/storage"
 	storer "github.com/ethersphere/bee/v2/pkg/storer"
 	"github.com/ethersphere/bee/v2/pkg/swarm"
@@ -31,6 +37,15 @@ func (s *Service) pinRootHash(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	queries := struct {
+		Label string `map:"label" validate:"max=256"`
+		TTL   int64  `map:"ttl" validate:"min=0"`
+	}{}
+	if response := s.mapStructure(r.URL.Query(), &queries); response != nil {
+		response("invalid query params", logger, w)
+		return
+	}
+
 	has, err := s.storer.HasPin(paths.Reference)
 	if err != nil {
 		logger.Debug("pin root hash: has pin failed", "chunk_address", paths.Reference, "error", err)
@@ -43,7 +58,7 @@ func (s *Service) pinRootHash(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	putter, err := s.storer.NewCollection(r.Context())
+	putter, err := s.storer.NewCollection(r.Context(), queries.Label, time.Duration(queries.TTL)*time.Second)
 	if err != nil {
 		logger.Debug("pin root hash: failed to create collection", "error", err)
 		logger.Error(nil, "pin root hash: failed to create collection")
@@ -98,11 +113,14 @@ func (s *Service) pinRootHash(w http.ResponseWriter, r *http.Request) {
 
 	if err := errors.Join(err, errTraverse); err != nil {
 		logger.Error(errors.Join(err, putter.Cleanup()), "pin collection failed")
-		if errors.Is(err, storage.ErrNotFound) {
+		switch {
+		case errors.Is(err, storage.ErrNotFound):
 			jsonhttp.NotFound(w, "pin collection failed")
-			return
+		case errors.Is(err, storer.ErrPinQuotaExceeded):
+			jsonhttp.RequestEntityTooLarge(w, "pin quota exceeded")
+		default:
+			jsonhttp.InternalServerError(w, "pin collection failed")
 		}
-		jsonhttp.InternalServerError(w, "pin collection failed")
 		return
 	}
 
@@ -183,11 +201,40 @@ func (s *Service) getPinnedRootHash(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
-// listPinnedRootHashes lists all the references of the pinned root hashes.
+type pinResponse struct {
+	Reference swarm.Address `json:"reference"`
+	Label     string        `json:"label"`
+	CreatedAt *time.Time    `json:"createdAt,omitempty"`
+	ExpiresAt *time.Time    `json:"expiresAt,omitempty"`
+	Chunks    uint64        `json:"chunks"`
+	Size      uint64        `json:"size"`
+}
+
+type pinsResponse struct {
+	References []swarm.Address `json:"references"`
+	Pins       []pinResponse   `json:"pins"`
+}
+
+// listPinnedRootHashes lists all the references of the pinned root hashes
+// with the metadata of their collections, filtered and sorted as requested.
 func (s *Service) listPinnedRootHashes(w http.ResponseWriter, r *http.Request) {
 	logger := s.logger.WithName("get_pins").Build()
 
-	pinned, err := s.storer.Pins()
+	queries := struct {
+		Label         *string `map:"label"`
+		CreatedAfter  int64   `map:"createdAfter"`
+		CreatedBefore int64   `map:"createdBefore"`
+		MinSize       uint64  `map:"minSize"`
+		MaxSize       uint64  `map:"maxSize"`
+		Sort          string  `map:"sort" validate:"omitempty,oneof=reference label createdAt chunks size"`
+		Order         string  `map:"order" validate:"omitempty,oneof=asc desc"`
+	}{}
+	if response := s.mapStructure(r.URL.Query(), &queries); response != nil {
+		response("invalid query params", logger, w)
+		return
+	}
+
+	pinned, err := s.storer.PinCollections()
 	if err != nil {
 		logger.Debug("list pinned root references: unable to list references", "error", err)
 		logger.Error(nil, "list pinned root references: unable to list references")
@@ -195,13 +242,214 @@ func (s *Service) listPinnedRootHashes(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	jsonhttp.OK(w, struct {
-		References []swarm.Address `json:"references"`
-	}{
-		References: pinned,
+	res := pinsResponse{
+		References: make([]swarm.Address, 0, len(pinned)),
+		Pins:       make([]pinResponse, 0, len(pinned)),
+	}
+	for _, p := range pinned {
+		switch {
+		case queries.Label != nil && p.Label != *queries.Label:
+			continue
+		case queries.CreatedAfter != 0 && !p.CreatedAt.After(time.Unix(queries.CreatedAfter, 0)):
+			continue
+		case queries.CreatedBefore != 0 && (p.CreatedAt.IsZero() || !p.CreatedAt.Before(time.Unix(queries.CreatedBefore, 0))):
+			continue
+		case queries.MinSize != 0 && p.Size < queries.MinSize:
+			continue
+		case queries.MaxSize != 0 && p.Size > queries.MaxSize:
+			continue
+		}
+		pr := pinResponse{
+			Reference: p.Reference,
+			Label:     p.Label,
+			Chunks:    p.Chunks,
+			Size:      p.Size,
+		}
+		if !p.CreatedAt.IsZero() {
+			createdAt := p.CreatedAt
+			pr.CreatedAt = &createdAt
+		}
+		if !p.ExpiresAt.IsZero() {
+			expiresAt := p.ExpiresAt
+			pr.ExpiresAt = &expiresAt
+		}
+		res.Pins = append(res.Pins, pr)
+	}
+
+	sortPins(res.Pins, queries.Sort, queries.Order == "desc")
+	for _, p := range res.Pins {
+		res.References = append(res.References, p.Reference)
+	}
+
+	jsonhttp.OK(w, res)
+}
+
+// sortPins sorts the pins by the field, by the reference if no field is given.
+func sortPins(pins []pinResponse, field string, desc bool) {
+	compare := func(a, b pinResponse) int {
+		switch field {
+		case "label":
+			return strings.Compare(a.Label, b.Label)
+		case "createdAt":
+			var at, bt time.Time
+			if a.CreatedAt != nil {
+				at = *a.CreatedAt
+			}
+			if b.CreatedAt != nil {
+				bt = *b.CreatedAt
+			}
+			return at.Compare(bt)
+		case "chunks":
+			return cmp.Compare(a.Chunks, b.Chunks)
+		case "size":
+			return cmp.Compare(a.Size, b.Size)
+		}
+		return 0
+	}
+	slices.SortStableFunc(pins, func(a, b pinResponse) int {
+		c := compare(a, b)
+		if c == 0 {
+			c = bytes.Compare(a.Reference.Bytes(), b.Reference.Bytes())
+		}
+		if desc {
+			return -c
+		}
+		return c
 	})
 }
 
+type pinJobResponse struct {
+	Reference swarm.Address `json:"reference"`
+	Label     string        `json:"label"`
+	State     pinner.State  `json:"state"`
+	Fetched   uint64        `json:"fetched"`
+	Remaining uint64        `json:"remaining"`
+	Error     string        `json:"error,omitempty"`
+}
+
+type pinJobsResponse struct {
+	Jobs []pinJobResponse `json:"jobs"`
+}
+
+func newPinJobResponse(j pinner.Job) pinJobResponse {
+	res := pinJobResponse{
+		Reference: j.Reference,
+		Label:     j.Label,
+		State:     j.State,
+		Fetched:   j.Fetched,
+		Remaining: j.Remaining,
+	}
+	if j.Err != nil {
+		res.Error = j.Err.Error()
+	}
+	return res
+}
+
+// startPinJobHandler starts pinning the reference in the background. The
+// failed job of the reference is restarted, the chunks it collected are kept.
+func (s *Service) startPinJobHandler(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.WithName("post_pin_job").Build()
+
+	paths := struct {
+		Reference swarm.Address `map:"reference" validate:"required"`
+	}{}
+	if response := s.mapStructure(mux.Vars(r), &paths); response != nil {
+		response("invalid path params", logger, w)
+		return
+	}
+
+	queries := struct {
+		Label string `map:"label" validate:"max=256"`
+		TTL   int64  `map:"ttl" validate:"min=0"`
+	}{}
+	if response := s.mapStructure(r.URL.Query(), &queries); response != nil {
+		response("invalid query params", logger, w)
+		return
+	}
+
+	job, err := s.pinner.Pin(paths.Reference, queries.Label, time.Duration(queries.TTL)*time.Second)
+	if err != nil {
+		switch {
+		case errors.Is(err, pinner.ErrPinned):
+			jsonhttp.OK(w, nil)
+			return
+		case errors.Is(err, pinner.ErrCancelling):
+			jsonhttp.Conflict(w, "pin job is being canceled")
+			return
+		}
+		logger.Debug("start pin job failed", "reference", paths.Reference, "error", err)
+		logger.Error(nil, "start pin job failed")
+		jsonhttp.InternalServerError(w, "start pin job failed")
+		return
+	}
+
+	jsonhttp.Accepted(w, newPinJobResponse(job))
+}
+
+// getPinJobHandler returns the progress of the job of the reference.
+func (s *Service) getPinJobHandler(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.WithName("get_pin_job").Build()
+
+	paths := struct {
+		Reference swarm.Address `map:"reference" validate:"required"`
+	}{}
+	if response := s.mapStructure(mux.Vars(r), &paths); response != nil {
+		response("invalid path params", logger, w)
+		return
+	}
+
+	job, err := s.pinner.Job(paths.Reference)
+	if err != nil {
+		logger.Debug("get pin job failed", "reference", paths.Reference, "error", err)
+		jsonhttp.NotFound(w, "pin job not found")
+		return
+	}
+
+	jsonhttp.OK(w, newPinJobResponse(job))
+}
+
+// listPinJobsHandler lists the pin jobs.
+func (s *Service) listPinJobsHandler(w http.ResponseWriter, _ *http.Request) {
+	jobs := s.pinner.Jobs()
+	res := pinJobsResponse{Jobs: make([]pinJobResponse, 0, len(jobs))}
+	for _, j := range jobs {
+		res.Jobs = append(res.Jobs, newPinJobResponse(j))
+	}
+
+	jsonhttp.OK(w, res)
+}
+
+// cancelPinJobHandler stops the job of the reference and removes the chunks it
+// collected unless the reference is pinned.
+func (s *Service) cancelPinJobHandler(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.WithName("delete_pin_job").Build()
+
+	paths := struct {
+		Reference swarm.Address `map:"reference" validate:"required"`
+	}{}
+	if response := s.mapStructure(mux.Vars(r), &paths); response != nil {
+		response("invalid path params", logger, w)
+		return
+	}
+
+	err := s.pinner.Cancel(paths.Reference)
+	switch {
+	case errors.Is(err, pinner.ErrNotFound):
+		jsonhttp.NotFound(w, "pin job not found")
+		return
+	case errors.Is(err, pinner.ErrCancelling):
+		jsonhttp.Conflict(w, "pin job is being canceled")
+		return
+	case err != nil:
+		logger.Debug("cancel pin job failed", "reference", paths.Reference, "error", err)
+		logger.Error(nil, "cancel pin job failed")
+		jsonhttp.InternalServerError(w, "cancel pin job failed")
+		return
+	}
+
+	jsonhttp.OK(w, nil)
+}
+
 type PinIntegrityResponse struct {
 	Reference swarm.Address `json:"reference"`
 	Total     int           `json:"total"`
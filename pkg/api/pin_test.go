@@ -6,20 +6,25 @@ package api_test
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/ethersphere/bee/v2/pkg/api"
 	"github.com/ethersphere/bee/v2/pkg/jsonhttp"
 	"github.com/ethersphere/bee/v2/pkg/jsonhttp/jsonhttptest"
 	"github.com/ethersphere/bee/v2/pkg/log"
+	"github.com/ethersphere/bee/v2/pkg/pinner"
 	mockpost "github.com/ethersphere/bee/v2/pkg/postage/mock"
+	"github.com/ethersphere/bee/v2/pkg/spinlock"
 	storage "github.com/ethersphere/bee/v2/pkg/storage"
 	"github.com/ethersphere/bee/v2/pkg/storage/inmemstore"
 	storer "github.com/ethersphere/bee/v2/pkg/storer"
 	mockstorer "github.com/ethersphere/bee/v2/pkg/storer/mock"
 	"github.com/ethersphere/bee/v2/pkg/swarm"
+	"github.com/ethersphere/bee/v2/pkg/util/testutil"
 )
 
 func checkPinHandlers(t *testing.T, client *http.Client, rootHash string, createPin bool) {
@@ -59,13 +64,16 @@ func checkPinHandlers(t *testing.T, client *http.Client, rootHash string, create
 		}),
 	)
 
+	var pins api.PinsResponse
 	jsonhttptest.Request(t, client, http.MethodGet, pinsBasePath, http.StatusOK,
-		jsonhttptest.WithExpectedJSONResponse(struct {
-			References []swarm.Address `json:"references"`
-		}{
-			References: []swarm.Address{swarm.MustParseHexAddress(rootHash)},
-		}),
+		jsonhttptest.WithUnmarshalJSONResponse(&pins),
 	)
+	if len(pins.References) != 1 || !pins.References[0].Equal(swarm.MustParseHexAddress(rootHash)) {
+		t.Fatalf("got references %v, want %s", pins.References, rootHash)
+	}
+	if len(pins.Pins) != 1 || !pins.Pins[0].Reference.Equal(swarm.MustParseHexAddress(rootHash)) {
+		t.Fatalf("got pins %v, want %s", pins.Pins, rootHash)
+	}
 
 	jsonhttptest.Request(t, client, http.MethodDelete, pinsReferencePath, http.StatusOK)
 
@@ -139,6 +147,109 @@ func TestPinHandlers(t *testing.T) {
 
 }
 
+func TestPinsMetadata(t *testing.T) {
+	t.Parallel()
+
+	client, _, _, _ := newTestServer(t, testServerOptions{
+		Storer: mockstorer.New(),
+		Post:   mockpost.New(mockpost.WithAcceptAll()),
+	})
+
+	pin := func(t *testing.T, data, label string) swarm.Address {
+		t.Helper()
+
+		var res api.BytesPostResponse
+		jsonhttptest.Request(t, client, http.MethodPost, "/bytes", http.StatusCreated,
+			jsonhttptest.WithRequestHeader(api.SwarmDeferredUploadHeader, "true"),
+			jsonhttptest.WithRequestHeader(api.SwarmPostageBatchIdHeader, batchOkStr),
+			jsonhttptest.WithRequestBody(strings.NewReader(data)),
+			jsonhttptest.WithUnmarshalJSONResponse(&res),
+		)
+		jsonhttptest.Request(t, client, http.MethodPost, "/pins/"+res.Reference.String()+"?label="+label, http.StatusCreated)
+		return res.Reference
+	}
+
+	var (
+		small  = pin(t, "small", "site")
+		large  = pin(t, strings.Repeat("large", 2000), "backup")
+		medium = pin(t, strings.Repeat("medium", 100), "site")
+	)
+
+	list := func(t *testing.T, query string) api.PinsResponse {
+		t.Helper()
+
+		var res api.PinsResponse
+		jsonhttptest.Request(t, client, http.MethodGet, "/pins"+query, http.StatusOK,
+			jsonhttptest.WithUnmarshalJSONResponse(&res),
+		)
+		if len(res.References) != len(res.Pins) {
+			t.Fatalf("got %d references and %d pins", len(res.References), len(res.Pins))
+		}
+		return res
+	}
+	expect := func(t *testing.T, res api.PinsResponse, want ...swarm.Address) {
+		t.Helper()
+
+		if len(res.Pins) != len(want) {
+			t.Fatalf("got %d pins, want %d", len(res.Pins), len(want))
+		}
+		for i, p := range res.Pins {
+			if !p.Reference.Equal(want[i]) || !res.References[i].Equal(want[i]) {
+				t.Fatalf("got pin %s at %d, want %s", p.Reference, i, want[i])
+			}
+		}
+	}
+
+	t.Run("metadata", func(t *testing.T) {
+		res := list(t, "?label=backup")
+		expect(t, res, large)
+		p := res.Pins[0]
+		if p.Label != "backup" || p.CreatedAt == nil || p.Chunks != 4 || p.Size != 10000+3*swarm.SpanSize+3*swarm.HashSize+swarm.SpanSize {
+			t.Fatalf("unexpected pin metadata %+v", p)
+		}
+	})
+
+	t.Run("filter", func(t *testing.T) {
+		expect(t, list(t, "?label=site&sort=size"), small, medium)
+		expect(t, list(t, "?minSize=100&maxSize=1000"), medium)
+		expect(t, list(t, "?label=none"))
+		expect(t, list(t, fmt.Sprintf("?createdBefore=%d", time.Now().Add(-time.Hour).Unix())))
+		expect(t, list(t, fmt.Sprintf("?createdAfter=%d&sort=size", time.Now().Add(-time.Hour).Unix())), small, medium, large)
+	})
+
+	t.Run("sort", func(t *testing.T) {
+		expect(t, list(t, "?sort=size&order=desc"), large, medium, small)
+		expect(t, list(t, "?sort=chunks"), small, medium, large)
+	})
+
+	t.Run("expiry", func(t *testing.T) {
+		if p := list(t, "?label=site&sort=size").Pins[0]; p.ExpiresAt != nil {
+			t.Fatalf("unexpected expiry of pin %+v", p)
+		}
+
+		var res api.BytesPostResponse
+		jsonhttptest.Request(t, client, http.MethodPost, "/bytes", http.StatusCreated,
+			jsonhttptest.WithRequestHeader(api.SwarmDeferredUploadHeader, "true"),
+			jsonhttptest.WithRequestHeader(api.SwarmPostageBatchIdHeader, batchOkStr),
+			jsonhttptest.WithRequestBody(strings.NewReader("expiring")),
+			jsonhttptest.WithUnmarshalJSONResponse(&res),
+		)
+		jsonhttptest.Request(t, client, http.MethodPost, "/pins/"+res.Reference.String()+"?label=expiring&ttl=3600", http.StatusCreated)
+
+		p := list(t, "?label=expiring").Pins[0]
+		if p.ExpiresAt == nil || p.ExpiresAt.Sub(*p.CreatedAt) != time.Hour {
+			t.Fatalf("unexpected expiry of pin %+v", p)
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		jsonhttptest.Request(t, client, http.MethodGet, "/pins?sort=color", http.StatusBadRequest)
+		jsonhttptest.Request(t, client, http.MethodPost, "/pins/"+swarm.RandAddress(t).String()+"?ttl=-1", http.StatusBadRequest)
+		jsonhttptest.Request(t, client, http.MethodGet, "/pins?order=up", http.StatusBadRequest)
+		jsonhttptest.Request(t, client, http.MethodPost, "/pins/"+swarm.RandAddress(t).String()+"?label="+strings.Repeat("x", 257), http.StatusBadRequest)
+	})
+}
+
 func TestPinHandlersInvalidInputs(t *testing.T) {
 	t.Parallel()
 
@@ -239,3 +350,101 @@ func (p *mockPinIntegrity) Check(ctx context.Context, logger log.Logger, pin str
 	}
 	close(out)
 }
+
+func TestPinQuota(t *testing.T) {
+	t.Parallel()
+
+	client, _, _, _ := newTestServer(t, testServerOptions{
+		Storer: mockstorer.NewWithPinQuota(1000),
+		Post:   mockpost.New(mockpost.WithAcceptAll()),
+	})
+
+	upload := func(t *testing.T, data string) swarm.Address {
+		t.Helper()
+
+		var res api.BytesPostResponse
+		jsonhttptest.Request(t, client, http.MethodPost, "/bytes", http.StatusCreated,
+			jsonhttptest.WithRequestHeader(api.SwarmDeferredUploadHeader, "true"),
+			jsonhttptest.WithRequestHeader(api.SwarmPostageBatchIdHeader, batchOkStr),
+			jsonhttptest.WithRequestBody(strings.NewReader(data)),
+			jsonhttptest.WithUnmarshalJSONResponse(&res),
+		)
+		return res.Reference
+	}
+
+	jsonhttptest.Request(t, client, http.MethodPost, "/pins/"+upload(t, "small").String(), http.StatusCreated)
+	jsonhttptest.Request(t, client, http.MethodPost, "/pins/"+upload(t, strings.Repeat("large", 2000)).String(), http.StatusRequestEntityTooLarge,
+		jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
+			Code:    http.StatusRequestEntityTooLarge,
+			Message: "pin quota exceeded",
+		}),
+	)
+}
+
+func TestPinJobs(t *testing.T) {
+	t.Parallel()
+
+	st := mockstorer.New()
+	p, err := pinner.New(st, log.Noop)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testutil.CleanupCloser(t, p)
+
+	client, _, _, _ := newTestServer(t, testServerOptions{
+		Storer: st,
+		Post:   mockpost.New(mockpost.WithAcceptAll()),
+		Pinner: p,
+	})
+
+	var uploaded api.BytesPostResponse
+	jsonhttptest.Request(t, client, http.MethodPost, "/bytes", http.StatusCreated,
+		jsonhttptest.WithRequestHeader(api.SwarmDeferredUploadHeader, "true"),
+		jsonhttptest.WithRequestHeader(api.SwarmPostageBatchIdHeader, batchOkStr),
+		jsonhttptest.WithRequestBody(strings.NewReader(strings.Repeat("data", 2000))),
+		jsonhttptest.WithUnmarshalJSONResponse(&uploaded),
+	)
+	jobPath := "/pins/jobs/" + uploaded.Reference.String()
+
+	var res api.PinJobResponse
+	jsonhttptest.Request(t, client, http.MethodPost, jobPath+"?label=job", http.StatusAccepted,
+		jsonhttptest.WithUnmarshalJSONResponse(&res),
+	)
+	if !res.Reference.Equal(uploaded.Reference) || res.Label != "job" {
+		t.Fatalf("unexpected job %+v", res)
+	}
+
+	err = spinlock.Wait(5*time.Second, func() bool {
+		jsonhttptest.Request(t, client, http.MethodGet, jobPath, http.StatusOK,
+			jsonhttptest.WithUnmarshalJSONResponse(&res),
+		)
+		return res.State == pinner.StateDone
+	})
+	if err != nil {
+		t.Fatalf("job not done: %+v", res)
+	}
+	if res.Fetched != 3 || res.Remaining != 0 || res.Error != "" {
+		t.Fatalf("unexpected job %+v", res)
+	}
+
+	var jobs api.PinJobsResponse
+	jsonhttptest.Request(t, client, http.MethodGet, "/pins/jobs", http.StatusOK,
+		jsonhttptest.WithUnmarshalJSONResponse(&jobs),
+	)
+	if len(jobs.Jobs) != 1 || !jobs.Jobs[0].Reference.Equal(res.Reference) || jobs.Jobs[0].State != pinner.StateDone {
+		t.Fatalf("unexpected jobs %+v", jobs)
+	}
+
+	jsonhttptest.Request(t, client, http.MethodGet, "/pins/"+uploaded.Reference.String(), http.StatusOK)
+	jsonhttptest.Request(t, client, http.MethodPost, jobPath, http.StatusOK)
+
+	jsonhttptest.Request(t, client, http.MethodDelete, jobPath, http.StatusOK)
+	jsonhttptest.Request(t, client, http.MethodDelete, jobPath, http.StatusNotFound,
+		jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
+			Code:    http.StatusNotFound,
+			Message: "pin job not found",
+		}),
+	)
+	jsonhttptest.Request(t, client, http.MethodGet, jobPath, http.StatusNotFound)
+	jsonhttptest.Request(t, client, http.MethodPost, jobPath+"?ttl=-1", http.StatusBadRequest)
+}
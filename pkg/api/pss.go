@@ -26,8 +26,23 @@ import (
 const (
 	writeDeadline   = 4 * time.Second // write deadline. should be smaller than the shutdown timeout on api close
 	targetMaxLength = 3               // max target length in bytes, in order to prevent grieving by excess computation
+	ackTargetLength = 2               // length of the default ack target derived from the overlay address
+
+	defaultReliableTimeout = 5 * time.Minute
 )
 
+type pssSendResponse struct {
+	ID string `json:"id"`
+}
+
+type pssMessageResponse struct {
+	ID       string     `json:"id"`
+	State    string     `json:"state"`
+	Attempts int        `json:"attempts"`
+	Deadline time.Time  `json:"deadline"`
+	AckedAt  *time.Time `json:"ackedAt,omitempty"`
+}
+
 func (s *Service) pssPostHandler(w http.ResponseWriter, r *http.Request) {
 	logger := s.logger.WithName("post_pss_send").Build()
 
@@ -54,7 +69,10 @@ func (s *Service) pssPostHandler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	queries := struct {
-		Recipient *ecdsa.PublicKey `map:"recipient,omitempty"`
+		Recipient  *ecdsa.PublicKey `map:"recipient,omitempty"`
+		Reliable   bool             `map:"reliable"`
+		AckTargets string           `map:"ackTargets"`
+		Timeout    int64            `map:"timeout" validate:"min=0"`
 	}{}
 	if response := s.mapStructure(r.URL.Query(), &queries); response != nil {
 		response("invalid query params", logger, w)
@@ -64,6 +82,25 @@ func (s *Service) pssPostHandler(w http.ResponseWriter, r *http.Request) {
 		queries.Recipient = &(crypto.Secp256k1PrivateKeyFromBytes(topic[:])).PublicKey
 	}
 
+	var ackTargets pss.Targets
+	if queries.Reliable {
+		switch {
+		case queries.AckTargets != "":
+			t, err := parseTargets(queries.AckTargets)
+			if err != nil {
+				logger.Debug("parse ack targets failed", "error", err)
+				jsonhttp.BadRequest(w, "invalid ack targets")
+				return
+			}
+			ackTargets = t
+		case s.overlay != nil:
+			ackTargets = pss.Targets{pss.Target(s.overlay.Bytes()[:ackTargetLength])}
+		default:
+			jsonhttp.BadRequest(w, "ack targets required")
+			return
+		}
+	}
+
 	headers := struct {
 		BatchID []byte `map:"Swarm-Postage-Batch-Id" validate:"required"`
 	}{}
@@ -96,7 +133,16 @@ func (s *Service) pssPostHandler(w http.ResponseWriter, r *http.Request) {
 
 	stamper := postage.NewStamper(s.stamperStore, i, s.signer)
 
-	err = s.pss.Send(r.Context(), topic, payload, stamper, queries.Recipient, targets)
+	var id pss.MessageID
+	if queries.Reliable {
+		timeout := defaultReliableTimeout
+		if queries.Timeout > 0 {
+			timeout = time.Duration(queries.Timeout) * time.Second
+		}
+		id, err = s.pss.SendReliable(r.Context(), topic, payload, stamper, queries.Recipient, targets, ackTargets, time.Now().Add(timeout))
+	} else {
+		err = s.pss.Send(r.Context(), topic, payload, stamper, queries.Recipient, targets)
+	}
 	if err != nil {
 		logger.Debug("send payload failed", "topic", paths.Topic, "error", err)
 		logger.Error(nil, "send payload failed")
@@ -116,9 +162,50 @@ func (s *Service) pssPostHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if queries.Reliable {
+		jsonhttp.Created(w, pssSendResponse{ID: id.String()})
+		return
+	}
 	jsonhttp.Created(w, nil)
 }
 
+func (s *Service) pssMessageHandler(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.WithName("get_pss_message").Build()
+
+	paths := struct {
+		ID []byte `map:"id" validate:"required,len=32"`
+	}{}
+	if response := s.mapStructure(mux.Vars(r), &paths); response != nil {
+		response("invalid path params", logger, w)
+		return
+	}
+
+	var id pss.MessageID
+	copy(id[:], paths.ID)
+	d, err := s.pss.Delivery(id)
+	if err != nil {
+		if errors.Is(err, pss.ErrMessageNotFound) {
+			jsonhttp.NotFound(w, "message not found")
+			return
+		}
+		logger.Debug("get delivery failed", "id", id, "error", err)
+		logger.Error(nil, "get delivery failed")
+		jsonhttp.InternalServerError(w, "get delivery failed")
+		return
+	}
+
+	res := pssMessageResponse{
+		ID:       d.ID.String(),
+		State:    string(d.State),
+		Attempts: d.Attempts,
+		Deadline: d.Deadline,
+	}
+	if !d.AckedAt.IsZero() {
+		res.AckedAt = &d.AckedAt
+	}
+	jsonhttp.OK(w, res)
+}
+
 func (s *Service) pssWsHandler(w http.ResponseWriter, r *http.Request) {
 	logger := s.logger.WithName("pss_subscribe").Build()
 
@@ -130,6 +217,36 @@ func (s *Service) pssWsHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// the reliable messages are acknowledged only if the subscriber pays for the acks
+	headers := struct {
+		BatchID []byte `map:"Swarm-Postage-Batch-Id"`
+	}{}
+	if response := s.mapStructure(r.Header, &headers); response != nil {
+		response("invalid header params", logger, w)
+		return
+	}
+	var (
+		stamper postage.Stamper
+		save    func() error
+	)
+	if len(headers.BatchID) > 0 {
+		i, sv, err := s.post.GetStampIssuer(headers.BatchID)
+		if err != nil {
+			logger.Debug("get postage batch issuer failed", "batch_id", hex.EncodeToString(headers.BatchID), "error", err)
+			logger.Error(nil, "get postage batch issuer failed")
+			switch {
+			case errors.Is(err, postage.ErrNotFound):
+				jsonhttp.BadRequest(w, "batch not found")
+			case errors.Is(err, postage.ErrNotUsable):
+				jsonhttp.BadRequest(w, "batch not usable yet")
+			default:
+				jsonhttp.BadRequest(w, "postage stamp issuer")
+			}
+			return
+		}
+		stamper, save = postage.NewStamper(s.stamperStore, i, s.signer), sv
+	}
+
 	upgrader := websocket.Upgrader{
 		ReadBufferSize:  swarm.ChunkSize,
 		WriteBufferSize: swarm.ChunkSize,
@@ -145,10 +262,12 @@ func (s *Service) pssWsHandler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	s.wsWg.Add(1)
-	go s.pumpWs(conn, paths.Topic)
+	go s.pumpWs(conn, paths.Topic, stamper, save)
 }
 
-func (s *Service) pumpWs(conn *websocket.Conn, t string) {
+// pumpWs writes the messages of the topic to the websocket connection. If
+// the stamper is set, the reliable messages are acknowledged with it.
+func (s *Service) pumpWs(conn *websocket.Conn, t string, stamper postage.Stamper, save func() error) {
 	defer s.wsWg.Done()
 
 	var (
@@ -176,12 +295,42 @@ func (s *Service) pumpWs(conn *websocket.Conn, t string) {
 
 	defer cleanup()
 
+	if stamper != nil {
+		defer func() {
+			if err := save(); err != nil {
+				s.logger.Debug("pss ws: save stamp failed", "error", err)
+			}
+		}()
+		defer s.pss.Acknowledge(topic, stamper)()
+	}
+
 	conn.SetCloseHandler(func(code int, text string) error {
 		s.logger.Debug("pss ws: client gone", "code", code, "message", text)
 		close(gone)
 		return nil
 	})
 
+	// the messages kept in the inbox while no subscriber was connected are written first
+	backlog, err := s.pss.InboxMessages(topic)
+	if err != nil && !errors.Is(err, pss.ErrInboxNotFound) && !errors.Is(err, pss.ErrInboxDisabled) {
+		s.logger.Debug("pss ws: get inbox messages failed", "error", err)
+	}
+	for _, m := range backlog {
+		err = conn.SetWriteDeadline(time.Now().Add(writeDeadline))
+		if err != nil {
+			s.logger.Debug("pss ws: set write deadline failed", "error", err)
+			return
+		}
+		err = conn.WriteMessage(websocket.BinaryMessage, m.Payload)
+		if err != nil {
+			s.logger.Debug("pss ws: write message failed", "error", err)
+			return
+		}
+		if err = s.pss.AckInboxMessage(topic, m.ID); err != nil {
+			s.logger.Debug("pss ws: ack inbox message failed", "id", m.ID, "error", err)
+		}
+	}
+
 	for {
 		select {
 		case b := <-dataC:
@@ -0,0 +1,363 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api
+
+import (
+	"crypto/ecdsa"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/ethersphere/bee/v2/pkg/crypto"
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp"
+	"github.com/ethersphere/bee/v2/pkg/postage"
+	"github.com/ethersphere/bee/v2/pkg/pss"
+	"github.com/gorilla/mux"
+)
+
+type pssGroupMember struct {
+	PublicKey string `json:"publicKey"`
+	Targets   string `json:"targets"`
+}
+
+type pssGroupRequest struct {
+	Members []pssGroupMember `json:"members"`
+}
+
+type pssGroupPatchRequest struct {
+	Add    []pssGroupMember `json:"add"`
+	Remove []string         `json:"remove"`
+}
+
+type pssGroupResponse struct {
+	Owner   string           `json:"owner"`
+	Epoch   uint64           `json:"epoch"`
+	Members []pssGroupMember `json:"members"`
+}
+
+func newPssGroupResponse(g *pss.Group) pssGroupResponse {
+	res := pssGroupResponse{
+		Owner:   hex.EncodeToString(crypto.EncodeSecp256k1PublicKey(g.Owner)),
+		Epoch:   g.Epoch,
+		Members: make([]pssGroupMember, 0, len(g.Members)),
+	}
+	for _, m := range g.Members {
+		targets := make([]string, 0, len(m.Targets))
+		for _, t := range m.Targets {
+			targets = append(targets, hex.EncodeToString(t))
+		}
+		res.Members = append(res.Members, pssGroupMember{
+			PublicKey: hex.EncodeToString(crypto.EncodeSecp256k1PublicKey(m.PublicKey)),
+			Targets:   strings.Join(targets, ","),
+		})
+	}
+	return res
+}
+
+// parseTargets parses the comma separated hex encoded targets.
+func parseTargets(v string) (pss.Targets, error) {
+	var targets pss.Targets
+	for _, t := range strings.Split(v, ",") {
+		target, err := hex.DecodeString(t)
+		if err != nil {
+			return nil, err
+		}
+		if len(target) == 0 || len(target) > targetMaxLength {
+			return nil, fmt.Errorf("invalid target length %d", len(target))
+		}
+		targets = append(targets, target)
+	}
+	return targets, nil
+}
+
+func parseGroupMembers(members []pssGroupMember) ([]pss.Member, error) {
+	res := make([]pss.Member, 0, len(members))
+	for _, m := range members {
+		pub, err := pss.ParseRecipient(m.PublicKey)
+		if err != nil {
+			return nil, err
+		}
+		targets, err := parseTargets(m.Targets)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, pss.Member{PublicKey: pub, Targets: targets})
+	}
+	return res, nil
+}
+
+// pssGroupErrorResponse writes the response for the errors of the group operations.
+func (s *Service) pssGroupErrorResponse(w http.ResponseWriter, msg string, err error) {
+	switch {
+	case errors.Is(err, pss.ErrGroupNotFound):
+		jsonhttp.NotFound(w, "group not found")
+	case errors.Is(err, pss.ErrGroupExists):
+		jsonhttp.Conflict(w, "group already exists")
+	case errors.Is(err, pss.ErrNotGroupOwner):
+		jsonhttp.Forbidden(w, "not the owner of the group")
+	case errors.Is(err, pss.ErrNoGroupKey):
+		jsonhttp.Conflict(w, "group key not received yet")
+	case errors.Is(err, pss.ErrGroupTooBig):
+		jsonhttp.BadRequest(w, "too many group members")
+	case errors.Is(err, postage.ErrBucketFull):
+		jsonhttp.PaymentRequired(w, "batch is overissued")
+	default:
+		jsonhttp.InternalServerError(w, msg)
+	}
+}
+
+// pssGroupStamper returns the stamper of the batch and the function that saves its issuer.
+func (s *Service) pssGroupStamper(w http.ResponseWriter, batchID []byte) (postage.Stamper, func() error, bool) {
+	i, save, err := s.post.GetStampIssuer(batchID)
+	if err != nil {
+		s.logger.Debug("get postage batch issuer failed", "batch_id", hex.EncodeToString(batchID), "error", err)
+		s.logger.Error(nil, "get postage batch issuer failed")
+		switch {
+		case errors.Is(err, postage.ErrNotFound):
+			jsonhttp.BadRequest(w, "batch not found")
+		case errors.Is(err, postage.ErrNotUsable):
+			jsonhttp.BadRequest(w, "batch not usable yet")
+		default:
+			jsonhttp.BadRequest(w, "postage stamp issuer")
+		}
+		return nil, nil, false
+	}
+	return postage.NewStamper(s.stamperStore, i, s.signer), save, true
+}
+
+// readJSONBody unmarshals the request body into v.
+func readJSONBody(w http.ResponseWriter, r *http.Request, v any) bool {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		if !jsonhttp.HandleBodyReadError(err, w) {
+			jsonhttp.InternalServerError(w, "cannot read request")
+		}
+		return false
+	}
+	if err := json.Unmarshal(body, v); err != nil {
+		jsonhttp.BadRequest(w, errInvalidRequest)
+		return false
+	}
+	return true
+}
+
+func (s *Service) pssGroupCreateHandler(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.WithName("post_pss_group").Build()
+
+	paths := struct {
+		Topic string `map:"topic" validate:"required"`
+	}{}
+	if response := s.mapStructure(mux.Vars(r), &paths); response != nil {
+		response("invalid path params", logger, w)
+		return
+	}
+	headers := struct {
+		BatchID []byte `map:"Swarm-Postage-Batch-Id" validate:"required"`
+	}{}
+	if response := s.mapStructure(r.Header, &headers); response != nil {
+		response("invalid header params", logger, w)
+		return
+	}
+
+	var req pssGroupRequest
+	if !readJSONBody(w, r, &req) {
+		return
+	}
+	members, err := parseGroupMembers(req.Members)
+	if err != nil {
+		logger.Debug("parse group members failed", "error", err)
+		jsonhttp.BadRequest(w, "invalid members")
+		return
+	}
+
+	stamper, save, ok := s.pssGroupStamper(w, headers.BatchID)
+	if !ok {
+		return
+	}
+	g, err := s.pss.CreateGroup(r.Context(), pss.NewTopic(paths.Topic), stamper, members)
+	if err != nil {
+		logger.Debug("create group failed", "topic", paths.Topic, "error", err)
+		logger.Error(nil, "create group failed")
+		s.pssGroupErrorResponse(w, "create group failed", err)
+		return
+	}
+	if err := save(); err != nil {
+		logger.Debug("save stamp failed", "error", err)
+		logger.Error(nil, "save stamp failed")
+		jsonhttp.InternalServerError(w, "create group failed")
+		return
+	}
+
+	jsonhttp.Created(w, newPssGroupResponse(g))
+}
+
+func (s *Service) pssGroupPatchHandler(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.WithName("patch_pss_group").Build()
+
+	paths := struct {
+		Topic string `map:"topic" validate:"required"`
+	}{}
+	if response := s.mapStructure(mux.Vars(r), &paths); response != nil {
+		response("invalid path params", logger, w)
+		return
+	}
+	headers := struct {
+		BatchID []byte `map:"Swarm-Postage-Batch-Id" validate:"required"`
+	}{}
+	if response := s.mapStructure(r.Header, &headers); response != nil {
+		response("invalid header params", logger, w)
+		return
+	}
+
+	var req pssGroupPatchRequest
+	if !readJSONBody(w, r, &req) {
+		return
+	}
+	add, err := parseGroupMembers(req.Add)
+	if err != nil {
+		logger.Debug("parse group members failed", "error", err)
+		jsonhttp.BadRequest(w, "invalid add list")
+		return
+	}
+	remove := make([]*ecdsa.PublicKey, 0, len(req.Remove))
+	for _, v := range req.Remove {
+		pub, err := pss.ParseRecipient(v)
+		if err != nil {
+			logger.Debug("parse group members failed", "error", err)
+			jsonhttp.BadRequest(w, "invalid remove list")
+			return
+		}
+		remove = append(remove, pub)
+	}
+
+	stamper, save, ok := s.pssGroupStamper(w, headers.BatchID)
+	if !ok {
+		return
+	}
+	g, err := s.pss.UpdateGroup(r.Context(), pss.NewTopic(paths.Topic), stamper, add, remove)
+	if err != nil {
+		logger.Debug("update group failed", "topic", paths.Topic, "error", err)
+		logger.Error(nil, "update group failed")
+		s.pssGroupErrorResponse(w, "update group failed", err)
+		return
+	}
+	if err := save(); err != nil {
+		logger.Debug("save stamp failed", "error", err)
+		logger.Error(nil, "save stamp failed")
+		jsonhttp.InternalServerError(w, "update group failed")
+		return
+	}
+
+	jsonhttp.OK(w, newPssGroupResponse(g))
+}
+
+func (s *Service) pssGroupGetHandler(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.WithName("get_pss_group").Build()
+
+	paths := struct {
+		Topic string `map:"topic" validate:"required"`
+	}{}
+	if response := s.mapStructure(mux.Vars(r), &paths); response != nil {
+		response("invalid path params", logger, w)
+		return
+	}
+
+	g, err := s.pss.Group(pss.NewTopic(paths.Topic))
+	if err != nil {
+		logger.Debug("get group failed", "topic", paths.Topic, "error", err)
+		s.pssGroupErrorResponse(w, "get group failed", err)
+		return
+	}
+	jsonhttp.OK(w, newPssGroupResponse(g))
+}
+
+func (s *Service) pssGroupDeleteHandler(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.WithName("delete_pss_group").Build()
+
+	paths := struct {
+		Topic string `map:"topic" validate:"required"`
+	}{}
+	if response := s.mapStructure(mux.Vars(r), &paths); response != nil {
+		response("invalid path params", logger, w)
+		return
+	}
+
+	if err := s.pss.LeaveGroup(pss.NewTopic(paths.Topic)); err != nil {
+		logger.Debug("leave group failed", "topic", paths.Topic, "error", err)
+		s.pssGroupErrorResponse(w, "leave group failed", err)
+		return
+	}
+	jsonhttp.NoContent(w)
+}
+
+func (s *Service) pssGroupJoinHandler(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.WithName("post_pss_group_join").Build()
+
+	paths := struct {
+		Topic string           `map:"topic" validate:"required"`
+		Owner *ecdsa.PublicKey `map:"owner" validate:"required"`
+	}{}
+	if response := s.mapStructure(mux.Vars(r), &paths); response != nil {
+		response("invalid path params", logger, w)
+		return
+	}
+
+	if err := s.pss.JoinGroup(pss.NewTopic(paths.Topic), paths.Owner); err != nil {
+		logger.Debug("join group failed", "topic", paths.Topic, "error", err)
+		s.pssGroupErrorResponse(w, "join group failed", err)
+		return
+	}
+	jsonhttp.Created(w, nil)
+}
+
+func (s *Service) pssGroupSendHandler(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.WithName("post_pss_group_send").Build()
+
+	paths := struct {
+		Topic string `map:"topic" validate:"required"`
+	}{}
+	if response := s.mapStructure(mux.Vars(r), &paths); response != nil {
+		response("invalid path params", logger, w)
+		return
+	}
+	headers := struct {
+		BatchID []byte `map:"Swarm-Postage-Batch-Id" validate:"required"`
+	}{}
+	if response := s.mapStructure(r.Header, &headers); response != nil {
+		response("invalid header params", logger, w)
+		return
+	}
+
+	payload, err := io.ReadAll(r.Body)
+	if err != nil {
+		logger.Debug("read body failed", "error", err)
+		logger.Error(nil, "read body failed")
+		jsonhttp.InternalServerError(w, "pss send failed")
+		return
+	}
+
+	stamper, save, ok := s.pssGroupStamper(w, headers.BatchID)
+	if !ok {
+		return
+	}
+	if err := s.pss.SendGroup(r.Context(), pss.NewTopic(paths.Topic), payload, stamper); err != nil {
+		logger.Debug("send group payload failed", "topic", paths.Topic, "error", err)
+		logger.Error(nil, "send group payload failed")
+		s.pssGroupErrorResponse(w, "pss send failed", err)
+		return
+	}
+	if err := save(); err != nil {
+		logger.Debug("save stamp failed", "error", err)
+		logger.Error(nil, "save stamp failed")
+		jsonhttp.InternalServerError(w, "pss send failed")
+		return
+	}
+
+	jsonhttp.Created(w, nil)
+}
@@ -0,0 +1,173 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"math/big"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/ethersphere/bee/v2/pkg/api"
+	"github.com/ethersphere/bee/v2/pkg/crypto"
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp"
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp/jsonhttptest"
+	"github.com/ethersphere/bee/v2/pkg/log"
+	"github.com/ethersphere/bee/v2/pkg/postage"
+	mockpost "github.com/ethersphere/bee/v2/pkg/postage/mock"
+	"github.com/ethersphere/bee/v2/pkg/pss"
+	"github.com/ethersphere/bee/v2/pkg/pushsync"
+	pushsyncmock "github.com/ethersphere/bee/v2/pkg/pushsync/mock"
+	mockstorer "github.com/ethersphere/bee/v2/pkg/storer/mock"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+	"github.com/ethersphere/bee/v2/pkg/util/testutil"
+)
+
+// nolint:paralleltest
+func TestPssGroup(t *testing.T) {
+	ownerKey, err := crypto.GenerateSecp256k1Key()
+	if err != nil {
+		t.Fatal(err)
+	}
+	memberKey, err := crypto.GenerateSecp256k1Key()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var (
+		owner     = hex.EncodeToString(crypto.EncodeSecp256k1PublicKey(&ownerKey.PublicKey))
+		member    = hex.EncodeToString(crypto.EncodeSecp256k1PublicKey(&memberKey.PublicKey))
+		pushed    = make(chan swarm.Chunk, 16)
+		mp        = mockpost.New(mockpost.WithIssuer(postage.NewStampIssuer("", "", batchOk, big.NewInt(3), 11, 10, 1000, true)))
+		p         = pss.New(ownerKey, log.Noop)
+		groupPath = "/pss/group/testtopic"
+	)
+	testutil.CleanupCloser(t, p)
+	p.SetPushSyncer(pushsyncmock.New(func(_ context.Context, ch swarm.Chunk) (*pushsync.Receipt, error) {
+		pushed <- ch
+		return nil, nil
+	}))
+
+	client, _, _, _ := newTestServer(t, testServerOptions{
+		Pss:    p,
+		Storer: mockstorer.New(),
+		Post:   mp,
+	})
+
+	t.Run("create", func(t *testing.T) {
+		jsonhttptest.Request(t, client, http.MethodPost, groupPath, http.StatusCreated,
+			jsonhttptest.WithRequestHeader(api.SwarmPostageBatchIdHeader, batchOkStr),
+			jsonhttptest.WithJSONRequestBody(api.PssGroupRequest{
+				Members: []api.PssGroupMember{{PublicKey: member, Targets: "01,02"}},
+			}),
+			jsonhttptest.WithExpectedJSONResponse(api.PssGroupResponse{
+				Owner:   owner,
+				Epoch:   0,
+				Members: []api.PssGroupMember{{PublicKey: member, Targets: "01,02"}},
+			}),
+		)
+
+		// the key message is sent to the member
+		ch := <-pushed
+		_, msg, err := pss.Unwrap(context.Background(), memberKey, ch, []pss.Topic{pss.NewTopic("pss-group-key")})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if msg == nil {
+			t.Fatal("member could not open the key message")
+		}
+	})
+
+	t.Run("create - exists", func(t *testing.T) {
+		jsonhttptest.Request(t, client, http.MethodPost, groupPath, http.StatusConflict,
+			jsonhttptest.WithRequestHeader(api.SwarmPostageBatchIdHeader, batchOkStr),
+			jsonhttptest.WithJSONRequestBody(api.PssGroupRequest{}),
+			jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
+				Code:    http.StatusConflict,
+				Message: "group already exists",
+			}),
+		)
+	})
+
+	t.Run("create - invalid members", func(t *testing.T) {
+		jsonhttptest.Request(t, client, http.MethodPost, "/pss/group/other", http.StatusBadRequest,
+			jsonhttptest.WithRequestHeader(api.SwarmPostageBatchIdHeader, batchOkStr),
+			jsonhttptest.WithJSONRequestBody(api.PssGroupRequest{
+				Members: []api.PssGroupMember{{PublicKey: member, Targets: "01020304"}},
+			}),
+			jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
+				Code:    http.StatusBadRequest,
+				Message: "invalid members",
+			}),
+		)
+	})
+
+	t.Run("send", func(t *testing.T) {
+		jsonhttptest.Request(t, client, http.MethodPost, groupPath+"/send", http.StatusCreated,
+			jsonhttptest.WithRequestHeader(api.SwarmPostageBatchIdHeader, batchOkStr),
+			jsonhttptest.WithRequestBody(bytes.NewReader(payload)),
+		)
+		ch := <-pushed
+		if !bytes.HasPrefix(ch.Address().Bytes(), []byte{1}) && !bytes.HasPrefix(ch.Address().Bytes(), []byte{2}) {
+			t.Fatalf("message %s not sent to the member targets", ch.Address())
+		}
+	})
+
+	t.Run("remove member", func(t *testing.T) {
+		jsonhttptest.Request(t, client, http.MethodPatch, groupPath, http.StatusOK,
+			jsonhttptest.WithRequestHeader(api.SwarmPostageBatchIdHeader, batchOkStr),
+			jsonhttptest.WithJSONRequestBody(api.PssGroupPatchRequest{Remove: []string{member}}),
+			jsonhttptest.WithExpectedJSONResponse(api.PssGroupResponse{
+				Owner:   owner,
+				Epoch:   1,
+				Members: []api.PssGroupMember{},
+			}),
+		)
+		jsonhttptest.Request(t, client, http.MethodGet, groupPath, http.StatusOK,
+			jsonhttptest.WithExpectedJSONResponse(api.PssGroupResponse{
+				Owner:   owner,
+				Epoch:   1,
+				Members: []api.PssGroupMember{},
+			}),
+		)
+	})
+
+	t.Run("leave", func(t *testing.T) {
+		jsonhttptest.Request(t, client, http.MethodDelete, groupPath, http.StatusNoContent)
+		jsonhttptest.Request(t, client, http.MethodGet, groupPath, http.StatusNotFound,
+			jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
+				Code:    http.StatusNotFound,
+				Message: "group not found",
+			}),
+		)
+	})
+
+	t.Run("join", func(t *testing.T) {
+		jsonhttptest.Request(t, client, http.MethodPost, groupPath+"/join/"+member, http.StatusCreated)
+		jsonhttptest.Request(t, client, http.MethodPatch, groupPath, http.StatusForbidden,
+			jsonhttptest.WithRequestHeader(api.SwarmPostageBatchIdHeader, batchOkStr),
+			jsonhttptest.WithJSONRequestBody(api.PssGroupPatchRequest{}),
+			jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
+				Code:    http.StatusForbidden,
+				Message: "not the owner of the group",
+			}),
+		)
+		jsonhttptest.Request(t, client, http.MethodPost, groupPath+"/send", http.StatusConflict,
+			jsonhttptest.WithRequestHeader(api.SwarmPostageBatchIdHeader, batchOkStr),
+			jsonhttptest.WithRequestBody(bytes.NewReader(payload)),
+			jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
+				Code:    http.StatusConflict,
+				Message: "group key not received yet",
+			}),
+		)
+	})
+
+	t.Run("join - invalid owner", func(t *testing.T) {
+		jsonhttptest.Request(t, client, http.MethodPost, "/pss/group/other/join/"+strings.Repeat("0", 66), http.StatusBadRequest)
+	})
+}
@@ -0,0 +1,123 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp"
+	"github.com/ethersphere/bee/v2/pkg/pss"
+	"github.com/gorilla/mux"
+)
+
+type pssInboxMessage struct {
+	ID       string    `json:"id"`
+	Received time.Time `json:"received"`
+	Payload  []byte    `json:"payload"`
+}
+
+type pssInboxResponse struct {
+	Messages []pssInboxMessage `json:"messages"`
+}
+
+// pssInboxErrorResponse writes the response for the errors of the inbox operations.
+func (s *Service) pssInboxErrorResponse(w http.ResponseWriter, msg string, err error) {
+	switch {
+	case errors.Is(err, pss.ErrInboxDisabled):
+		jsonhttp.NotImplemented(w, "inboxes disabled")
+	case errors.Is(err, pss.ErrInboxNotFound):
+		jsonhttp.NotFound(w, "inbox not found")
+	case errors.Is(err, pss.ErrInboxMessageNotFound):
+		jsonhttp.NotFound(w, "message not found")
+	default:
+		jsonhttp.InternalServerError(w, msg)
+	}
+}
+
+func (s *Service) pssInboxCreateHandler(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.WithName("post_pss_inbox").Build()
+
+	paths := struct {
+		Topic string `map:"topic" validate:"required"`
+	}{}
+	if response := s.mapStructure(mux.Vars(r), &paths); response != nil {
+		response("invalid path params", logger, w)
+		return
+	}
+
+	if err := s.pss.EnableInbox(pss.NewTopic(paths.Topic)); err != nil {
+		logger.Debug("enable inbox failed", "topic", paths.Topic, "error", err)
+		logger.Error(nil, "enable inbox failed")
+		s.pssInboxErrorResponse(w, "enable inbox failed", err)
+		return
+	}
+	jsonhttp.Created(w, nil)
+}
+
+func (s *Service) pssInboxGetHandler(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.WithName("get_pss_inbox").Build()
+
+	paths := struct {
+		Topic string `map:"topic" validate:"required"`
+	}{}
+	if response := s.mapStructure(mux.Vars(r), &paths); response != nil {
+		response("invalid path params", logger, w)
+		return
+	}
+
+	msgs, err := s.pss.InboxMessages(pss.NewTopic(paths.Topic))
+	if err != nil {
+		logger.Debug("get inbox messages failed", "topic", paths.Topic, "error", err)
+		s.pssInboxErrorResponse(w, "get inbox messages failed", err)
+		return
+	}
+
+	res := pssInboxResponse{Messages: make([]pssInboxMessage, 0, len(msgs))}
+	for _, m := range msgs {
+		res.Messages = append(res.Messages, pssInboxMessage(m))
+	}
+	jsonhttp.OK(w, res)
+}
+
+func (s *Service) pssInboxDeleteHandler(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.WithName("delete_pss_inbox").Build()
+
+	paths := struct {
+		Topic string `map:"topic" validate:"required"`
+	}{}
+	if response := s.mapStructure(mux.Vars(r), &paths); response != nil {
+		response("invalid path params", logger, w)
+		return
+	}
+
+	if err := s.pss.DisableInbox(pss.NewTopic(paths.Topic)); err != nil {
+		logger.Debug("disable inbox failed", "topic", paths.Topic, "error", err)
+		s.pssInboxErrorResponse(w, "disable inbox failed", err)
+		return
+	}
+	jsonhttp.NoContent(w)
+}
+
+func (s *Service) pssInboxAckHandler(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.WithName("delete_pss_inbox_message").Build()
+
+	paths := struct {
+		Topic string `map:"topic" validate:"required"`
+		ID    string `map:"id" validate:"required,hexadecimal"`
+	}{}
+	if response := s.mapStructure(mux.Vars(r), &paths); response != nil {
+		response("invalid path params", logger, w)
+		return
+	}
+
+	if err := s.pss.AckInboxMessage(pss.NewTopic(paths.Topic), paths.ID); err != nil {
+		logger.Debug("ack inbox message failed", "topic", paths.Topic, "id", paths.ID, "error", err)
+		s.pssInboxErrorResponse(w, "ack inbox message failed", err)
+		return
+	}
+	jsonhttp.NoContent(w)
+}
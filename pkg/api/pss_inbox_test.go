@@ -0,0 +1,120 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package api_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/ethersphere/bee/v2/pkg/api"
+	"github.com/ethersphere/bee/v2/pkg/crypto"
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp"
+	"github.com/ethersphere/bee/v2/pkg/jsonhttp/jsonhttptest"
+	"github.com/ethersphere/bee/v2/pkg/log"
+	"github.com/ethersphere/bee/v2/pkg/pss"
+	"github.com/ethersphere/bee/v2/pkg/spinlock"
+	statestore "github.com/ethersphere/bee/v2/pkg/statestore/mock"
+	mockstorer "github.com/ethersphere/bee/v2/pkg/storer/mock"
+	"github.com/ethersphere/bee/v2/pkg/util/testutil"
+)
+
+func TestPssInbox(t *testing.T) {
+	t.Parallel()
+
+	privkey, err := crypto.GenerateSecp256k1Key()
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := pss.New(privkey, log.Noop)
+	testutil.CleanupCloser(t, p)
+	if err := p.SetInboxStore(statestore.NewStateStore(), pss.DefaultInboxCapacity, pss.DefaultInboxTTL); err != nil {
+		t.Fatal(err)
+	}
+
+	receive := func(t *testing.T, topic string, payload []byte) {
+		t.Helper()
+		ch, err := pss.Wrap(context.Background(), pss.NewTopic(topic), payload, &privkey.PublicKey, targets)
+		if err != nil {
+			t.Fatal(err)
+		}
+		p.TryUnwrap(ch)
+	}
+
+	client, _, _, _ := newTestServer(t, testServerOptions{
+		Pss:    p,
+		Storer: mockstorer.New(),
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		jsonhttptest.Request(t, client, http.MethodGet, "/pss/inbox/testtopic", http.StatusNotFound,
+			jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
+				Code:    http.StatusNotFound,
+				Message: "inbox not found",
+			}),
+		)
+	})
+
+	t.Run("fetch and ack", func(t *testing.T) {
+		jsonhttptest.Request(t, client, http.MethodPost, "/pss/inbox/testtopic", http.StatusCreated)
+		receive(t, "testtopic", payload)
+
+		var res api.PssInboxResponse
+		jsonhttptest.Request(t, client, http.MethodGet, "/pss/inbox/testtopic", http.StatusOK,
+			jsonhttptest.WithUnmarshalJSONResponse(&res),
+		)
+		if len(res.Messages) != 1 || !bytes.Equal(res.Messages[0].Payload, payload) {
+			t.Fatalf("unexpected inbox %+v", res)
+		}
+
+		jsonhttptest.Request(t, client, http.MethodDelete, "/pss/inbox/testtopic/"+res.Messages[0].ID, http.StatusNoContent)
+		jsonhttptest.Request(t, client, http.MethodDelete, "/pss/inbox/testtopic/"+res.Messages[0].ID, http.StatusNotFound,
+			jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
+				Code:    http.StatusNotFound,
+				Message: "message not found",
+			}),
+		)
+		jsonhttptest.Request(t, client, http.MethodGet, "/pss/inbox/testtopic", http.StatusOK,
+			jsonhttptest.WithExpectedJSONResponse(api.PssInboxResponse{Messages: []api.PssInboxMessage{}}),
+		)
+	})
+
+	t.Run("backlog on subscribe", func(t *testing.T) {
+		receive(t, "testtopic", payload)
+
+		_, cl, _, _ := newTestServer(t, testServerOptions{
+			Pss:    p,
+			Storer: mockstorer.New(),
+			WsPath: "/pss/subscribe/testtopic",
+			Logger: log.Noop,
+		})
+		if err := cl.SetReadDeadline(time.Now().Add(longTimeout)); err != nil {
+			t.Fatal(err)
+		}
+		_, msg, err := cl.ReadMessage()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(msg, payload) {
+			t.Fatalf("got message %q, want %q", msg, payload)
+		}
+
+		// the written messages are removed from the inbox
+		err = spinlock.Wait(time.Second, func() bool {
+			msgs, err := p.InboxMessages(pss.NewTopic("testtopic"))
+			return err == nil && len(msgs) == 0
+		})
+		if err != nil {
+			t.Fatal("written message not removed from the inbox")
+		}
+	})
+
+	t.Run("disable", func(t *testing.T) {
+		jsonhttptest.Request(t, client, http.MethodDelete, "/pss/inbox/testtopic", http.StatusNoContent)
+		jsonhttptest.Request(t, client, http.MethodDelete, "/pss/inbox/testtopic", http.StatusNotFound)
+	})
+}
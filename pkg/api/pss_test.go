@@ -28,6 +28,7 @@ import (
 	mockpost "github.com/ethersphere/bee/v2/pkg/postage/mock"
 	"github.com/ethersphere/bee/v2/pkg/pss"
 	"github.com/ethersphere/bee/v2/pkg/pushsync"
+	pushsyncmock "github.com/ethersphere/bee/v2/pkg/pushsync/mock"
 	"github.com/ethersphere/bee/v2/pkg/spinlock"
 	mockstorer "github.com/ethersphere/bee/v2/pkg/storer/mock"
 	"github.com/ethersphere/bee/v2/pkg/swarm"
@@ -277,6 +278,70 @@ func TestPssSend(t *testing.T) {
 	})
 }
 
+func TestPssSendReliable(t *testing.T) {
+	t.Parallel()
+
+	privk, err := crypto.GenerateSecp256k1Key()
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := pss.New(privk, log.Noop)
+	testutil.CleanupCloser(t, p)
+	p.SetPushSyncer(pushsyncmock.New(func(context.Context, swarm.Chunk) (*pushsync.Receipt, error) {
+		return nil, nil
+	}))
+
+	var (
+		mp              = mockpost.New(mockpost.WithIssuer(postage.NewStampIssuer("", "", batchOk, big.NewInt(3), 11, 10, 1000, true)))
+		client, _, _, _ = newTestServer(t, testServerOptions{
+			Pss:    p,
+			Storer: mockstorer.New(),
+			Post:   mp,
+		})
+		res api.PssSendResponse
+	)
+
+	t.Run("ok", func(t *testing.T) {
+		jsonhttptest.Request(t, client, http.MethodPost, "/pss/send/testtopic/12?reliable=true&ackTargets=00", http.StatusCreated,
+			jsonhttptest.WithRequestHeader(api.SwarmPostageBatchIdHeader, batchOkStr),
+			jsonhttptest.WithRequestBody(bytes.NewReader(payload)),
+			jsonhttptest.WithUnmarshalJSONResponse(&res),
+		)
+
+		var msg api.PssMessageResponse
+		jsonhttptest.Request(t, client, http.MethodGet, "/pss/messages/"+res.ID, http.StatusOK,
+			jsonhttptest.WithUnmarshalJSONResponse(&msg),
+		)
+		if msg.ID != res.ID || msg.State != string(pss.DeliveryPending) || msg.Attempts != 1 || msg.AckedAt != nil {
+			t.Fatalf("unexpected message status %+v", msg)
+		}
+	})
+
+	t.Run("invalid ack targets", func(t *testing.T) {
+		jsonhttptest.Request(t, client, http.MethodPost, "/pss/send/testtopic/12?reliable=true&ackTargets=01020304", http.StatusBadRequest,
+			jsonhttptest.WithRequestHeader(api.SwarmPostageBatchIdHeader, batchOkStr),
+			jsonhttptest.WithRequestBody(bytes.NewReader(payload)),
+			jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
+				Code:    http.StatusBadRequest,
+				Message: "invalid ack targets",
+			}),
+		)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		jsonhttptest.Request(t, client, http.MethodGet, "/pss/messages/"+strings.Repeat("ab", 32), http.StatusNotFound,
+			jsonhttptest.WithExpectedJSONResponse(jsonhttp.StatusResponse{
+				Code:    http.StatusNotFound,
+				Message: "message not found",
+			}),
+		)
+	})
+
+	t.Run("invalid id", func(t *testing.T) {
+		jsonhttptest.Request(t, client, http.MethodGet, "/pss/messages/abcd", http.StatusBadRequest)
+	})
+}
+
 // TestPssPingPong tests that the websocket api adheres to the websocket standard
 // and sends ping-pong messages to keep the connection alive.
 // The test opens a websocket, keeps it alive for 500ms, then receives a pss message.
@@ -448,11 +513,14 @@ func TestPssPostHandlerInvalidInputs(t *testing.T) {
 
 type pssSendFn func(context.Context, pss.Targets, swarm.Chunk) error
 type mpss struct {
+	pss.Groups
+	pss.Reliable
+	pss.Inbox
 	f pssSendFn
 }
 
 func newMockPss(f pssSendFn) *mpss {
-	return &mpss{f}
+	return &mpss{f: f}
 }
 
 // Send arbitrary byte slice with the given topic to Targets.
@@ -11,6 +11,7 @@ import (
 	"net/http/pprof"
 	"strings"
 
+	"github.com/ethersphere/bee/v2/pkg/auth"
 	"github.com/ethersphere/bee/v2/pkg/jsonhttp"
 	"github.com/ethersphere/bee/v2/pkg/log/httpaccess"
 	"github.com/ethersphere/bee/v2/pkg/swarm"
@@ -35,6 +36,7 @@ func (s *Service) Mount() {
 	router.NotFoundHandler = http.HandlerFunc(jsonhttp.NotFoundHandler)
 
 	s.router = router
+	s.router.Use(s.authHandler)
 
 	s.mountTechnicalDebug()
 	s.mountBusinessDebug()
@@ -165,6 +167,15 @@ func (s *Service) mountTechnicalDebug() {
 		),
 	})
 
+	s.router.Handle("/auth", jsonhttp.MethodHandler{
+		"GET":  http.HandlerFunc(s.authTokensHandler),
+		"POST": http.HandlerFunc(s.authTokenPostHandler),
+	})
+
+	s.router.Handle("/auth/{id}", jsonhttp.MethodHandler{
+		"DELETE": http.HandlerFunc(s.authTokenDeleteHandler),
+	})
+
 	s.router.Handle("/readiness", web.ChainHandlers(
 		httpaccess.NewHTTPAccessSuppressLogHandler(),
 		web.FinalHandlerFunc(s.readinessHandler),
@@ -176,6 +187,139 @@ func (s *Service) mountTechnicalDebug() {
 	))
 }
 
+// routeScopes maps the path templates of the routes to the scopes that are
+// required for calling them when the authentication is enabled. The scope
+// can be set for all methods of the route or, prefixed with the method, only
+// for a single one. The routes with an empty scope are public and the routes
+// that are not listed require the admin scope.
+var routeScopes = map[string]auth.Scope{
+	"/":                                 "",
+	"/robots.txt":                       "",
+	"/health":                           "",
+	"/readiness":                        "",
+	"POST /auth":                        "",                 // the handler authenticates by itself
+	"/{path:.*}":                        auth.ScopeDownload, // subdomain
+	"/bytes":                            auth.ScopeUpload,
+	"/bytes/{address}":                  auth.ScopeDownload,
+	"/chunks":                           auth.ScopeUpload,
+	"/chunks/stream":                    auth.ScopeUpload,
+	"/chunks/{address}":                 auth.ScopeDownload,
+	"/envelope/{address}":               auth.ScopeUpload,
+	"GET /soc/{owner}/{id}":             auth.ScopeDownload,
+	"POST /soc/{owner}/{id}":            auth.ScopeUpload,
+	"GET /feeds/{owner}/{topic}":        auth.ScopeDownload,
+	"POST /feeds/{owner}/{topic}":       auth.ScopeUpload,
+	"/feeds/{owner}/{topic}/history":    auth.ScopeDownload,
+	"/feeds/{owner}/{topic}/subscribe":  auth.ScopeDownload,
+	"/bzz":                              auth.ScopeUpload,
+	"/bzz/{address}":                    auth.ScopeDownload,
+	"/bzz/{address}/{path:.*}":          auth.ScopeDownload,
+	"/grantee":                          auth.ScopeUpload,
+	"GET /grantee/{address}":            auth.ScopeDownload,
+	"PATCH /grantee/{address}":          auth.ScopeUpload,
+	"/grantee/{grantee}/access":         auth.ScopeUpload,
+	"GET /manifest/{address}":           auth.ScopeDownload,
+	"PATCH /manifest/{address}":         auth.ScopeUpload,
+	"/manifest/{address}/diff/{other}":  auth.ScopeDownload,
+	"/pss/send/{topic}/{targets}":       auth.ScopeUpload,
+	"/pss/subscribe/{topic}":            auth.ScopeDownload,
+	"/pss/messages/{id}":                auth.ScopeUpload,
+	"/pss/inbox/{topic}":                auth.ScopeDownload,
+	"/pss/inbox/{topic}/{id}":           auth.ScopeDownload,
+	"GET /pss/group/{topic}":            auth.ScopeDownload,
+	"POST /pss/group/{topic}":           auth.ScopeUpload,
+	"PATCH /pss/group/{topic}":          auth.ScopeUpload,
+	"DELETE /pss/group/{topic}":         auth.ScopeUpload,
+	"/pss/group/{topic}/join/{owner}":   auth.ScopeUpload,
+	"/pss/group/{topic}/send":           auth.ScopeUpload,
+	"/gsoc/subscribe/{address}":         auth.ScopeDownload,
+	"/gsoc/poll/{address}":              auth.ScopeDownload,
+	"/gsoc/mine/{owner}/{targets}":      auth.ScopeUpload,
+	"/gsoc/{owner}/{id}":                auth.ScopeUpload,
+	"/tags":                             auth.ScopeUpload,
+	"/tags/{id}":                        auth.ScopeUpload,
+	"/pins":                             auth.ScopeUpload,
+	"/pins/check":                       auth.ScopeUpload,
+	"/pins/jobs":                        auth.ScopeUpload,
+	"/pins/jobs/{reference}":            auth.ScopeUpload,
+	"/pins/{reference}":                 auth.ScopeUpload,
+	"GET /stewardship/{address}":        auth.ScopeDownload,
+	"PUT /stewardship/{address}":        auth.ScopeUpload,
+	"/stamps":                           auth.ScopeStamps,
+	"/stamps/{batch_id}":                auth.ScopeStamps,
+	"/stamps/{batch_id}/buckets":        auth.ScopeStamps,
+	"/stamps/{amount}/{depth}":          auth.ScopeStamps,
+	"/stamps/topup/{batch_id}/{amount}": auth.ScopeStamps,
+	"/stamps/dilute/{batch_id}/{depth}": auth.ScopeStamps,
+	"/batches":                          auth.ScopeStamps,
+	"/wallet":                           auth.ScopeWallet,
+	"/wallet/withdraw/{coin}":           auth.ScopeWallet,
+	"/chequebook/cheque/{peer}":         auth.ScopeWallet,
+	"/chequebook/cheque":                auth.ScopeWallet,
+	"/chequebook/cashout/{peer}":        auth.ScopeWallet,
+	"/chequebook/balance":               auth.ScopeWallet,
+	"/chequebook/address":               auth.ScopeWallet,
+	"/chequebook/deposit":               auth.ScopeWallet,
+	"/chequebook/withdraw":              auth.ScopeWallet,
+	"/balances":                         auth.ScopeWallet,
+	"/balances/{peer}":                  auth.ScopeWallet,
+	"/consumed":                         auth.ScopeWallet,
+	"/consumed/{peer}":                  auth.ScopeWallet,
+	"/timesettlements":                  auth.ScopeWallet,
+	"/settlements":                      auth.ScopeWallet,
+	"/settlements/{peer}":               auth.ScopeWallet,
+	"/accounting":                       auth.ScopeWallet,
+	"/stake":                            auth.ScopeWallet,
+	"/stake/{amount}":                   auth.ScopeWallet,
+	"/stake/withdrawable":               auth.ScopeWallet,
+	"/transactions":                     auth.ScopeWallet,
+	"/transactions/{hash}":              auth.ScopeWallet,
+}
+
+// authHandler checks that the bearer token of the request grants the
+// scope of the matched route. It is a no-op if the authentication is not
+// enabled.
+func (s *Service) authHandler(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if s.auth == nil || r.Method == http.MethodOptions {
+			h.ServeHTTP(w, r)
+			return
+		}
+
+		route := mux.CurrentRoute(r)
+		if route == nil {
+			h.ServeHTTP(w, r)
+			return
+		}
+		tpl, err := route.GetPathTemplate()
+		if err != nil {
+			h.ServeHTTP(w, r)
+			return
+		}
+		tpl = strings.TrimPrefix(tpl, rootPath)
+		if tpl == "" {
+			tpl = "/"
+		}
+
+		scope, ok := routeScopes[r.Method+" "+tpl]
+		if !ok {
+			if scope, ok = routeScopes[tpl]; !ok {
+				scope = auth.ScopeAdmin
+			}
+		}
+		if scope == "" {
+			h.ServeHTTP(w, r)
+			return
+		}
+
+		if _, err := s.authorize(r, scope); err != nil {
+			s.authErrorResponse(w, err)
+			return
+		}
+		h.ServeHTTP(w, r)
+	})
+}
+
 func (s *Service) checkRouteAvailability(handler http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if !s.fullAPIEnabled {
@@ -295,6 +439,12 @@ func (s *Service) mountAPI() {
 		),
 	})
 
+	handle("/feeds/{owner}/{topic}/history", jsonhttp.MethodHandler{
+		"GET": http.HandlerFunc(s.feedHistoryHandler),
+	})
+
+	handle("/feeds/{owner}/{topic}/subscribe", http.HandlerFunc(s.feedSubscribeHandler))
+
 	handle("/bzz", jsonhttp.MethodHandler{
 		"POST": web.ChainHandlers(
 			s.contentLengthMetricMiddleware(),
@@ -312,6 +462,10 @@ func (s *Service) mountAPI() {
 		"PATCH": http.HandlerFunc(s.actGrantRevokeHandler),
 	})
 
+	handle("/grantee/{grantee}/access", jsonhttp.MethodHandler{
+		"GET": http.HandlerFunc(s.actAuditGranteeHandler),
+	})
+
 	handle("/bzz/{address}", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		u := r.URL
 		u.Path += "/"
@@ -331,6 +485,26 @@ func (s *Service) mountAPI() {
 		),
 	})
 
+	handle("/manifest/{address}", jsonhttp.MethodHandler{
+		"GET": web.ChainHandlers(
+			s.newTracingHandler("manifest-list"),
+			s.actDecryptionHandler(),
+			web.FinalHandlerFunc(s.manifestListHandler),
+		),
+		"PATCH": web.ChainHandlers(
+			s.newTracingHandler("manifest-patch"),
+			jsonhttp.NewMaxBodyBytesHandler(manifestPatchMaxRequestSize),
+			web.FinalHandlerFunc(s.manifestPatchHandler),
+		),
+	})
+
+	handle("/manifest/{address}/diff/{other}", jsonhttp.MethodHandler{
+		"GET": web.ChainHandlers(
+			s.newTracingHandler("manifest-diff"),
+			web.FinalHandlerFunc(s.manifestDiffHandler),
+		),
+	})
+
 	handle("/pss/send/{topic}/{targets}", jsonhttp.MethodHandler{
 		"POST": web.ChainHandlers(
 			jsonhttp.NewMaxBodyBytesHandler(swarm.ChunkSize),
@@ -338,10 +512,63 @@ func (s *Service) mountAPI() {
 		),
 	})
 
+	handle("/pss/messages/{id}", jsonhttp.MethodHandler{
+		"GET": http.HandlerFunc(s.pssMessageHandler),
+	})
+
+	handle("/pss/inbox/{topic}", jsonhttp.MethodHandler{
+		"GET":    http.HandlerFunc(s.pssInboxGetHandler),
+		"POST":   http.HandlerFunc(s.pssInboxCreateHandler),
+		"DELETE": http.HandlerFunc(s.pssInboxDeleteHandler),
+	})
+
+	handle("/pss/inbox/{topic}/{id}", jsonhttp.MethodHandler{
+		"DELETE": http.HandlerFunc(s.pssInboxAckHandler),
+	})
+
+	handle("/pss/group/{topic}", jsonhttp.MethodHandler{
+		"GET": http.HandlerFunc(s.pssGroupGetHandler),
+		"POST": web.ChainHandlers(
+			jsonhttp.NewMaxBodyBytesHandler(swarm.ChunkSize),
+			web.FinalHandlerFunc(s.pssGroupCreateHandler),
+		),
+		"PATCH": web.ChainHandlers(
+			jsonhttp.NewMaxBodyBytesHandler(swarm.ChunkSize),
+			web.FinalHandlerFunc(s.pssGroupPatchHandler),
+		),
+		"DELETE": http.HandlerFunc(s.pssGroupDeleteHandler),
+	})
+
+	handle("/pss/group/{topic}/join/{owner}", jsonhttp.MethodHandler{
+		"POST": http.HandlerFunc(s.pssGroupJoinHandler),
+	})
+
+	handle("/pss/group/{topic}/send", jsonhttp.MethodHandler{
+		"POST": web.ChainHandlers(
+			jsonhttp.NewMaxBodyBytesHandler(swarm.ChunkSize),
+			web.FinalHandlerFunc(s.pssGroupSendHandler),
+		),
+	})
+
 	handle("/gsoc/subscribe/{address}", web.ChainHandlers(
 		web.FinalHandlerFunc(s.gsocWsHandler),
 	))
 
+	handle("/gsoc/poll/{address}", jsonhttp.MethodHandler{
+		"GET": http.HandlerFunc(s.gsocPollHandler),
+	})
+
+	handle("/gsoc/mine/{owner}/{targets}", jsonhttp.MethodHandler{
+		"GET": http.HandlerFunc(s.gsocMineHandler),
+	})
+
+	handle("/gsoc/{owner}/{id}", jsonhttp.MethodHandler{
+		"POST": web.ChainHandlers(
+			jsonhttp.NewMaxBodyBytesHandler(swarm.ChunkSize),
+			web.FinalHandlerFunc(s.gsocPostHandler),
+		),
+	})
+
 	handle("/tags", web.ChainHandlers(
 		web.FinalHandler(jsonhttp.MethodHandler{
 			"GET": http.HandlerFunc(s.listTagsHandler),
@@ -375,6 +602,20 @@ func (s *Service) mountAPI() {
 		}),
 	))
 
+	handle("/pins/jobs", web.ChainHandlers(
+		web.FinalHandler(jsonhttp.MethodHandler{
+			"GET": http.HandlerFunc(s.listPinJobsHandler),
+		}),
+	))
+
+	handle("/pins/jobs/{reference}", web.ChainHandlers(
+		web.FinalHandler(jsonhttp.MethodHandler{
+			"GET":    http.HandlerFunc(s.getPinJobHandler),
+			"POST":   http.HandlerFunc(s.startPinJobHandler),
+			"DELETE": http.HandlerFunc(s.cancelPinJobHandler),
+		}),
+	))
+
 	handle("/pins/{reference}", web.ChainHandlers(
 		web.FinalHandler(jsonhttp.MethodHandler{
 			"GET":    http.HandlerFunc(s.getPinnedRootHash),
@@ -419,6 +660,16 @@ func (s *Service) mountAPI() {
 		"GET": http.HandlerFunc(s.pinIntegrityHandler),
 	})
 
+	handle("/pins/jobs", jsonhttp.MethodHandler{
+		"GET": http.HandlerFunc(s.listPinJobsHandler),
+	})
+
+	handle("/pins/jobs/{reference}", jsonhttp.MethodHandler{
+		"GET":    http.HandlerFunc(s.getPinJobHandler),
+		"POST":   http.HandlerFunc(s.startPinJobHandler),
+		"DELETE": http.HandlerFunc(s.cancelPinJobHandler),
+	})
+
 	handle("/pins/{reference}", jsonhttp.MethodHandler{
 		"GET":    http.HandlerFunc(s.getPinnedRootHash),
 		"POST":   http.HandlerFunc(s.pinRootHash),
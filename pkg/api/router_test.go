@@ -44,6 +44,8 @@ func TestEndpointOptions(t *testing.T) {
 				{"/loggers", []string{"GET"}, http.StatusNoContent},
 				{"/loggers/some-exp", []string{"GET"}, http.StatusNoContent},
 				{"/loggers/some-exp/1", []string{"PUT"}, http.StatusNoContent},
+				{"/auth", []string{"GET", "POST"}, http.StatusNoContent},
+				{"/auth/{id}", []string{"DELETE"}, http.StatusNoContent},
 				{"/readiness", nil, http.StatusBadRequest},
 				{"/health", nil, http.StatusOK},
 				{"/metrics", nil, http.StatusOK},
@@ -60,17 +62,33 @@ func TestEndpointOptions(t *testing.T) {
 				{"/envelope/{address}", []string{"POST"}, http.StatusNoContent},
 				{"/soc/{owner}/{id}", []string{"GET", "POST"}, http.StatusNoContent},
 				{"/feeds/{owner}/{topic}", []string{"GET", "POST"}, http.StatusNoContent},
+				{"/feeds/{owner}/{topic}/history", []string{"GET"}, http.StatusNoContent},
+				{"/feeds/{owner}/{topic}/subscribe", nil, http.StatusBadRequest},
 				{"/bzz", []string{"POST"}, http.StatusNoContent},
 				{"/grantee", []string{"POST"}, http.StatusNoContent},
 				{"/grantee/{address}", []string{"GET", "PATCH"}, http.StatusNoContent},
+				{"/grantee/{grantee}/access", []string{"GET"}, http.StatusNoContent},
 				{"/bzz/{address}", []string{"GET"}, http.StatusNoContent},
 				{"/bzz/{address}/{path:.*}", []string{"GET", "HEAD"}, http.StatusNoContent},
+				{"/manifest/{address}", []string{"GET", "PATCH"}, http.StatusNoContent},
+				{"/manifest/{address}/diff/{other}", []string{"GET"}, http.StatusNoContent},
 				{"/pss/send/{topic}/{targets}", []string{"POST"}, http.StatusNoContent},
+				{"/pss/messages/{id}", []string{"GET"}, http.StatusNoContent},
+				{"/pss/inbox/{topic}", []string{"GET", "POST", "DELETE"}, http.StatusNoContent},
+				{"/pss/inbox/{topic}/{id}", []string{"DELETE"}, http.StatusNoContent},
+				{"/pss/group/{topic}", []string{"GET", "POST", "PATCH", "DELETE"}, http.StatusNoContent},
+				{"/pss/group/{topic}/join/{owner}", []string{"POST"}, http.StatusNoContent},
+				{"/pss/group/{topic}/send", []string{"POST"}, http.StatusNoContent},
 				{"/pss/subscribe/{topic}", nil, http.StatusBadRequest},
+				{"/gsoc/poll/{address}", []string{"GET"}, http.StatusNoContent},
+				{"/gsoc/mine/{owner}/{targets}", []string{"GET"}, http.StatusNoContent},
+				{"/gsoc/{owner}/{id}", []string{"POST"}, http.StatusNoContent},
 				{"/tags", []string{"GET", "POST"}, http.StatusNoContent},
 				{"/tags/{id}", []string{"GET", "DELETE", "PATCH"}, http.StatusNoContent},
 				{"/pins", []string{"GET"}, http.StatusNoContent},
 				{"/pins/check", []string{"GET"}, http.StatusNoContent},
+				{"/pins/jobs", []string{"GET"}, http.StatusNoContent},
+				{"/pins/jobs/{reference}", []string{"GET", "POST", "DELETE"}, http.StatusNoContent},
 				{"/pins/{reference}", []string{"GET", "POST", "DELETE"}, http.StatusNoContent},
 				{"/stewardship/{address}", []string{"GET", "PUT"}, http.StatusNoContent},
 
@@ -139,6 +157,8 @@ func TestEndpointOptions(t *testing.T) {
 				{"/loggers", []string{"GET"}, http.StatusNoContent},
 				{"/loggers/some-exp", []string{"GET"}, http.StatusNoContent},
 				{"/loggers/some-exp/1", []string{"PUT"}, http.StatusNoContent},
+				{"/auth", []string{"GET", "POST"}, http.StatusNoContent},
+				{"/auth/{id}", []string{"DELETE"}, http.StatusNoContent},
 				{"/readiness", nil, http.StatusBadRequest},
 				{"/health", nil, http.StatusOK},
 				{"/metrics", nil, http.StatusOK},
@@ -155,17 +175,33 @@ func TestEndpointOptions(t *testing.T) {
 				{"/envelope/{address}", nil, http.StatusServiceUnavailable},
 				{"/soc/{owner}/{id}", nil, http.StatusServiceUnavailable},
 				{"/feeds/{owner}/{topic}", nil, http.StatusServiceUnavailable},
+				{"/feeds/{owner}/{topic}/history", nil, http.StatusServiceUnavailable},
+				{"/feeds/{owner}/{topic}/subscribe", nil, http.StatusServiceUnavailable},
 				{"/bzz", nil, http.StatusServiceUnavailable},
 				{"/grantee", nil, http.StatusServiceUnavailable},
 				{"/grantee/{address}", nil, http.StatusServiceUnavailable},
+				{"/grantee/{grantee}/access", nil, http.StatusServiceUnavailable},
 				{"/bzz/{address}", nil, http.StatusServiceUnavailable},
 				{"/bzz/{address}/{path:.*}", nil, http.StatusServiceUnavailable},
+				{"/manifest/{address}", nil, http.StatusServiceUnavailable},
+				{"/manifest/{address}/diff/{other}", nil, http.StatusServiceUnavailable},
 				{"/pss/send/{topic}/{targets}", nil, http.StatusServiceUnavailable},
+				{"/pss/messages/{id}", nil, http.StatusServiceUnavailable},
+				{"/pss/inbox/{topic}", nil, http.StatusServiceUnavailable},
+				{"/pss/inbox/{topic}/{id}", nil, http.StatusServiceUnavailable},
+				{"/pss/group/{topic}", nil, http.StatusServiceUnavailable},
+				{"/pss/group/{topic}/join/{owner}", nil, http.StatusServiceUnavailable},
+				{"/pss/group/{topic}/send", nil, http.StatusServiceUnavailable},
 				{"/pss/subscribe/{topic}", nil, http.StatusServiceUnavailable},
+				{"/gsoc/poll/{address}", nil, http.StatusServiceUnavailable},
+				{"/gsoc/mine/{owner}/{targets}", nil, http.StatusServiceUnavailable},
+				{"/gsoc/{owner}/{id}", nil, http.StatusServiceUnavailable},
 				{"/tags", nil, http.StatusServiceUnavailable},
 				{"/tags/{id}", nil, http.StatusServiceUnavailable},
 				{"/pins", nil, http.StatusServiceUnavailable},
 				{"/pins/check", nil, http.StatusServiceUnavailable},
+				{"/pins/jobs", nil, http.StatusServiceUnavailable},
+				{"/pins/jobs/{reference}", nil, http.StatusServiceUnavailable},
 				{"/pins/{reference}", nil, http.StatusServiceUnavailable},
 				{"/stewardship/{address}", nil, http.StatusServiceUnavailable},
 
@@ -234,6 +270,8 @@ func TestEndpointOptions(t *testing.T) {
 				{"/loggers", []string{"GET"}, http.StatusNoContent},
 				{"/loggers/some-exp", []string{"GET"}, http.StatusNoContent},
 				{"/loggers/some-exp/1", []string{"PUT"}, http.StatusNoContent},
+				{"/auth", []string{"GET", "POST"}, http.StatusNoContent},
+				{"/auth/{id}", []string{"DELETE"}, http.StatusNoContent},
 				{"/readiness", nil, http.StatusBadRequest},
 				{"/health", nil, http.StatusOK},
 				{"/metrics", nil, http.StatusOK},
@@ -250,17 +288,33 @@ func TestEndpointOptions(t *testing.T) {
 				{"/envelope/{address}", []string{"POST"}, http.StatusNoContent},
 				{"/soc/{owner}/{id}", []string{"GET", "POST"}, http.StatusNoContent},
 				{"/feeds/{owner}/{topic}", []string{"GET", "POST"}, http.StatusNoContent},
+				{"/feeds/{owner}/{topic}/history", []string{"GET"}, http.StatusNoContent},
+				{"/feeds/{owner}/{topic}/subscribe", nil, http.StatusBadRequest},
 				{"/bzz", []string{"POST"}, http.StatusNoContent},
 				{"/grantee", []string{"POST"}, http.StatusNoContent},
 				{"/grantee/{address}", []string{"GET", "PATCH"}, http.StatusNoContent},
+				{"/grantee/{grantee}/access", []string{"GET"}, http.StatusNoContent},
 				{"/bzz/{address}", []string{"GET"}, http.StatusNoContent},
 				{"/bzz/{address}/{path:.*}", []string{"GET", "HEAD"}, http.StatusNoContent},
+				{"/manifest/{address}", []string{"GET", "PATCH"}, http.StatusNoContent},
+				{"/manifest/{address}/diff/{other}", []string{"GET"}, http.StatusNoContent},
 				{"/pss/send/{topic}/{targets}", []string{"POST"}, http.StatusNoContent},
+				{"/pss/messages/{id}", []string{"GET"}, http.StatusNoContent},
+				{"/pss/inbox/{topic}", []string{"GET", "POST", "DELETE"}, http.StatusNoContent},
+				{"/pss/inbox/{topic}/{id}", []string{"DELETE"}, http.StatusNoContent},
+				{"/pss/group/{topic}", []string{"GET", "POST", "PATCH", "DELETE"}, http.StatusNoContent},
+				{"/pss/group/{topic}/join/{owner}", []string{"POST"}, http.StatusNoContent},
+				{"/pss/group/{topic}/send", []string{"POST"}, http.StatusNoContent},
 				{"/pss/subscribe/{topic}", nil, http.StatusBadRequest},
+				{"/gsoc/poll/{address}", []string{"GET"}, http.StatusNoContent},
+				{"/gsoc/mine/{owner}/{targets}", []string{"GET"}, http.StatusNoContent},
+				{"/gsoc/{owner}/{id}", []string{"POST"}, http.StatusNoContent},
 				{"/tags", []string{"GET", "POST"}, http.StatusNoContent},
 				{"/tags/{id}", []string{"GET", "DELETE", "PATCH"}, http.StatusNoContent},
 				{"/pins", []string{"GET"}, http.StatusNoContent},
 				{"/pins/check", []string{"GET"}, http.StatusNoContent},
+				{"/pins/jobs", []string{"GET"}, http.StatusNoContent},
+				{"/pins/jobs/{reference}", []string{"GET", "POST", "DELETE"}, http.StatusNoContent},
 				{"/pins/{reference}", []string{"GET", "POST", "DELETE"}, http.StatusNoContent},
 				{"/stewardship/{address}", []string{"GET", "PUT"}, http.StatusNoContent},
 
@@ -329,6 +383,8 @@ func TestEndpointOptions(t *testing.T) {
 				{"/loggers", []string{"GET"}, http.StatusNoContent},
 				{"/loggers/some-exp", []string{"GET"}, http.StatusNoContent},
 				{"/loggers/some-exp/1", []string{"PUT"}, http.StatusNoContent},
+				{"/auth", []string{"GET", "POST"}, http.StatusNoContent},
+				{"/auth/{id}", []string{"DELETE"}, http.StatusNoContent},
 				{"/readiness", nil, http.StatusBadRequest},
 				{"/health", nil, http.StatusOK},
 				{"/metrics", nil, http.StatusOK},
@@ -345,17 +401,33 @@ func TestEndpointOptions(t *testing.T) {
 				{"/envelope/{address}", []string{"POST"}, http.StatusNoContent},
 				{"/soc/{owner}/{id}", []string{"GET", "POST"}, http.StatusNoContent},
 				{"/feeds/{owner}/{topic}", []string{"GET", "POST"}, http.StatusNoContent},
+				{"/feeds/{owner}/{topic}/history", []string{"GET"}, http.StatusNoContent},
+				{"/feeds/{owner}/{topic}/subscribe", nil, http.StatusBadRequest},
 				{"/bzz", []string{"POST"}, http.StatusNoContent},
 				{"/grantee", []string{"POST"}, http.StatusNoContent},
 				{"/grantee/{address}", []string{"GET", "PATCH"}, http.StatusNoContent},
+				{"/grantee/{grantee}/access", []string{"GET"}, http.StatusNoContent},
 				{"/bzz/{address}", []string{"GET"}, http.StatusNoContent},
 				{"/bzz/{address}/{path:.*}", []string{"GET", "HEAD"}, http.StatusNoContent},
+				{"/manifest/{address}", []string{"GET", "PATCH"}, http.StatusNoContent},
+				{"/manifest/{address}/diff/{other}", []string{"GET"}, http.StatusNoContent},
 				{"/pss/send/{topic}/{targets}", []string{"POST"}, http.StatusNoContent},
+				{"/pss/messages/{id}", []string{"GET"}, http.StatusNoContent},
+				{"/pss/inbox/{topic}", []string{"GET", "POST", "DELETE"}, http.StatusNoContent},
+				{"/pss/inbox/{topic}/{id}", []string{"DELETE"}, http.StatusNoContent},
+				{"/pss/group/{topic}", []string{"GET", "POST", "PATCH", "DELETE"}, http.StatusNoContent},
+				{"/pss/group/{topic}/join/{owner}", []string{"POST"}, http.StatusNoContent},
+				{"/pss/group/{topic}/send", []string{"POST"}, http.StatusNoContent},
 				{"/pss/subscribe/{topic}", nil, http.StatusBadRequest},
+				{"/gsoc/poll/{address}", []string{"GET"}, http.StatusNoContent},
+				{"/gsoc/mine/{owner}/{targets}", []string{"GET"}, http.StatusNoContent},
+				{"/gsoc/{owner}/{id}", []string{"POST"}, http.StatusNoContent},
 				{"/tags", []string{"GET", "POST"}, http.StatusNoContent},
 				{"/tags/{id}", []string{"GET", "DELETE", "PATCH"}, http.StatusNoContent},
 				{"/pins", []string{"GET"}, http.StatusNoContent},
 				{"/pins/check", []string{"GET"}, http.StatusNoContent},
+				{"/pins/jobs", []string{"GET"}, http.StatusNoContent},
+				{"/pins/jobs/{reference}", []string{"GET", "POST", "DELETE"}, http.StatusNoContent},
 				{"/pins/{reference}", []string{"GET", "POST", "DELETE"}, http.StatusNoContent},
 				{"/stewardship/{address}", []string{"GET", "PUT"}, http.StatusNoContent},
 
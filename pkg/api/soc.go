@@ -6,6 +6,7 @@ package api
 
 import (
 	"bytes"
+	"context"
 	"encoding/hex"
 	"errors"
 	"io"
@@ -16,6 +17,7 @@ import (
 	"github.com/ethersphere/bee/v2/pkg/accesscontrol"
 	"github.com/ethersphere/bee/v2/pkg/cac"
 	"github.com/ethersphere/bee/v2/pkg/jsonhttp"
+	"github.com/ethersphere/bee/v2/pkg/log"
 	"github.com/ethersphere/bee/v2/pkg/postage"
 	"github.com/ethersphere/bee/v2/pkg/soc"
 	storage "github.com/ethersphere/bee/v2/pkg/storage"
@@ -66,69 +68,8 @@ func (s *Service) socUploadHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// if pinning header is set we do a deferred upload, else we do a direct upload
-	var (
-		tag uint64
-		err error
-	)
-	if headers.Pin {
-		session, err := s.storer.NewSession()
-		if err != nil {
-			logger.Debug("get or create tag failed", "error", err)
-			logger.Error(nil, "get or create tag failed")
-			switch {
-			case errors.Is(err, storage.ErrNotFound):
-				jsonhttp.NotFound(w, "tag not found")
-			default:
-				jsonhttp.InternalServerError(w, "cannot get or create tag")
-			}
-			return
-		}
-		tag = session.TagID
-	}
-
-	deferred := tag != 0
-
-	var putter storer.PutterSession
-	if len(headers.StampSig) != 0 {
-		stamp := postage.Stamp{}
-		if err := stamp.UnmarshalBinary(headers.StampSig); err != nil {
-			errorMsg := "Stamp deserialization failure"
-			logger.Debug(errorMsg, "error", err)
-			logger.Error(nil, errorMsg)
-			jsonhttp.BadRequest(w, errorMsg)
-			return
-		}
-
-		putter, err = s.newStampedPutter(r.Context(), putterOptions{
-			BatchID:  stamp.BatchID(),
-			TagID:    tag,
-			Pin:      headers.Pin,
-			Deferred: deferred,
-		}, &stamp)
-	} else {
-		putter, err = s.newStamperPutter(r.Context(), putterOptions{
-			BatchID:  headers.BatchID,
-			TagID:    tag,
-			Pin:      headers.Pin,
-			Deferred: deferred,
-		})
-	}
-	if err != nil {
-		logger.Debug("get putter failed", "error", err)
-		logger.Error(nil, "get putter failed")
-		switch {
-		case errors.Is(err, errBatchUnusable) || errors.Is(err, postage.ErrNotUsable):
-			jsonhttp.UnprocessableEntity(w, "batch not usable yet or does not exist")
-		case errors.Is(err, postage.ErrNotFound):
-			jsonhttp.NotFound(w, "batch with id not found")
-		case errors.Is(err, errInvalidPostageBatch):
-			jsonhttp.BadRequest(w, "invalid batch id")
-		case errors.Is(err, errUnsupportedDevNodeOperation):
-			jsonhttp.NotImplemented(w, "operation is not supported in dev mode")
-		default:
-			jsonhttp.BadRequest(w, nil)
-		}
+	putter, ok := s.newSOCPutter(w, r, logger, headers.BatchID, headers.StampSig, headers.Pin)
+	if !ok {
 		return
 	}
 
@@ -214,23 +155,104 @@ func (s *Service) socUploadHandler(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	err = putter.Put(r.Context(), sch)
+	if !s.putSOC(r.Context(), ow, logger, putter, sch) {
+		return
+	}
+
+	jsonhttp.Created(w, socPostResponse{Reference: reference})
+}
+
+// newSOCPutter returns the putter of a single owner chunk upload. The chunk
+// is stamped with the given stamp or else with the batch. If the putter cannot
+// be created, the error response is written and false is returned.
+func (s *Service) newSOCPutter(w http.ResponseWriter, r *http.Request, logger log.Logger, batchID, stampSig []byte, pin bool) (storer.PutterSession, bool) {
+	// if pinning header is set we do a deferred upload, else we do a direct upload
+	var tag uint64
+	if pin {
+		session, err := s.storer.NewSession()
+		if err != nil {
+			logger.Debug("get or create tag failed", "error", err)
+			logger.Error(nil, "get or create tag failed")
+			switch {
+			case errors.Is(err, storage.ErrNotFound):
+				jsonhttp.NotFound(w, "tag not found")
+			default:
+				jsonhttp.InternalServerError(w, "cannot get or create tag")
+			}
+			return nil, false
+		}
+		tag = session.TagID
+	}
+
+	deferred := tag != 0
+
+	var (
+		putter storer.PutterSession
+		err    error
+	)
+	if len(stampSig) != 0 {
+		stamp := postage.Stamp{}
+		if err := stamp.UnmarshalBinary(stampSig); err != nil {
+			errorMsg := "Stamp deserialization failure"
+			logger.Debug(errorMsg, "error", err)
+			logger.Error(nil, errorMsg)
+			jsonhttp.BadRequest(w, errorMsg)
+			return nil, false
+		}
+
+		putter, err = s.newStampedPutter(r.Context(), putterOptions{
+			BatchID:  stamp.BatchID(),
+			TagID:    tag,
+			Pin:      pin,
+			Deferred: deferred,
+		}, &stamp)
+	} else {
+		putter, err = s.newStamperPutter(r.Context(), putterOptions{
+			BatchID:  batchID,
+			TagID:    tag,
+			Pin:      pin,
+			Deferred: deferred,
+		})
+	}
 	if err != nil {
+		logger.Debug("get putter failed", "error", err)
+		logger.Error(nil, "get putter failed")
+		switch {
+		case errors.Is(err, errBatchUnusable) || errors.Is(err, postage.ErrNotUsable):
+			jsonhttp.UnprocessableEntity(w, "batch not usable yet or does not exist")
+		case errors.Is(err, postage.ErrNotFound):
+			jsonhttp.NotFound(w, "batch with id not found")
+		case errors.Is(err, errInvalidPostageBatch):
+			jsonhttp.BadRequest(w, "invalid batch id")
+		case errors.Is(err, errUnsupportedDevNodeOperation):
+			jsonhttp.NotImplemented(w, "operation is not supported in dev mode")
+		default:
+			jsonhttp.BadRequest(w, nil)
+		}
+		return nil, false
+	}
+
+	return putter, true
+}
+
+// putSOC stores the single owner chunk with the putter. If it fails, the error
+// response is written and false is returned.
+func (s *Service) putSOC(ctx context.Context, w http.ResponseWriter, logger log.Logger, putter storer.PutterSession, sch swarm.Chunk) bool {
+	if err := putter.Put(ctx, sch); err != nil {
 		logger.Debug("write chunk failed", "chunk_address", sch.Address(), "error", err)
 		logger.Error(nil, "write chunk failed")
-		jsonhttp.BadRequest(ow, "chunk write error")
-		return
+		jsonhttp.BadRequest(w, "chunk write error")
+		return false
 	}
 
-	err = putter.Done(sch.Address())
-	if err != nil {
+	if err := putter.Done(sch.Address()); err != nil {
 		logger.Debug("done split failed", "error", err)
 		logger.Error(nil, "done split failed")
-		jsonhttp.InternalServerError(ow, "done split failed")
-		return
+		jsonhttp.InternalServerError(w, "done split failed")
+		return false
 	}
 
-	jsonhttp.Created(w, socPostResponse{Reference: reference})
+	return true
 }
 
 func (s *Service) socGetHandler(w http.ResponseWriter, r *http.Request) {
@@ -0,0 +1,244 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package auth provides the bearer tokens with scoped permissions
+// for restricting the access to the node API.
+package auth
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/ethersphere/bee/v2/pkg/crypto"
+	"github.com/ethersphere/bee/v2/pkg/storage"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Scope is a permission granted by a token.
+type Scope string
+
+const (
+	// ScopeUpload permits uploading data and managing the uploads.
+	ScopeUpload Scope = "upload"
+	// ScopeDownload permits retrieving data.
+	ScopeDownload Scope = "download"
+	// ScopeStamps permits buying and managing postage stamps.
+	ScopeStamps Scope = "stamps"
+	// ScopeWallet permits operations on the wallet, chequebook, stake and settlements.
+	ScopeWallet Scope = "wallet"
+	// ScopeAdmin permits everything, including the node administration
+	// and the management of the tokens.
+	ScopeAdmin Scope = "admin"
+)
+
+// Scopes lists all known scopes.
+var Scopes = []Scope{ScopeUpload, ScopeDownload, ScopeStamps, ScopeWallet, ScopeAdmin}
+
+var (
+	// ErrInvalidToken is returned if the token is malformed or not signed by the node.
+	ErrInvalidToken = errors.New("auth: invalid token")
+	// ErrExpiredToken is returned if the token is past its expiry.
+	ErrExpiredToken = errors.New("auth: token expired")
+	// ErrRevokedToken is returned if the token was revoked.
+	ErrRevokedToken = errors.New("auth: token revoked")
+	// ErrForbidden is returned if the token does not grant the required scope.
+	ErrForbidden = errors.New("auth: scope not granted")
+	// ErrUnknownScope is returned on issuing a token with an unknown scope.
+	ErrUnknownScope = errors.New("auth: unknown scope")
+	// ErrNotFound is returned on revoking a token that does not exist.
+	ErrNotFound = errors.New("auth: token not found")
+)
+
+const keyPrefix = "auth-token-"
+
+// Token holds the claims of an issued token.
+type Token struct {
+	ID     string    `json:"id"`
+	Scopes []Scope   `json:"scopes"`
+	Expiry time.Time `json:"expiry"`
+}
+
+// Permits reports whether the token grants the scope.
+func (t *Token) Permits(scope Scope) bool {
+	return slices.Contains(t.Scopes, ScopeAdmin) || slices.Contains(t.Scopes, scope)
+}
+
+// Authenticator issues and verifies the API tokens.
+type Authenticator interface {
+	// Authenticate reports whether the password is the admin password.
+	Authenticate(password string) bool
+	// Issue creates a token with the scopes, valid for the expiry duration.
+	Issue(scopes []Scope, expiry time.Duration) (string, *Token, error)
+	// Authorize verifies the token and checks that it grants the scope.
+	Authorize(token string, scope Scope) (*Token, error)
+	// Revoke invalidates the token with the id.
+	Revoke(id string) error
+	// Tokens lists the tokens that are neither expired nor revoked.
+	Tokens() ([]*Token, error)
+}
+
+type authenticator struct {
+	signer       crypto.Signer
+	owner        []byte
+	passwordHash []byte
+	store        storage.StateStorer
+	now          func() time.Time
+}
+
+// New creates an Authenticator which signs the tokens with the signer,
+// and keeps track of the issued tokens in the store so that they can be
+// revoked. The passwordHash is the bcrypt hash of the admin password.
+func New(signer crypto.Signer, passwordHash string, store storage.StateStorer) (Authenticator, error) {
+	if _, err := bcrypt.Cost([]byte(passwordHash)); err != nil {
+		return nil, fmt.Errorf("auth: admin password hash: %w", err)
+	}
+	owner, err := signer.EthereumAddress()
+	if err != nil {
+		return nil, err
+	}
+	return &authenticator{
+		signer:       signer,
+		owner:        owner.Bytes(),
+		passwordHash: []byte(passwordHash),
+		store:        store,
+		now:          time.Now,
+	}, nil
+}
+
+func (a *authenticator) Authenticate(password string) bool {
+	return bcrypt.CompareHashAndPassword(a.passwordHash, []byte(password)) == nil
+}
+
+func (a *authenticator) Issue(scopes []Scope, expiry time.Duration) (string, *Token, error) {
+	if len(scopes) == 0 {
+		return "", nil, ErrUnknownScope
+	}
+	for _, s := range scopes {
+		if !slices.Contains(Scopes, s) {
+			return "", nil, fmt.Errorf("%w: %s", ErrUnknownScope, s)
+		}
+	}
+
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return "", nil, err
+	}
+	t := &Token{
+		ID:     hex.EncodeToString(id),
+		Scopes: scopes,
+		Expiry: a.now().Add(expiry).Truncate(time.Second).UTC(),
+	}
+
+	claims, err := json.Marshal(t)
+	if err != nil {
+		return "", nil, err
+	}
+	sig, err := a.signer.Sign(claims)
+	if err != nil {
+		return "", nil, err
+	}
+	if err := a.store.Put(keyPrefix+t.ID, t); err != nil {
+		return "", nil, err
+	}
+
+	token := base64.RawURLEncoding.EncodeToString(claims) + "." + base64.RawURLEncoding.EncodeToString(sig)
+	return token, t, nil
+}
+
+func (a *authenticator) Authorize(token string, scope Scope) (*Token, error) {
+	t, err := a.verify(token)
+	if err != nil {
+		return nil, err
+	}
+	if !t.Permits(scope) {
+		return t, ErrForbidden
+	}
+	return t, nil
+}
+
+// verify checks the signature and the validity of the token.
+func (a *authenticator) verify(token string) (*Token, error) {
+	c, s, ok := strings.Cut(token, ".")
+	if !ok {
+		return nil, ErrInvalidToken
+	}
+	claims, err := base64.RawURLEncoding.DecodeString(c)
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+	pk, err := crypto.Recover(sig, claims)
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+	owner, err := crypto.NewEthereumAddress(*pk)
+	if err != nil || !bytes.Equal(owner, a.owner) {
+		return nil, ErrInvalidToken
+	}
+
+	t := new(Token)
+	if err := json.Unmarshal(claims, t); err != nil {
+		return nil, ErrInvalidToken
+	}
+	if !a.now().Before(t.Expiry) {
+		return nil, ErrExpiredToken
+	}
+	if err := a.store.Get(keyPrefix+t.ID, new(Token)); err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, ErrRevokedToken
+		}
+		return nil, err
+	}
+	return t, nil
+}
+
+func (a *authenticator) Revoke(id string) error {
+	if err := a.store.Get(keyPrefix+id, new(Token)); err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return ErrNotFound
+		}
+		return err
+	}
+	return a.store.Delete(keyPrefix + id)
+}
+
+func (a *authenticator) Tokens() ([]*Token, error) {
+	var (
+		tokens  []*Token
+		expired []string
+	)
+	err := a.store.Iterate(keyPrefix, func(k, v []byte) (bool, error) {
+		t := new(Token)
+		if err := json.Unmarshal(v, t); err != nil {
+			return true, err
+		}
+		if a.now().Before(t.Expiry) {
+			tokens = append(tokens, t)
+		} else {
+			expired = append(expired, string(k))
+		}
+		return false, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	// the expired tokens are of no use, not even for revocation
+	for _, k := range expired {
+		if err := a.store.Delete(k); err != nil {
+			return nil, err
+		}
+	}
+	return tokens, nil
+}
@@ -0,0 +1,186 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package auth_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ethersphere/bee/v2/pkg/auth"
+	"github.com/ethersphere/bee/v2/pkg/crypto"
+	"github.com/ethersphere/bee/v2/pkg/statestore/mock"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func newAuthenticator(t *testing.T) auth.Authenticator {
+	t.Helper()
+
+	pk, err := crypto.GenerateSecp256k1Key()
+	if err != nil {
+		t.Fatal(err)
+	}
+	hash, err := bcrypt.GenerateFromPassword([]byte("secret"), bcrypt.MinCost)
+	if err != nil {
+		t.Fatal(err)
+	}
+	a, err := auth.New(crypto.NewDefaultSigner(pk), string(hash), mock.NewStateStore())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return a
+}
+
+func TestAuthenticate(t *testing.T) {
+	t.Parallel()
+
+	a := newAuthenticator(t)
+	if !a.Authenticate("secret") {
+		t.Fatal("expected the admin password to be accepted")
+	}
+	if a.Authenticate("guess") {
+		t.Fatal("expected a wrong password to be rejected")
+	}
+
+	pk, _ := crypto.GenerateSecp256k1Key()
+	if _, err := auth.New(crypto.NewDefaultSigner(pk), "secret", mock.NewStateStore()); err == nil {
+		t.Fatal("expected error on a password which is not a bcrypt hash")
+	}
+}
+
+func TestAuthorize(t *testing.T) {
+	t.Parallel()
+
+	a := newAuthenticator(t)
+	token, issued, err := a.Issue([]auth.Scope{auth.ScopeUpload, auth.ScopeDownload}, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []struct {
+		scope auth.Scope
+		want  error
+	}{
+		{auth.ScopeUpload, nil},
+		{auth.ScopeDownload, nil},
+		{auth.ScopeStamps, auth.ErrForbidden},
+		{auth.ScopeWallet, auth.ErrForbidden},
+		{auth.ScopeAdmin, auth.ErrForbidden},
+	} {
+		got, err := a.Authorize(token, tc.scope)
+		if !errors.Is(err, tc.want) {
+			t.Fatalf("scope %s: got error %v, want %v", tc.scope, err, tc.want)
+		}
+		if got == nil || got.ID != issued.ID {
+			t.Fatalf("scope %s: got token %+v, want %+v", tc.scope, got, issued)
+		}
+	}
+
+	admin, _, err := a.Issue([]auth.Scope{auth.ScopeAdmin}, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, s := range auth.Scopes {
+		if _, err := a.Authorize(admin, s); err != nil {
+			t.Fatalf("admin scope %s: %v", s, err)
+		}
+	}
+
+	if _, _, err := a.Issue([]auth.Scope{"root"}, time.Hour); !errors.Is(err, auth.ErrUnknownScope) {
+		t.Fatalf("got error %v, want %v", err, auth.ErrUnknownScope)
+	}
+}
+
+func TestInvalidToken(t *testing.T) {
+	t.Parallel()
+
+	a := newAuthenticator(t)
+	token, _, err := a.Issue([]auth.Scope{auth.ScopeDownload}, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// a token of another node
+	other, _, err := newAuthenticator(t).Issue([]auth.Scope{auth.ScopeDownload}, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []struct {
+		name  string
+		token string
+	}{
+		{"empty", ""},
+		{"no signature", token[:len(token)/2]},
+		{"tampered", "x" + token[1:]},
+		{"other node", other},
+	} {
+		if _, err := a.Authorize(tc.token, auth.ScopeDownload); !errors.Is(err, auth.ErrInvalidToken) {
+			t.Fatalf("%s: got error %v, want %v", tc.name, err, auth.ErrInvalidToken)
+		}
+	}
+}
+
+func TestExpiry(t *testing.T) {
+	t.Parallel()
+
+	a := newAuthenticator(t)
+	now := time.Now()
+	auth.SetNow(a, func() time.Time { return now })
+
+	token, _, err := a.Issue([]auth.Scope{auth.ScopeDownload}, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := a.Authorize(token, auth.ScopeDownload); err != nil {
+		t.Fatal(err)
+	}
+
+	now = now.Add(time.Minute)
+	if _, err := a.Authorize(token, auth.ScopeDownload); !errors.Is(err, auth.ErrExpiredToken) {
+		t.Fatalf("got error %v, want %v", err, auth.ErrExpiredToken)
+	}
+	tokens, err := a.Tokens()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tokens) != 0 {
+		t.Fatalf("got tokens %+v, want none", tokens)
+	}
+}
+
+func TestRevoke(t *testing.T) {
+	t.Parallel()
+
+	a := newAuthenticator(t)
+	token, issued, err := a.Issue([]auth.Scope{auth.ScopeDownload}, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	kept, _, err := a.Issue([]auth.Scope{auth.ScopeUpload}, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := a.Revoke(issued.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := a.Authorize(token, auth.ScopeDownload); !errors.Is(err, auth.ErrRevokedToken) {
+		t.Fatalf("got error %v, want %v", err, auth.ErrRevokedToken)
+	}
+	if _, err := a.Authorize(kept, auth.ScopeUpload); err != nil {
+		t.Fatal(err)
+	}
+	if err := a.Revoke(issued.ID); !errors.Is(err, auth.ErrNotFound) {
+		t.Fatalf("got error %v, want %v", err, auth.ErrNotFound)
+	}
+
+	tokens, err := a.Tokens()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tokens) != 1 || tokens[0].Scopes[0] != auth.ScopeUpload {
+		t.Fatalf("got tokens %+v, want the upload token", tokens)
+	}
+}
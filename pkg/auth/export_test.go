@@ -0,0 +1,11 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package auth
+
+import "time"
+
+func SetNow(a Authenticator, now func() time.Time) {
+	a.(*authenticator).now = now
+}
@@ -0,0 +1,210 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package chain provides implementation of hash chained feeds, which are
+// append-only logs of updates indexed the same way as sequence feeds.
+// Every update commits to the content of the previous one, so the readers
+// can verify that the log is unbroken and detect if the owner forked it.
+// this feed type is best suited for
+// - audit logs
+// - version histories that must not be rewritten
+package chain
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/ethersphere/bee/v2/pkg/cac"
+	"github.com/ethersphere/bee/v2/pkg/crypto"
+	"github.com/ethersphere/bee/v2/pkg/feeds"
+	"github.com/ethersphere/bee/v2/pkg/feeds/sequence"
+	storage "github.com/ethersphere/bee/v2/pkg/storage"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+)
+
+var (
+	_ feeds.Index   = (*index)(nil)
+	_ feeds.Lookup  = (*finder)(nil)
+	_ feeds.Updater = (*updater)(nil)
+)
+
+var (
+	// ErrBroken is returned if an update does not commit to the previous update of the feed.
+	ErrBroken = errors.New("chain feed: update does not commit to the previous update")
+	// ErrInvalidPayload is returned if an update is too short to hold the commitment.
+	ErrInvalidPayload = errors.New("chain feed: invalid update payload")
+)
+
+// index just wraps a uint64. implements the feeds.Index interface
+// with the same binary form as the sequence feed index.
+type index struct {
+	index uint64
+}
+
+func (i *index) String() string {
+	return strconv.FormatUint(i.index, 10)
+}
+
+func (i *index) MarshalBinary() ([]byte, error) {
+	indexBytes := make([]byte, 8)
+	binary.BigEndian.PutUint64(indexBytes, i.index)
+	return indexBytes, nil
+}
+
+// Next returns the index of the update following i.
+func (i *index) Next(last int64, at uint64) feeds.Index {
+	return &index{i.index + 1}
+}
+
+// fromIndex converts an index of the same binary form to a chain index.
+func fromIndex(i feeds.Index) (*index, error) {
+	b, err := i.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	if len(b) != 8 {
+		return nil, fmt.Errorf("chain feed: invalid index %s", i)
+	}
+	return &index{binary.BigEndian.Uint64(b)}, nil
+}
+
+// Payload splits the wrapped chunk of an update into the address of the
+// wrapped chunk of the previous update, which is the empty address for the
+// first update, and the chunk of the update payload.
+func Payload(wc swarm.Chunk) (prev swarm.Address, payload swarm.Chunk, err error) {
+	data := wc.Data()
+	if len(data) < swarm.SpanSize+swarm.HashSize {
+		return swarm.ZeroAddress, nil, ErrInvalidPayload
+	}
+	prev = swarm.NewAddress(data[swarm.SpanSize : swarm.SpanSize+swarm.HashSize])
+	payload, err = cac.New(data[swarm.SpanSize+swarm.HashSize:])
+	if err != nil {
+		return swarm.ZeroAddress, nil, err
+	}
+	return prev, payload, nil
+}
+
+// GetWrappedChunk is the feeds.GetWrappedChunk of the chain feed updates,
+// it skips the commitment to the previous update.
+func GetWrappedChunk(ctx context.Context, getter storage.Getter, ch swarm.Chunk) (swarm.Chunk, error) {
+	wc, err := feeds.FromChunk(ch)
+	if err != nil {
+		return nil, err
+	}
+	_, payload, err := Payload(wc)
+	if err != nil {
+		return nil, err
+	}
+	return feeds.ResolveWrappedChunk(ctx, getter, payload)
+}
+
+// verify checks that the update ch on the index i commits to the previous update.
+func verify(ctx context.Context, getter *feeds.Getter, i uint64, ch swarm.Chunk) error {
+	wc, err := feeds.FromChunk(ch)
+	if err != nil {
+		return err
+	}
+	prev, _, err := Payload(wc)
+	if err != nil {
+		return err
+	}
+	if i == 0 {
+		if !prev.IsEmpty() {
+			return fmt.Errorf("update %d: %w", i, ErrBroken)
+		}
+		return nil
+	}
+
+	pch, err := getter.Get(ctx, &index{i - 1})
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return fmt.Errorf("update %d: %w", i, ErrBroken)
+		}
+		return err
+	}
+	pwc, err := feeds.FromChunk(pch)
+	if err != nil {
+		return err
+	}
+	if !pwc.Address().Equal(prev) {
+		return fmt.Errorf("update %d: %w", i, ErrBroken)
+	}
+	return nil
+}
+
+// finder looks up the latest update with the sequence feed lookup and
+// verifies that it commits to the update before it.
+type finder struct {
+	getter *feeds.Getter
+	lookup feeds.Lookup
+}
+
+// NewFinder constructs a finder (feeds.Lookup interface)
+func NewFinder(getter storage.Getter, feed *feeds.Feed) feeds.Lookup {
+	return &finder{
+		getter: feeds.NewGetter(getter, feed),
+		lookup: sequence.NewAsyncFinder(getter, feed),
+	}
+}
+
+// At looks up the latest update, the chain feeds are not bound to time.
+// Only the link to the previous update is checked, the whole chain
+// is verified by the iteration of the feed history.
+func (f *finder) At(ctx context.Context, at int64, after uint64) (ch swarm.Chunk, current, next feeds.Index, err error) {
+	ch, cur, _, err := f.lookup.At(ctx, at, after)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if ch == nil {
+		return nil, nil, &index{0}, nil
+	}
+	i, err := fromIndex(cur)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if err := verify(ctx, f.getter, i.index, ch); err != nil {
+		return nil, nil, nil, err
+	}
+	return ch, i, i.Next(0, 0), nil
+}
+
+// updater encapsulates a feeds putter to generate successive updates for chain feeds
+// it persists the last update
+type updater struct {
+	*feeds.Putter
+	next uint64
+	prev swarm.Address
+}
+
+// NewUpdater constructs a feed updater
+func NewUpdater(putter storage.Putter, signer crypto.Signer, topic []byte) (feeds.Updater, error) {
+	p, err := feeds.NewPutter(putter, signer, topic)
+	if err != nil {
+		return nil, err
+	}
+	return &updater{Putter: p, prev: swarm.EmptyAddress}, nil
+}
+
+// Update pushes an update to the feed through the chunk stores
+// with the payload prefixed by the commitment to the previous update.
+func (u *updater) Update(ctx context.Context, at int64, payload []byte) error {
+	data := append(append(make([]byte, 0, swarm.HashSize+len(payload)), u.prev.Bytes()...), payload...)
+	wc, err := cac.New(data)
+	if err != nil {
+		return err
+	}
+	if err := u.Put(ctx, &index{u.next}, data); err != nil {
+		return err
+	}
+	u.next++
+	u.prev = wc.Address()
+	return nil
+}
+
+func (u *updater) Feed() *feeds.Feed {
+	return u.Putter.Feed
+}
@@ -0,0 +1,128 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package chain_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/ethersphere/bee/v2/pkg/crypto"
+	"github.com/ethersphere/bee/v2/pkg/feeds"
+	"github.com/ethersphere/bee/v2/pkg/feeds/chain"
+	feedstesting "github.com/ethersphere/bee/v2/pkg/feeds/testing"
+	"github.com/ethersphere/bee/v2/pkg/storage"
+	"github.com/ethersphere/bee/v2/pkg/storage/inmemchunkstore"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+)
+
+func TestFinder(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	storer := inmemchunkstore.New()
+	topic := []byte("testtopic")
+	pk, _ := crypto.GenerateSecp256k1Key()
+	updater, err := chain.NewUpdater(storer, crypto.NewDefaultSigner(pk), topic)
+	if err != nil {
+		t.Fatal(err)
+	}
+	finder := chain.NewFinder(storer, updater.Feed())
+
+	ch, err := feeds.Latest(ctx, finder, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ch != nil {
+		t.Fatalf("expected no update, got addr %v", ch.Address())
+	}
+
+	for i := 0; i < 5; i++ {
+		payload := []byte(fmt.Sprintf("payload %d", i))
+		if err := updater.Update(ctx, 0, payload); err != nil {
+			t.Fatal(err)
+		}
+
+		ch, cur, next, err := finder.At(ctx, 0, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if cur.String() != fmt.Sprint(i) || next.String() != fmt.Sprint(i+1) {
+			t.Fatalf("got indices %s %s, want %d %d", cur, next, i, i+1)
+		}
+		wc, err := chain.GetWrappedChunk(ctx, storer, ch)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(wc.Data()[swarm.SpanSize:], payload) {
+			t.Fatalf("got payload %q, want %q", wc.Data()[swarm.SpanSize:], payload)
+		}
+	}
+}
+
+func TestHistory(t *testing.T) {
+	t.Parallel()
+
+	feedstesting.TestHistory(t, chain.NewHistory, chain.NewUpdater)
+}
+
+// TestFork tests that an update which does not commit to the previous
+// update of the feed is detected.
+func TestFork(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	topic := []byte("testtopic")
+	pk, _ := crypto.GenerateSecp256k1Key()
+	signer := crypto.NewDefaultSigner(pk)
+
+	// the owner publishes the updates of two diverging logs
+	var forked []swarm.Chunk
+	storer := inmemchunkstore.New()
+	updater, err := chain.NewUpdater(storer, signer, topic)
+	if err != nil {
+		t.Fatal(err)
+	}
+	forkUpdater, err := chain.NewUpdater(storage.PutterFunc(func(_ context.Context, ch swarm.Chunk) error {
+		forked = append(forked, ch)
+		return nil
+	}), signer, topic)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 3; i++ {
+		if err := updater.Update(ctx, 0, []byte(fmt.Sprintf("payload %d", i))); err != nil {
+			t.Fatal(err)
+		}
+		if err := forkUpdater.Update(ctx, 0, []byte(fmt.Sprintf("fork %d", i))); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := forkUpdater.Update(ctx, 0, []byte("fork 3")); err != nil {
+		t.Fatal(err)
+	}
+	if err := storer.Put(ctx, forked[3]); err != nil {
+		t.Fatal(err)
+	}
+
+	_, _, _, err = chain.NewFinder(storer, updater.Feed()).At(ctx, 0, 0)
+	if !errors.Is(err, chain.ErrBroken) {
+		t.Fatalf("got error %v, want %v", err, chain.ErrBroken)
+	}
+
+	var visited int
+	err = chain.NewHistory(storer, updater.Feed()).Iterate(ctx, 0, func(*feeds.HistoryUpdate) (bool, error) {
+		visited++
+		return false, nil
+	})
+	if !errors.Is(err, chain.ErrBroken) {
+		t.Fatalf("got error %v, want %v", err, chain.ErrBroken)
+	}
+	if visited != 1 {
+		t.Fatalf("visited %d updates before the fork, want 1", visited)
+	}
+}
@@ -0,0 +1,98 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package chain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ethersphere/bee/v2/pkg/feeds"
+	"github.com/ethersphere/bee/v2/pkg/feeds/sequence"
+	storage "github.com/ethersphere/bee/v2/pkg/storage"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+)
+
+var _ feeds.History = (*history)(nil)
+
+// history iterates over the updates of a chain feed from the latest index
+// down to the first one and verifies the chain on the way.
+type history struct {
+	getter *feeds.Getter
+	lookup feeds.Lookup
+}
+
+// NewHistory constructs a history iterator (feeds.History interface)
+func NewHistory(getter storage.Getter, feed *feeds.Feed) feeds.History {
+	return &history{
+		getter: feeds.NewGetter(getter, feed),
+		lookup: sequence.NewAsyncFinder(getter, feed),
+	}
+}
+
+// Iterate calls fn for every update of the feed starting from the latest one.
+// The updates with a payload timestamp later than at are skipped. ErrBroken
+// is returned on the first update that the update after it does not commit
+// to, so iterating over all updates verifies the whole chain.
+func (h *history) Iterate(ctx context.Context, at int64, fn feeds.HistoryFunc) error {
+	_, cur, _, err := h.lookup.At(ctx, at, 0)
+	if err != nil {
+		return err
+	}
+	if cur == nil {
+		// the feed has no updates
+		return nil
+	}
+	last, err := fromIndex(cur)
+	if err != nil {
+		return err
+	}
+
+	// commitment of the update after the current one
+	var commitment swarm.Address
+	for i := last.index; ; i-- {
+		idx := &index{i}
+		ch, err := h.getter.Get(ctx, idx)
+		if err != nil {
+			if errors.Is(err, storage.ErrNotFound) {
+				return fmt.Errorf("update %d: %w", i+1, ErrBroken)
+			}
+			return fmt.Errorf("update %d: %w", i, err)
+		}
+		wc, err := feeds.FromChunk(ch)
+		if err != nil {
+			return fmt.Errorf("update %d: %w", i, err)
+		}
+		prev, payload, err := Payload(wc)
+		if err != nil {
+			return fmt.Errorf("update %d: %w", i, err)
+		}
+		if i != last.index && !commitment.Equal(wc.Address()) {
+			return fmt.Errorf("update %d: %w", i+1, ErrBroken)
+		}
+		if i == 0 && !prev.IsEmpty() {
+			return fmt.Errorf("update %d: %w", i, ErrBroken)
+		}
+
+		u := &feeds.HistoryUpdate{
+			Index:     idx,
+			Reference: payload.Address(),
+			Chunk:     ch,
+		}
+		if at, ref, err := feeds.LegacyPayload(payload); err == nil {
+			u.Timestamp, u.Reference = at, ref
+		}
+		if u.Timestamp == 0 || u.Timestamp <= uint64(at) {
+			stop, err := fn(u)
+			if err != nil || stop {
+				return err
+			}
+		}
+		if i == 0 {
+			return nil
+		}
+		commitment = prev
+	}
+}
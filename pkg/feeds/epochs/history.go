@@ -0,0 +1,74 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package epochs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ethersphere/bee/v2/pkg/feeds"
+	storage "github.com/ethersphere/bee/v2/pkg/storage"
+)
+
+var _ feeds.History = (*history)(nil)
+
+// history iterates over the updates of an epoch feed.
+//
+// The first update within the span of an epoch is always stored on the epoch
+// itself, so the epochs with updates form a tree rooted at the top epoch and
+// an epoch without an update has no updates below it either. An update also
+// precedes the updates stored below its epoch, which makes the reverse
+// pre-order traversal of this tree visit the updates from the latest one.
+type history struct {
+	getter *feeds.Getter
+}
+
+// NewHistory constructs a history iterator (feeds.History interface)
+func NewHistory(getter storage.Getter, feed *feeds.Feed) feeds.History {
+	return &history{feeds.NewGetter(getter, feed)}
+}
+
+// Iterate calls fn for every update of the feed not later than at,
+// starting from the latest one.
+func (h *history) Iterate(ctx context.Context, at int64, fn feeds.HistoryFunc) error {
+	if at < 0 {
+		return nil
+	}
+	_, err := h.iterate(ctx, &epoch{0, maxLevel}, uint64(at), fn)
+	return err
+}
+
+func (h *history) iterate(ctx context.Context, e *epoch, at uint64, fn feeds.HistoryFunc) (bool, error) {
+	ch, err := h.getter.Get(ctx, e)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return false, nil
+		}
+		return false, fmt.Errorf("update %s: %w", e, err)
+	}
+
+	if e.level > 0 {
+		left := &epoch{e.start, e.level - 1}
+		right := &epoch{e.start | left.length(), e.level - 1}
+		for _, c := range []*epoch{right, left} {
+			if c.start > at {
+				continue
+			}
+			if stop, err := h.iterate(ctx, c, at, fn); err != nil || stop {
+				return stop, err
+			}
+		}
+	}
+
+	u, err := feeds.NewHistoryUpdate(e, ch)
+	if err != nil {
+		return false, fmt.Errorf("update %s: %w", e, err)
+	}
+	if u.Timestamp > at {
+		return false, nil
+	}
+	return fn(u)
+}
@@ -44,3 +44,9 @@ func TestFinder_FLAKY(t *testing.T) {
 		testf(t, epochs.NewAsyncFinder, epochs.NewUpdater)
 	})
 }
+
+func TestHistory(t *testing.T) {
+	t.Parallel()
+
+	feedstesting.TestHistory(t, epochs.NewHistory, epochs.NewUpdater)
+}
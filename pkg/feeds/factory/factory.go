@@ -6,6 +6,7 @@ package factory
 
 import (
 	"github.com/ethersphere/bee/v2/pkg/feeds"
+	"github.com/ethersphere/bee/v2/pkg/feeds/chain"
 	"github.com/ethersphere/bee/v2/pkg/feeds/epochs"
 	"github.com/ethersphere/bee/v2/pkg/feeds/sequence"
 	storage "github.com/ethersphere/bee/v2/pkg/storage"
@@ -25,6 +26,21 @@ func (f *factory) NewLookup(t feeds.Type, feed *feeds.Feed) (feeds.Lookup, error
 		return sequence.NewAsyncFinder(f.Getter, feed), nil
 	case feeds.Epoch:
 		return epochs.NewAsyncFinder(f.Getter, feed), nil
+	case feeds.Chain:
+		return chain.NewFinder(f.Getter, feed), nil
+	}
+
+	return nil, feeds.ErrFeedTypeNotFound
+}
+
+func (f *factory) NewHistory(t feeds.Type, feed *feeds.Feed) (feeds.History, error) {
+	switch t {
+	case feeds.Sequence:
+		return sequence.NewHistory(f.Getter, feed), nil
+	case feeds.Epoch:
+		return epochs.NewHistory(f.Getter, feed), nil
+	case feeds.Chain:
+		return chain.NewHistory(f.Getter, feed), nil
 	}
 
 	return nil, feeds.ErrFeedTypeNotFound
@@ -6,6 +6,7 @@
 // indexing schemes are implemented in subpackages
 // - epochs
 // - sequence
+// - chain
 package feeds
 
 import (
@@ -23,9 +24,10 @@ import (
 
 var ErrFeedTypeNotFound = errors.New("no such feed type")
 
-// Factory creates feed lookups for different types of feeds.
+// Factory creates feed lookups and history iterators for different types of feeds.
 type Factory interface {
 	NewLookup(Type, *Feed) (Lookup, error)
+	NewHistory(Type, *Feed) (History, error)
 }
 
 // Type enumerates the time-based feed types
@@ -34,6 +36,7 @@ type Type int
 const (
 	Sequence Type = iota
 	Epoch
+	Chain
 )
 
 func (t Type) String() string {
@@ -42,6 +45,8 @@ func (t Type) String() string {
 		return "Sequence"
 	case Epoch:
 		return "Epoch"
+	case Chain:
+		return "Chain"
 	default:
 		return ""
 	}
@@ -54,6 +59,8 @@ func (t *Type) FromString(s string) error {
 		*t = Sequence
 	case "epoch":
 		*t = Epoch
+	case "chain":
+		*t = Chain
 	default:
 		return ErrFeedTypeNotFound
 	}
@@ -56,6 +56,12 @@ func GetWrappedChunk(ctx context.Context, getter storage.Getter, ch swarm.Chunk)
 	if err != nil {
 		return nil, err
 	}
+	return ResolveWrappedChunk(ctx, getter, wc)
+}
+
+// ResolveWrappedChunk returns the chunk referenced by the wrapped chunk of
+// a feed update if it has the legacy payload, otherwise the wrapped chunk itself.
+func ResolveWrappedChunk(ctx context.Context, getter storage.Getter, wc swarm.Chunk) (swarm.Chunk, error) {
 	// try to split the timestamp and reference
 	// possible values right now:
 	// unencrypted ref: span+timestamp+ref => 8+8+32=48
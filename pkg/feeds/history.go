@@ -0,0 +1,59 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package feeds
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+)
+
+// HistoryUpdate is a past update of a feed visited by the History iteration.
+type HistoryUpdate struct {
+	// Index of the update, nil if the feed type cannot tell it.
+	Index Index
+	// Timestamp of the update taken from the payload, 0 if it is not known.
+	Timestamp uint64
+	// Reference in the payload, or the address of the wrapped chunk
+	// if the payload does not hold a reference.
+	Reference swarm.Address
+	// Chunk is the single owner chunk of the update.
+	Chunk swarm.Chunk
+}
+
+// HistoryFunc is a callback on every update visited by the History iteration.
+// Returning true stops the iteration.
+type HistoryFunc func(*HistoryUpdate) (stop bool, err error)
+
+// History is the interface for iterating over the past updates of a feed.
+type History interface {
+	// Iterate calls fn for the updates of the feed published not later than
+	// the time at, starting with the latest one and going back in time.
+	Iterate(ctx context.Context, at int64, fn HistoryFunc) error
+}
+
+// NewHistoryUpdate creates a history update from the update chunk ch
+// found on the index i.
+func NewHistoryUpdate(i Index, ch swarm.Chunk) (*HistoryUpdate, error) {
+	wc, err := FromChunk(ch)
+	if err != nil {
+		return nil, err
+	}
+
+	u := &HistoryUpdate{
+		Index:     i,
+		Reference: wc.Address(),
+		Chunk:     ch,
+	}
+	at, ref, err := LegacyPayload(wc)
+	switch {
+	case err == nil:
+		u.Timestamp, u.Reference = at, ref
+	case !errors.Is(err, errNotLegacyPayload):
+		return nil, err
+	}
+	return u, nil
+}
@@ -0,0 +1,65 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sequence
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethersphere/bee/v2/pkg/feeds"
+	storage "github.com/ethersphere/bee/v2/pkg/storage"
+)
+
+var _ feeds.History = (*history)(nil)
+
+// history iterates over the updates of a sequence feed
+// from the latest index down to the first one
+type history struct {
+	getter *feeds.Getter
+	lookup feeds.Lookup
+}
+
+// NewHistory constructs a history iterator (feeds.History interface)
+func NewHistory(getter storage.Getter, feed *feeds.Feed) feeds.History {
+	return &history{
+		getter: feeds.NewGetter(getter, feed),
+		lookup: NewAsyncFinder(getter, feed),
+	}
+}
+
+// Iterate calls fn for every update of the feed starting from the latest one.
+// The sequence indices are not bound to time, so the updates with a payload
+// timestamp later than at are skipped.
+func (h *history) Iterate(ctx context.Context, at int64, fn feeds.HistoryFunc) error {
+	_, cur, _, err := h.lookup.At(ctx, at, 0)
+	if err != nil {
+		return err
+	}
+	if cur == nil {
+		// the feed has no updates
+		return nil
+	}
+
+	for i := cur.(*index).index; ; i-- {
+		idx := &index{i}
+		ch, err := h.getter.Get(ctx, idx)
+		if err != nil {
+			return fmt.Errorf("update %d: %w", i, err)
+		}
+		u, err := feeds.NewHistoryUpdate(idx, ch)
+		if err != nil {
+			return fmt.Errorf("update %d: %w", i, err)
+		}
+		if u.Timestamp == 0 || u.Timestamp <= uint64(at) {
+			stop, err := fn(u)
+			if err != nil || stop {
+				return err
+			}
+		}
+		if i == 0 {
+			return nil
+		}
+	}
+}
@@ -48,3 +48,9 @@ func TestFinder(t *testing.T) {
 		testf(t, sequence.NewAsyncFinder, sequence.NewUpdater)
 	})
 }
+
+func TestHistory(t *testing.T) {
+	t.Parallel()
+
+	feedstesting.TestHistory(t, sequence.NewHistory, sequence.NewUpdater)
+}
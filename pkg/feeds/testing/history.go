@@ -0,0 +1,90 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testing
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"testing"
+
+	"github.com/ethersphere/bee/v2/pkg/crypto"
+	"github.com/ethersphere/bee/v2/pkg/feeds"
+	storage "github.com/ethersphere/bee/v2/pkg/storage"
+	"github.com/ethersphere/bee/v2/pkg/storage/inmemchunkstore"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+)
+
+// TestHistory tests that the history iterator visits the updates published
+// not later than the given time, from the latest to the earliest one.
+func TestHistory(t *testing.T, historyf func(storage.Getter, *feeds.Feed) feeds.History, updaterf func(putter storage.Putter, signer crypto.Signer, topic []byte) (feeds.Updater, error)) {
+	t.Helper()
+
+	storer := inmemchunkstore.New()
+	topic, err := crypto.LegacyKeccak256([]byte("testtopic"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	pk, _ := crypto.GenerateSecp256k1Key()
+	signer := crypto.NewDefaultSigner(pk)
+
+	updater, err := updaterf(storer, signer, topic)
+	if err != nil {
+		t.Fatal(err)
+	}
+	history := historyf(storer, updater.Feed())
+	ctx := context.Background()
+
+	iterate := func(t *testing.T, at int64, limit int) []string {
+		t.Helper()
+
+		var got []string
+		err := history.Iterate(ctx, at, func(u *feeds.HistoryUpdate) (bool, error) {
+			got = append(got, fmt.Sprintf("%d:%s", u.Timestamp, u.Reference))
+			return len(got) == limit, nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return got
+	}
+
+	t.Run("no updates", func(t *testing.T) {
+		if got := iterate(t, 100, 0); len(got) != 0 {
+			t.Fatalf("expected no updates, got %v", got)
+		}
+	})
+
+	ats := []int64{10, 20, 35, 70, 100, 1000, 1001, 1 << 20}
+	var want []string
+	for _, at := range ats {
+		ref := swarm.RandAddress(t)
+		payload := make([]byte, 8, 8+swarm.HashSize)
+		binary.BigEndian.PutUint64(payload, uint64(at))
+		payload = append(payload, ref.Bytes()...)
+		if err := updater.Update(ctx, at, payload); err != nil {
+			t.Fatal(err)
+		}
+		want = append([]string{fmt.Sprintf("%d:%s", at, ref)}, want...)
+	}
+
+	t.Run("all updates", func(t *testing.T) {
+		if got := iterate(t, 1<<20, 0); fmt.Sprint(got) != fmt.Sprint(want) {
+			t.Fatalf("got updates %v, want %v", got, want)
+		}
+	})
+
+	t.Run("updates before", func(t *testing.T) {
+		if got := iterate(t, 50, 0); fmt.Sprint(got) != fmt.Sprint(want[5:]) {
+			t.Fatalf("got updates %v, want %v", got, want[5:])
+		}
+	})
+
+	t.Run("stop", func(t *testing.T) {
+		if got := iterate(t, 1<<20, 2); fmt.Sprint(got) != fmt.Sprint(want[:2]) {
+			t.Fatalf("got updates %v, want %v", got, want[:2])
+		}
+	})
+}
@@ -66,7 +66,9 @@ func fingerprint(addrs []swarm.Address) string {
 }
 
 // GetOrCreate returns a decoder for the given chunk address
-func (g *decoderCache) GetOrCreate(addrs []swarm.Address, shardCnt int) storage.Getter {
+// if lazy is set, a newly created decoder does not prefetch the sibling chunks
+// unless the retrieval of a requested chunk fails
+func (g *decoderCache) GetOrCreate(addrs []swarm.Address, shardCnt int, lazy bool) storage.Getter {
 
 	// since a recovery decoder is not allowed, simply return the underlying netstore
 	if g.config.Strict && g.config.Strategy == getter.NONE {
@@ -98,7 +100,9 @@ func (g *decoderCache) GetOrCreate(addrs []swarm.Address, shardCnt int) storage.
 			g.cache[key] = nil
 		}
 	}
-	d = getter.New(addrs, shardCnt, g.fetcher, g.putter, remove, g.config)
+	conf := g.config
+	conf.Lazy = lazy
+	d = getter.New(addrs, shardCnt, g.fetcher, g.putter, remove, conf)
 	g.cache[key] = d
 	return d
 }
@@ -186,7 +190,7 @@ func (j *joiner) ReadAt(buffer []byte, off int64) (read int, err error) {
 		return 0, io.EOF
 	}
 
-	readLen := int64(cap(buffer))
+	readLen := int64(len(buffer))
 	if readLen > j.span-off {
 		readLen = j.span - off
 	}
@@ -234,7 +238,10 @@ func (j *joiner) readAtOffset(
 	}
 
 	addrs, shardCnt := file.ChunkAddresses(data[:pSize], parity, j.refLength)
-	g := store.New(j.decoders.GetOrCreate(addrs, shardCnt))
+	// only prefetch the children if the whole subtrie is read,
+	// otherwise fetch just the chunks covering the requested range
+	whole := off == cur && bytesToRead >= subTrieSize
+	g := store.New(j.decoders.GetOrCreate(addrs, shardCnt, !whole))
 	for cursor := 0; cursor < len(data); cursor += j.refLength {
 		if bytesToRead == 0 {
 			break
@@ -385,7 +392,7 @@ func (j *joiner) processChunkAddresses(ctx context.Context, fn swarm.AddressIter
 		return err
 	}
 	addrs, shardCnt := file.ChunkAddresses(data[:eSize], parity, j.refLength)
-	g := store.New(j.decoders.GetOrCreate(addrs, shardCnt))
+	g := store.New(j.decoders.GetOrCreate(addrs, shardCnt, false))
 	for i, addr := range addrs {
 		if err := fn(addr); err != nil {
 			return err
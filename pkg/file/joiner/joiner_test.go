@@ -693,6 +693,75 @@ func TestJoinerReadAt(t *testing.T) {
 	}
 }
 
+// TestJoinerReadAtRange tests that reading a byte range only retrieves
+// the intermediate chunks and leaves covering the range.
+func TestJoinerReadAtRange(t *testing.T) {
+	t.Parallel()
+
+	for _, rLevel := range []redundancy.Level{redundancy.NONE, redundancy.MEDIUM} {
+		rLevel := rLevel
+		t.Run(fmt.Sprintf("rLevel=%d", rLevel), func(t *testing.T) {
+			t.Parallel()
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			shardCnt := swarm.Branches
+			if rLevel != redundancy.NONE {
+				shardCnt = rLevel.GetMaxShards()
+			}
+			size := 3 * shardCnt * swarm.ChunkSize
+			data, err := io.ReadAll(io.LimitReader(rand.Reader, int64(size)))
+			if err != nil {
+				t.Fatal(err)
+			}
+			store := mockstorer.NewForgettingStore(newChunkStore())
+			pipe := builder.NewPipelineBuilder(ctx, store, false, rLevel)
+			addr, err := builder.FeedPipeline(ctx, pipe, bytes.NewReader(data))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// do not retrieve the root chunk replicas in the background
+			ctx = redundancy.SetLevelInContext(ctx, redundancy.NONE)
+
+			for _, tc := range []struct {
+				name    string
+				off     int64
+				length  int
+				fetched int
+			}{
+				{"within a leaf", int64(shardCnt*swarm.ChunkSize + 100), 200, 2},
+				{"across leaves", int64(shardCnt*swarm.ChunkSize + swarm.ChunkSize - 10), 20, 3},
+				{"across intermediate chunks", int64(2*shardCnt*swarm.ChunkSize - 10), 20, 4},
+			} {
+				// a new joiner for each range so that no chunks are cached by the decoders
+				j, _, err := joiner.New(ctx, store, store, addr)
+				if err != nil {
+					t.Fatal(err)
+				}
+				store.Reset()
+				store.Record()
+				buf := make([]byte, tc.length)
+				n, err := j.ReadAt(buf, tc.off)
+				store.Unrecord()
+				if err != nil {
+					t.Fatal(err)
+				}
+				if n != tc.length {
+					t.Fatalf("%s: read %d bytes, want %d", tc.name, n, tc.length)
+				}
+				if !bytes.Equal(buf, data[tc.off:tc.off+int64(tc.length)]) {
+					t.Fatalf("%s: content mismatch", tc.name)
+				}
+				if got := store.Missed(); got != tc.fetched {
+					t.Fatalf("%s: retrieved %d chunks, want %d", tc.name, got, tc.fetched)
+				}
+			}
+		})
+	}
+}
+
 // TestJoinerOneLevel tests the retrieval of two data chunks immediately
 // below the root chunk level.
 func TestJoinerOneLevel(t *testing.T) {
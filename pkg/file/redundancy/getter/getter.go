@@ -7,6 +7,7 @@ package getter
 import (
 	"context"
 	"errors"
+	"sort"
 	"sync"
 	"sync/atomic"
 
@@ -42,6 +43,7 @@ type decoder struct {
 	fetchedCnt   atomic.Int32    // count successful retrievals
 	failedCnt    atomic.Int32    // count successful retrievals
 	remove       func(error)     // callback to remove decoder from decoders cache
+	prefetchOnce sync.Once       // ensures the prefetch strategies are started only once
 	config       Config          // configuration
 	logger       log.Logger
 }
@@ -78,7 +80,9 @@ func New(addrs []swarm.Address, shardCnt int, g storage.Getter, p storage.Putter
 		d.waits[i] = make(chan error)
 	}
 
-	go d.prefetch()
+	if !conf.Lazy {
+		d.startPrefetch()
+	}
 
 	return d
 }
@@ -107,6 +111,11 @@ func (g *decoder) fetch(ctx context.Context, i int, waitForRecovery bool) (err e
 			return err
 		}
 
+		// a lazy decoder only starts the strategies when a retrieval fails
+		if err != nil {
+			g.startPrefetch()
+		}
+
 		select {
 		case <-g.badRecovery:
 			return storage.ErrNotFound
@@ -170,6 +179,13 @@ func (g *decoder) fetch(ctx context.Context, i int, waitForRecovery bool) (err e
 	return waitRecovery(storage.ErrNotFound)
 }
 
+// startPrefetch starts the retrieval strategies in the background unless already started
+func (g *decoder) startPrefetch() {
+	g.prefetchOnce.Do(func() {
+		go g.prefetch()
+	})
+}
+
 func (g *decoder) prefetch() {
 
 	var err error
@@ -213,9 +229,11 @@ func (g *decoder) runStrategy(s Strategy) error {
 	// across the different strategies, the common goal is to fetch at least as many chunks
 	// as the number of data shards.
 	// DATA strategy has a max error tolerance of zero.
+	// PROX strategy has a max error tolerance of number of parity chunks,
+	// failed retrievals are replaced by the next closest unattempted chunk.
 	// RACE strategy has a max error tolerance of number of parity chunks.
 	var allowedErrs int
-	var m []int
+	var m, spare []int
 
 	switch s {
 	case NONE:
@@ -225,9 +243,17 @@ func (g *decoder) runStrategy(s Strategy) error {
 		m = g.unattemptedDataShards()
 		allowedErrs = 0
 	case PROX:
+		allowedErrs = g.parityCnt
 		// proximity driven selective fetching
-		// NOT IMPLEMENTED
-		return errStrategyNotAllowed
+		// retrieve only as many of the closest chunks as needed, keep the rest as spare
+		m = g.proximityOrder(g.unattemptedShards())
+		need := g.shardCnt - g.pendingOrFetchedCnt()
+		if need < 0 {
+			need = 0
+		}
+		if need < len(m) {
+			m, spare = m[:need], m[need:]
+		}
 	case RACE:
 		allowedErrs = g.parityCnt
 		// retrieve all chunks at once enabling race among chunks
@@ -237,28 +263,44 @@ func (g *decoder) runStrategy(s Strategy) error {
 		}
 	}
 
-	if len(m) == 0 {
-		return nil
-	}
-
-	c := make(chan error, len(m))
+	c := make(chan error, len(m)+len(spare))
 
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
-	for _, i := range m {
-		go func(i int) {
+	run := func(i int) {
+		go func() {
 			c <- g.fetch(ctx, i, false)
-		}(i)
+		}()
 	}
 
-	for range c {
+	for _, i := range m {
+		run(i)
+	}
+
+	for pending := len(m); pending > 0; pending-- {
+		err := <-c
 		if g.fetchedCnt.Load() >= int32(g.shardCnt) {
 			return nil
 		}
 		if g.failedCnt.Load() > int32(allowedErrs) {
 			return errStrategyFailed
 		}
+		if err != nil && len(spare) > 0 {
+			run(spare[0])
+			spare = spare[1:]
+			pending++
+		}
+	}
+
+	// wait for retrievals that were not started by the strategy
+	for i := range g.addrs {
+		if g.inflight[i].Load() {
+			<-g.waits[i]
+		}
+	}
+	if g.fetchedCnt.Load() < int32(g.shardCnt) {
+		return errStrategyFailed
 	}
 
 	return nil
@@ -311,6 +353,46 @@ func (g *decoder) unattemptedDataShards() (m []int) {
 	return m
 }
 
+// unattemptedShards returns the positions of both data and parity shards
+// whose retrieval has not been attempted yet
+func (g *decoder) unattemptedShards() (m []int) {
+	for i := 0; i < len(g.addrs); i++ {
+		if !g.inflight[i].Load() {
+			m = append(m, i)
+		}
+	}
+	return m
+}
+
+// pendingOrFetchedCnt returns the number of shards that are either retrieved
+// or still being retrieved
+func (g *decoder) pendingOrFetchedCnt() (n int) {
+	for i := 0; i < len(g.addrs); i++ {
+		if g.inflight[i].Load() {
+			n++
+		}
+	}
+	return n - int(g.failedCnt.Load())
+}
+
+// proximityOrder sorts the shard positions by the proximity of the closest known node to the
+// shard address in descending order. On equal proximity the original order is kept,
+// so data shards are preferred over parities as they need no decoding.
+// If no proximity function is configured, the positions are returned unchanged.
+func (g *decoder) proximityOrder(m []int) []int {
+	if g.config.Proximity == nil {
+		return m
+	}
+	po := make(map[int]uint8, len(m))
+	for _, i := range m {
+		po[i] = g.config.Proximity(g.addrs[i])
+	}
+	sort.SliceStable(m, func(a, b int) bool {
+		return po[m[a]] > po[m[b]]
+	})
+	return m
+}
+
 // it must be called under mutex protection
 func (g *decoder) missingDataShards() (m []int) {
 	for i := 0; i < g.shardCnt; i++ {
@@ -19,6 +19,7 @@ import (
 
 	"github.com/ethersphere/bee/v2/pkg/cac"
 	"github.com/ethersphere/bee/v2/pkg/file/redundancy/getter"
+	"github.com/ethersphere/bee/v2/pkg/log"
 	"github.com/ethersphere/bee/v2/pkg/storage"
 	inmem "github.com/ethersphere/bee/v2/pkg/storage/inmemchunkstore"
 	mockstorer "github.com/ethersphere/bee/v2/pkg/storer/mock"
@@ -92,6 +93,139 @@ func TestGetterFallback(t *testing.T) {
 	})
 }
 
+// TestGetterPROX tests the retrieval of chunks with missing data shards
+// using the PROX strategy
+func TestGetterPROX(t *testing.T) {
+	t.Parallel()
+
+	t.Run("fetches closest chunks only", func(t *testing.T) {
+		t.Parallel()
+
+		bufSize := 12
+		shardCnt := 6
+		store := inmem.New()
+		buf := make([][]byte, bufSize)
+		addrs := initData(t, buf, shardCnt, store)
+
+		// erase two data shards that are far away
+		far := []int{1, 2}
+		ctx := context.TODO()
+		for _, i := range far {
+			if err := store.Delete(ctx, addrs[i]); err != nil {
+				t.Fatal(err)
+			}
+		}
+		proximity := func(addr swarm.Address) uint8 {
+			for _, i := range far {
+				if addr.Equal(addrs[i]) {
+					return 0
+				}
+			}
+			return 8
+		}
+
+		fetcher := newRecordingGetter(store)
+		conf := getter.Config{
+			Strategy:     getter.PROX,
+			Strict:       true,
+			FetchTimeout: time.Second,
+			Logger:       log.Noop,
+			Proximity:    proximity,
+		}
+		g := getter.New(addrs, shardCnt, fetcher, store, func(error) {}, conf)
+
+		if _, err := g.Get(context.Background(), addrs[0]); err != nil {
+			t.Fatal(err)
+		}
+		checkShardsAvailable(t, store, addrs[:shardCnt], buf[:shardCnt])
+
+		// only the closest shardCnt chunks are requested
+		for _, i := range far {
+			if fetcher.requested(addrs[i]) {
+				t.Fatalf("far data shard %d should not be requested", i)
+			}
+		}
+		if got := fetcher.count(); got != shardCnt {
+			t.Fatalf("got %d requests, want %d", got, shardCnt)
+		}
+	})
+
+	t.Run("replaces failed retrievals", func(t *testing.T) {
+		t.Parallel()
+
+		bufSize := 12
+		shardCnt := 6
+		store := inmem.New()
+		buf := make([][]byte, bufSize)
+		addrs := initData(t, buf, shardCnt, store)
+
+		// erase two close parity shards
+		ctx := context.TODO()
+		for _, i := range []int{6, 7} {
+			if err := store.Delete(ctx, addrs[i]); err != nil {
+				t.Fatal(err)
+			}
+		}
+		// erase a far data shard
+		if err := store.Delete(ctx, addrs[5]); err != nil {
+			t.Fatal(err)
+		}
+		// parities are the closest, data shards are the farthest
+		proximity := func(addr swarm.Address) uint8 {
+			for i := range addrs {
+				if addr.Equal(addrs[i]) {
+					return uint8(i)
+				}
+			}
+			return 0
+		}
+
+		fetcher := newRecordingGetter(store)
+		conf := getter.Config{
+			Strategy:     getter.PROX,
+			Strict:       true,
+			FetchTimeout: time.Second,
+			Logger:       log.Noop,
+			Proximity:    proximity,
+		}
+		g := getter.New(addrs, shardCnt, fetcher, store, func(error) {}, conf)
+
+		if _, err := g.Get(context.Background(), addrs[5]); err != nil {
+			t.Fatal(err)
+		}
+		checkShardsAvailable(t, store, addrs[:shardCnt], buf[:shardCnt])
+
+		if got, max := fetcher.count(), shardCnt+3; got > max {
+			t.Fatalf("got %d requests, want at most %d", got, max)
+		}
+	})
+
+	t.Run("unable to recover", func(t *testing.T) {
+		t.Parallel()
+
+		bufSize := 12
+		shardCnt := 6
+		store := inmem.New()
+		buf := make([][]byte, bufSize)
+		addrs := initData(t, buf, shardCnt, store)
+		forget(t, store, addrs[:shardCnt], shardCnt)
+		forget(t, store, addrs[shardCnt:], 1)
+
+		conf := getter.Config{
+			Strategy:     getter.PROX,
+			Strict:       true,
+			FetchTimeout: time.Second,
+			Logger:       log.Noop,
+		}
+		g := getter.New(addrs, shardCnt, store, store, func(error) {}, conf)
+
+		_, err := g.Get(context.Background(), addrs[0])
+		if !errors.Is(err, storage.ErrNotFound) {
+			t.Fatalf("expected not found error, got %v", err)
+		}
+	})
+}
+
 func testDecodingRACE(t *testing.T, bufSize, shardCnt, erasureCnt int) {
 	t.Helper()
 	store := inmem.New()
@@ -360,3 +494,34 @@ func forget(t *testing.T, store storage.ChunkStore, addrs []swarm.Address, erasu
 	}
 	return erasures
 }
+
+// recordingGetter records the addresses of the chunks requested from the underlying getter
+type recordingGetter struct {
+	storage.Getter
+	mu   sync.Mutex
+	reqs map[string]struct{}
+}
+
+func newRecordingGetter(g storage.Getter) *recordingGetter {
+	return &recordingGetter{Getter: g, reqs: make(map[string]struct{})}
+}
+
+func (r *recordingGetter) Get(ctx context.Context, addr swarm.Address) (swarm.Chunk, error) {
+	r.mu.Lock()
+	r.reqs[addr.ByteString()] = struct{}{}
+	r.mu.Unlock()
+	return r.Getter.Get(ctx, addr)
+}
+
+func (r *recordingGetter) requested(addr swarm.Address) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	_, ok := r.reqs[addr.ByteString()]
+	return ok
+}
+
+func (r *recordingGetter) count() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.reqs)
+}
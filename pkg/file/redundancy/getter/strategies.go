@@ -11,6 +11,7 @@ import (
 
 	"github.com/ethersphere/bee/v2/pkg/log"
 	"github.com/ethersphere/bee/v2/pkg/retrieval"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
 )
 
 const (
@@ -24,15 +25,23 @@ type (
 	modeKey         struct{}
 	fetchTimeoutKey struct{}
 	loggerKey       struct{}
+	proximityKey    struct{}
 	Strategy        = int
 )
 
+// ProximityFunc returns the proximity order of the closest known node
+// (the local node or one of its connected peers) to the given chunk address.
+// The higher the returned value, the fewer hops the retrieval is expected to take.
+type ProximityFunc func(swarm.Address) uint8
+
 // Config is the configuration for the getter - public
 type Config struct {
 	Strategy     Strategy
 	Strict       bool
 	FetchTimeout time.Duration
 	Logger       log.Logger
+	Proximity    ProximityFunc
+	Lazy         bool // start the strategies only when a retrieval fails
 }
 
 const (
@@ -82,6 +91,12 @@ func NewConfigFromContext(ctx context.Context, def Config) (conf Config, err err
 			return conf, e("strategy timeout")
 		}
 	}
+	if val := ctx.Value(proximityKey{}); val != nil {
+		conf.Proximity, ok = val.(ProximityFunc)
+		if !ok {
+			return conf, e("proximity function")
+		}
+	}
 
 	return conf, nil
 }
@@ -105,6 +120,11 @@ func SetLogger(ctx context.Context, l log.Logger) context.Context {
 	return context.WithValue(ctx, loggerKey{}, l)
 }
 
+// SetProximity sets the proximity function used by the PROX strategy
+func SetProximity(ctx context.Context, f ProximityFunc) context.Context {
+	return context.WithValue(ctx, proximityKey{}, f)
+}
+
 // SetConfigInContext sets the config params in the context
 func SetConfigInContext(ctx context.Context, s *Strategy, fallbackmode *bool, fetchTimeout *string, logger log.Logger) (context.Context, error) {
 	if s != nil {
@@ -41,6 +41,15 @@ var (
 // the Store function.
 type StoreSizeFunc func(int64) error
 
+// IterateEntryFunc is a callback on every entry visited by the IterateEntries
+// function.
+type IterateEntryFunc func(path string, entry Entry) error
+
+// DiffFunc is a callback on every path that differs between the manifests
+// compared by the Diff function. The entry a is nil for the added paths and
+// the entry b is nil for the removed ones.
+type DiffFunc func(path string, a, b Entry) error
+
 // Interface for operations with manifest.
 type Interface interface {
 	// Type returns manifest implementation type information
@@ -58,6 +67,13 @@ type Interface interface {
 	// IterateAddresses is used to iterate over chunks addresses for
 	// the manifest.
 	IterateAddresses(context.Context, swarm.AddressIterFunc) error
+	// IterateEntries is used to iterate over the entries of the manifest
+	// whose path starts with the given prefix, in lexicographical order.
+	IterateEntries(context.Context, string, IterateEntryFunc) error
+	// IterateEntriesAfter is used to iterate over the entries of the manifest
+	// whose path starts with the given prefix and is lexicographically after
+	// the given path, in lexicographical order.
+	IterateEntriesAfter(context.Context, string, string, IterateEntryFunc) error
 }
 
 // Entry represents a single manifest entry.
@@ -183,6 +183,68 @@ func (m *mantarayManifest) IterateAddresses(ctx context.Context, fn swarm.Addres
 	return nil
 }
 
+func (m *mantarayManifest) IterateEntries(ctx context.Context, prefix string, fn IterateEntryFunc) error {
+	err := m.trie.WalkPrefix(ctx, []byte(prefix), m.ls, entryWalker(fn))
+	if err != nil {
+		return fmt.Errorf("manifest iterate entries: %w", err)
+	}
+
+	return nil
+}
+
+func (m *mantarayManifest) IterateEntriesAfter(ctx context.Context, prefix, after string, fn IterateEntryFunc) error {
+	err := m.trie.WalkPrefixAfter(ctx, []byte(prefix), []byte(after), m.ls, entryWalker(fn))
+	if err != nil {
+		return fmt.Errorf("manifest iterate entries: %w", err)
+	}
+
+	return nil
+}
+
+// entryWalker returns the node walker calling fn for the value nodes.
+func entryWalker(fn IterateEntryFunc) mantaray.WalkNodeFunc {
+	return func(path []byte, node *mantaray.Node, err error) error {
+		if err != nil {
+			return err
+		}
+		if node == nil || !node.IsValueType() {
+			return nil
+		}
+		return fn(string(path), NewEntry(swarm.NewAddress(node.Entry()), node.Metadata()))
+	}
+}
+
+// Diff compares the mantaray manifests a and b, calling fn for every entry
+// that was added, removed or changed in b with respect to a, in lexicographical
+// order of the paths. The subtrees shared by both manifests are skipped. The
+// nodes of both manifests are loaded with the load saver of the manifest a.
+func Diff(ctx context.Context, a, b Interface, fn DiffFunc) error {
+	ma, ok := a.(*mantarayManifest)
+	if !ok {
+		return ErrInvalidManifestType
+	}
+	mb, ok := b.(*mantarayManifest)
+	if !ok {
+		return ErrInvalidManifestType
+	}
+
+	toEntry := func(n *mantaray.Node) Entry {
+		if n == nil {
+			return nil
+		}
+		return NewEntry(swarm.NewAddress(n.Entry()), n.Metadata())
+	}
+
+	err := mantaray.Diff(ctx, ma.trie, mb.trie, ma.ls, func(path []byte, na, nb *mantaray.Node) error {
+		return fn(string(path), toEntry(na), toEntry(nb))
+	})
+	if err != nil {
+		return fmt.Errorf("manifest diff: %w", err)
+	}
+
+	return nil
+}
+
 type mantarayLoadSaver struct {
 	ls          file.LoadSaver
 	storeSizeFn []StoreSizeFunc
@@ -0,0 +1,153 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mantaray
+
+import (
+	"bytes"
+	"context"
+	"maps"
+	"sort"
+)
+
+// DiffFunc is the type of the function called for each path whose value
+// differs between the tries compared by Diff. The node a is nil for the paths
+// added in b and the node b is nil for the paths removed from a.
+type DiffFunc func(path []byte, a, b *Node) error
+
+// Diff compares the tries rooted at a and b, calling diffFn for every value
+// path that was added, removed or changed in b with respect to a, in
+// lexicographical order. Forks referencing the same node in both tries
+// are skipped without loading them.
+func Diff(ctx context.Context, a, b *Node, l Loader, diffFn DiffFunc) error {
+	return diffNodes(ctx, []byte{}, a, b, l, diffFn)
+}
+
+// diffNodes compares the nodes a and b that are both on the given path.
+func diffNodes(ctx context.Context, path []byte, a, b *Node, l Loader, diffFn DiffFunc) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+	}
+
+	// the type and the metadata of a node are stored in its parent,
+	// so they have to be compared even if the nodes are the same
+	same := a.ref != nil && bytes.Equal(a.ref, b.ref)
+	if !same {
+		if a.forks == nil {
+			if err := a.load(ctx, l); err != nil {
+				return err
+			}
+		}
+		if b.forks == nil {
+			if err := b.load(ctx, l); err != nil {
+				return err
+			}
+		}
+	}
+
+	var err error
+	switch {
+	case a.IsValueType() && b.IsValueType():
+		if (!same && !bytes.Equal(a.entry, b.entry)) || !maps.Equal(a.metadata, b.metadata) {
+			err = diffFn(append(path[:0:0], path...), a, b)
+		}
+	case a.IsValueType():
+		err = diffFn(append(path[:0:0], path...), a, nil)
+	case b.IsValueType():
+		err = diffFn(append(path[:0:0], path...), nil, b)
+	}
+	if err != nil || same {
+		return err
+	}
+
+	keys := make([]byte, 0, len(a.forks)+len(b.forks))
+	for k := range a.forks {
+		keys = append(keys, k)
+	}
+	for k := range b.forks {
+		if _, ok := a.forks[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+
+	for _, k := range keys {
+		fa, fb := a.forks[k], b.forks[k]
+		if err := diffForks(ctx, path, fa, fb, l, diffFn); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// diffForks compares the forks fa and fb of the nodes on the given path
+// starting with the same byte. Either of the forks may be nil.
+func diffForks(ctx context.Context, path []byte, fa, fb *fork, l Loader, diffFn DiffFunc) error {
+	removed := func(p []byte, n *Node, _ error) error {
+		if n.IsValueType() {
+			return diffFn(p, n, nil)
+		}
+		return nil
+	}
+	added := func(p []byte, n *Node, _ error) error {
+		if n.IsValueType() {
+			return diffFn(p, nil, n)
+		}
+		return nil
+	}
+
+	switch {
+	case fb == nil:
+		return walkNode(ctx, joinPath(path, fa.prefix), l, fa.Node, removed)
+	case fa == nil:
+		return walkNode(ctx, joinPath(path, fb.prefix), l, fb.Node, added)
+	}
+
+	c := common(fa.prefix, fb.prefix)
+	nextPath := joinPath(path, c)
+
+	switch {
+	case len(c) == len(fa.prefix) && len(c) == len(fb.prefix):
+		return diffNodes(ctx, nextPath, fa.Node, fb.Node, l, diffFn)
+	case len(c) == len(fa.prefix):
+		return diffNodes(ctx, nextPath, fa.Node, splitFork(fb, len(c)), l, diffFn)
+	case len(c) == len(fb.prefix):
+		return diffNodes(ctx, nextPath, splitFork(fa, len(c)), fb.Node, l, diffFn)
+	}
+
+	// the forks diverge, so none of their paths are shared
+	pathA := joinPath(path, fa.prefix)
+	pathB := joinPath(path, fb.prefix)
+	if fa.prefix[len(c)] < fb.prefix[len(c)] {
+		if err := walkNode(ctx, pathA, l, fa.Node, removed); err != nil {
+			return err
+		}
+		return walkNode(ctx, pathB, l, fb.Node, added)
+	}
+	if err := walkNode(ctx, pathB, l, fb.Node, added); err != nil {
+		return err
+	}
+	return walkNode(ctx, pathA, l, fa.Node, removed)
+}
+
+// splitFork returns an intermediate node holding the rest of the fork f
+// after the first n bytes of its prefix.
+func splitFork(f *fork, n int) *Node {
+	nn := New()
+	nn.refBytesSize = f.Node.refBytesSize
+	rest := f.prefix[n:]
+	nn.forks[rest[0]] = &fork{rest, f.Node}
+	nn.makeEdge()
+	return nn
+}
+
+// joinPath returns a new path made of path followed by suffix.
+func joinPath(path, suffix []byte) []byte {
+	p := make([]byte, 0, len(path)+len(suffix))
+	p = append(p, path...)
+	return append(p, suffix...)
+}
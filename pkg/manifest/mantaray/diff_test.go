@@ -0,0 +1,164 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mantaray_test
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"testing"
+
+	"github.com/ethersphere/bee/v2/pkg/manifest/mantaray"
+)
+
+type countingLoader struct {
+	mantaray.Loader
+	count atomic.Int32
+}
+
+func (l *countingLoader) Load(ctx context.Context, ref []byte) ([]byte, error) {
+	l.count.Add(1)
+	return l.Loader.Load(ctx, ref)
+}
+
+func TestDiff(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	type entry struct {
+		path     string
+		value    string
+		metadata map[string]string
+	}
+
+	for _, tc := range []struct {
+		name     string
+		a, b     []entry
+		expected []string
+	}{
+		{
+			name: "equal",
+			a:    []entry{{path: "index.html"}, {path: "img/1.png"}},
+			b:    []entry{{path: "img/1.png"}, {path: "index.html"}},
+		},
+		{
+			name: "added and removed",
+			a:    []entry{{path: "index.html"}, {path: "img/1.png"}, {path: "robots.txt"}},
+			b:    []entry{{path: "index.html"}, {path: "img/1.png"}, {path: "img/2.png"}},
+			expected: []string{
+				"added img/2.png",
+				"removed robots.txt",
+			},
+		},
+		{
+			name: "changed",
+			a:    []entry{{path: "index.html", value: "a"}, {path: "img/1.png"}, {path: "/", metadata: map[string]string{"index": "index.html"}}},
+			b:    []entry{{path: "index.html", value: "b"}, {path: "img/1.png"}, {path: "/", metadata: map[string]string{"index": "img/1.png"}}},
+			expected: []string{
+				"changed /",
+				"changed index.html",
+			},
+		},
+		{
+			name: "split forks",
+			a:    []entry{{path: "img/1.png"}, {path: "index.html"}, {path: "index.html.backup"}},
+			b:    []entry{{path: "img/1.png"}, {path: "img/10.png"}, {path: "index.html.backup"}},
+			expected: []string{
+				"added img/10.png",
+				"removed index.html",
+			},
+		},
+		{
+			name: "diverging forks",
+			a:    []entry{{path: "img/abc.png"}, {path: "img/axe.png"}},
+			b:    []entry{{path: "img/abd.png"}},
+			expected: []string{
+				"removed img/abc.png",
+				"added img/abd.png",
+				"removed img/axe.png",
+			},
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ls := newMockLoadSaver()
+			create := func(entries []entry) *mantaray.Node {
+				n := mantaray.New()
+				for _, e := range entries {
+					v := e.path + e.value
+					if err := n.Add(ctx, []byte(e.path), append(make([]byte, 32-len(v)), v...), e.metadata, ls); err != nil {
+						t.Fatalf("expected no error, got %v", err)
+					}
+				}
+				if err := n.Save(ctx, ls); err != nil {
+					t.Fatalf("expected no error, got %v", err)
+				}
+				return mantaray.NewNodeRef(n.Reference())
+			}
+
+			var got []string
+			err := mantaray.Diff(ctx, create(tc.a), create(tc.b), ls, func(path []byte, a, b *mantaray.Node) error {
+				switch {
+				case a == nil:
+					got = append(got, "added "+string(path))
+				case b == nil:
+					got = append(got, "removed "+string(path))
+				default:
+					got = append(got, "changed "+string(path))
+				}
+				return nil
+			})
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if fmt.Sprint(got) != fmt.Sprint(tc.expected) {
+				t.Fatalf("got %v, want %v", got, tc.expected)
+			}
+		})
+	}
+
+	t.Run("shared subtrees are not loaded", func(t *testing.T) {
+		t.Parallel()
+
+		ls := newMockLoadSaver()
+		a := mantaray.New()
+		for i := 0; i < 100; i++ {
+			p := fmt.Sprintf("img/%03d.png", i)
+			if err := a.Add(ctx, []byte(p), append(make([]byte, 32-len(p)), p...), nil, ls); err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+		}
+		if err := a.Save(ctx, ls); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		b := mantaray.NewNodeRef(a.Reference())
+		if err := b.Add(ctx, []byte("robots.txt"), make([]byte, 32), nil, ls); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if err := b.Save(ctx, ls); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		l := &countingLoader{Loader: ls}
+		var got []string
+		err := mantaray.Diff(ctx, mantaray.NewNodeRef(a.Reference()), mantaray.NewNodeRef(b.Reference()), l, func(path []byte, _, _ *mantaray.Node) error {
+			got = append(got, string(path))
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if fmt.Sprint(got) != "[robots.txt]" {
+			t.Fatalf("got %v, want [robots.txt]", got)
+		}
+		// only the two root nodes and the added node are loaded
+		if c := l.count.Load(); c != 3 {
+			t.Fatalf("got %d loaded nodes, want 3", c)
+		}
+	})
+}
@@ -105,7 +105,6 @@ func (n *Node) makeWithMetadata() {
 	n.nodeType = n.nodeType | nodeTypeWithMetadata
 }
 
-//nolint:unused
 func (n *Node) makeNotValue() {
 	n.nodeType = (nodeTypeMask ^ nodeTypeValue) & n.nodeType
 }
@@ -119,7 +118,6 @@ func (n *Node) makeNotWithPathSeparator() {
 	n.nodeType = (nodeTypeMask ^ nodeTypeWithPathSeparator) & n.nodeType
 }
 
-//nolint:unused
 func (n *Node) makeNotWithMetadata() {
 	n.nodeType = (nodeTypeMask ^ nodeTypeWithMetadata) & n.nodeType
 }
@@ -216,8 +214,9 @@ func (n *Node) Add(ctx context.Context, path, entry []byte, metadata map[string]
 		if err := n.load(ctx, ls); err != nil {
 			return err
 		}
-		n.ref = nil
 	}
+	// the node changes, so it has to be saved again
+	n.ref = nil
 	f := n.forks[path[0]]
 	if f == nil {
 		nn := New()
@@ -313,10 +312,29 @@ func (n *Node) Remove(ctx context.Context, path []byte, ls LoadSaver) error {
 	rest := path[len(f.prefix):]
 	if len(rest) == 0 {
 		// full path matched
-		delete(n.forks, path[0])
+		if f.Node.forks == nil {
+			if err := f.Node.load(ctx, ls); err != nil {
+				return err
+			}
+		}
+		if len(f.Node.forks) > 0 {
+			// keep the paths that continue the removed one
+			f.Node.entry = nil
+			f.Node.metadata = nil
+			f.Node.makeNotValue()
+			f.Node.makeNotWithMetadata()
+			f.Node.ref = nil
+		} else {
+			delete(n.forks, path[0])
+		}
+		n.ref = nil
 		return nil
 	}
-	return f.Node.Remove(ctx, rest, ls)
+	if err := f.Node.Remove(ctx, rest, ls); err != nil {
+		return err
+	}
+	n.ref = nil
+	return nil
 }
 
 func common(a, b []byte) (c []byte) {
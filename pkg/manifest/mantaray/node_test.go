@@ -262,6 +262,23 @@ func TestRemove(t *testing.T) {
 				[]byte("img/2/test1.png"),
 			},
 		},
+		{
+			name: "nested-value-is-kept",
+			toAdd: []mantaray.NodeEntry{
+				{
+					Path: []byte("index.html"),
+				},
+				{
+					Path: []byte("index.html.backup"),
+				},
+				{
+					Path: []byte("robots.txt"),
+				},
+			},
+			toRemove: [][]byte{
+				[]byte("index.html"),
+			},
+		},
 	} {
 		ctx := context.Background()
 		tc := tc
@@ -306,6 +323,25 @@ func TestRemove(t *testing.T) {
 				}
 			}
 
+			for i := 0; i < len(tc.toAdd); i++ {
+				d := tc.toAdd[i].Path
+				removed := false
+				for _, c := range tc.toRemove {
+					removed = removed || bytes.Equal(c, d)
+				}
+				if removed || len(tc.toAdd[i].Entry) > 0 {
+					continue
+				}
+				m, err := n.Lookup(ctx, d, nil)
+				if err != nil {
+					t.Fatalf("expected no error for %s, got %v", d, err)
+				}
+				de := append(make([]byte, 32-len(d)), d...)
+				if !bytes.Equal(m, de) {
+					t.Fatalf("expected value %x, got %x", de, m)
+				}
+			}
+
 		})
 	}
 }
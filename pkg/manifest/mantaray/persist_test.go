@@ -8,6 +8,7 @@ import (
 	"bytes"
 	"context"
 	"crypto/sha256"
+	"errors"
 	"sync"
 	"testing"
 
@@ -62,6 +63,63 @@ func TestPersistIdempotence(t *testing.T) {
 	}
 }
 
+func TestPersistAfterEdit(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	ls := newMockLoadSaver()
+
+	value := func(p []byte) []byte {
+		var v [32]byte
+		copy(v[:], p)
+		return v[:]
+	}
+
+	n := mantaray.New()
+	for _, p := range [][]byte{[]byte("img/1.png"), []byte("img/2.png"), []byte("index.html")} {
+		if err := n.Add(ctx, p, value(p), nil, ls); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+	}
+	if err := n.Save(ctx, ls); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	// load the forks on the edited paths before changing them
+	n2 := mantaray.NewNodeRef(n.Reference())
+	for _, p := range [][]byte{[]byte("img/1.png"), []byte("index.html")} {
+		if _, err := n2.Lookup(ctx, p, ls); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+	}
+	if err := n2.Add(ctx, []byte("img/3.png"), value([]byte("img/3.png")), nil, ls); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if err := n2.Remove(ctx, []byte("index.html"), ls); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if err := n2.Save(ctx, ls); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if bytes.Equal(n.Reference(), n2.Reference()) {
+		t.Fatal("expected the reference to change after the edit")
+	}
+
+	n3 := mantaray.NewNodeRef(n2.Reference())
+	for _, p := range [][]byte{[]byte("img/1.png"), []byte("img/2.png"), []byte("img/3.png")} {
+		v, err := n3.Lookup(ctx, p, ls)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if !bytes.Equal(v, value(p)) {
+			t.Fatalf("expected value %x, got %x", value(p), v)
+		}
+	}
+	if _, err := n3.Lookup(ctx, []byte("index.html"), ls); !errors.Is(err, mantaray.ErrNotFound) {
+		t.Fatalf("expected not found error, got %v", err)
+	}
+}
+
 type addr [32]byte
 type mockLoadSaver struct {
 	mtx   sync.Mutex
@@ -5,6 +5,7 @@
 package mantaray
 
 import (
+	"bytes"
 	"context"
 	"sort"
 )
@@ -62,3 +63,104 @@ func (n *Node) WalkNode(ctx context.Context, root []byte, l Loader, walkFn WalkN
 	}
 	return err
 }
+
+// walkAfter recursively descends path, calling walkFn for the nodes whose
+// path is after the given one. Forks holding only paths up to after are not
+// loaded. A nil after walks all the nodes.
+func walkAfter(ctx context.Context, path, after []byte, l Loader, n *Node, walkFn WalkNodeFunc) error {
+	if after == nil {
+		return walkNode(ctx, path, l, n, walkFn)
+	}
+
+	if n.forks == nil {
+		if err := n.load(ctx, l); err != nil {
+			return err
+		}
+	}
+
+	if bytes.Compare(path, after) > 0 {
+		if err := walkNodeFnCopyBytes(path, n, walkFn); err != nil {
+			return err
+		}
+	}
+
+	keys := make([]byte, 0, len(n.forks))
+	for k := range n.forks {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+
+	for _, k := range keys {
+		v := n.forks[k]
+		nextPath := append(path[:0:0], path...)
+		nextPath = append(nextPath, v.prefix...)
+
+		var err error
+		switch {
+		case bytes.HasPrefix(after, nextPath):
+			err = walkAfter(ctx, nextPath, after, l, v.Node, walkFn)
+		case bytes.Compare(nextPath, after) > 0:
+			// every path under the fork is after
+			err = walkNode(ctx, nextPath, l, v.Node, walkFn)
+		default:
+			// every path under the fork is before after
+			continue
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// walkPrefix descends only into the forks that lead to paths starting with
+// prefix and, if after is not nil, to paths after it.
+func walkPrefix(ctx context.Context, path, prefix, after []byte, l Loader, n *Node, walkFn WalkNodeFunc) error {
+	if len(prefix) == 0 {
+		return walkAfter(ctx, path, after, l, n, walkFn)
+	}
+
+	if n.forks == nil {
+		if err := n.load(ctx, l); err != nil {
+			return err
+		}
+	}
+
+	f := n.forks[prefix[0]]
+	if f == nil {
+		return nil
+	}
+	nextPath := append(path[:0:0], path...)
+	nextPath = append(nextPath, f.prefix...)
+
+	c := common(f.prefix, prefix)
+	switch {
+	case len(c) == len(prefix):
+		// every path under the fork starts with prefix
+		return walkAfter(ctx, nextPath, after, l, f.Node, walkFn)
+	case len(c) == len(f.prefix):
+		return walkPrefix(ctx, nextPath, prefix[len(c):], after, l, f.Node, walkFn)
+	default:
+		return nil
+	}
+}
+
+// WalkPrefix walks the node tree structure rooted at n, calling walkFn for
+// each node whose path starts with prefix. Forks that cannot contain such
+// paths are not loaded.
+func (n *Node) WalkPrefix(ctx context.Context, prefix []byte, l Loader, walkFn WalkNodeFunc) error {
+	return walkPrefix(ctx, []byte{}, prefix, nil, l, n, walkFn)
+}
+
+// WalkPrefixAfter walks the node tree structure rooted at n, calling walkFn
+// for each node whose path starts with prefix and is lexicographically after
+// the given path. Forks that cannot contain such paths are not loaded, so the
+// walk can resume after the last visited path without descending into the
+// parts of the tree visited before.
+func (n *Node) WalkPrefixAfter(ctx context.Context, prefix, after []byte, l Loader, walkFn WalkNodeFunc) error {
+	if after == nil {
+		after = []byte{}
+	}
+	return walkPrefix(ctx, []byte{}, prefix, after, l, n, walkFn)
+}
@@ -143,3 +143,176 @@ func TestWalkNode(t *testing.T) {
 		})
 	}
 }
+
+func TestWalkPrefix(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	toAdd := [][]byte{
+		[]byte("index.html.backup"),
+		[]byte("index.html"),
+		[]byte("img/test/oho.png"),
+		[]byte("img/test/old/test.png.backup"),
+		[]byte("img/test/old/test.png"),
+		[]byte("img/2.png"),
+		[]byte("img/1.png"),
+		[]byte("robots.txt"),
+	}
+
+	n := mantaray.New()
+	for _, c := range toAdd {
+		e := append(make([]byte, 32-len(c)), c...)
+		if err := n.Add(ctx, c, e, nil, nil); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+	}
+	ls := newMockLoadSaver()
+	if err := n.Save(ctx, ls); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []struct {
+		prefix   string
+		expected []string
+	}{
+		{
+			prefix:   "img/test/",
+			expected: []string{"img/test/o", "img/test/oho.png", "img/test/old/test.png", "img/test/old/test.png.backup"},
+		},
+		{
+			prefix:   "img/test/ol",
+			expected: []string{"img/test/old/test.png", "img/test/old/test.png.backup"},
+		},
+		{
+			prefix:   "index.html",
+			expected: []string{"index.html", "index.html.backup"},
+		},
+		{
+			prefix:   "img/3",
+			expected: nil,
+		},
+		{
+			prefix:   "robots.txt.backup",
+			expected: nil,
+		},
+	} {
+		tc := tc
+		t.Run(tc.prefix, func(t *testing.T) {
+			t.Parallel()
+
+			var walked []string
+			walker := func(path []byte, node *mantaray.Node, err error) error {
+				if err != nil {
+					return err
+				}
+				walked = append(walked, string(path))
+				return nil
+			}
+
+			err := mantaray.NewNodeRef(n.Reference()).WalkPrefix(ctx, []byte(tc.prefix), ls, walker)
+			if err != nil {
+				t.Fatalf("no error expected, found: %s", err)
+			}
+
+			if fmt.Sprint(walked) != fmt.Sprint(tc.expected) {
+				t.Fatalf("got paths %v, want %v", walked, tc.expected)
+			}
+		})
+	}
+}
+
+func TestWalkPrefixAfter(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	toAdd := [][]byte{
+		[]byte("index.html.backup"),
+		[]byte("index.html"),
+		[]byte("img/test/oho.png"),
+		[]byte("img/test/old/test.png.backup"),
+		[]byte("img/test/old/test.png"),
+		[]byte("img/2.png"),
+		[]byte("img/1.png"),
+		[]byte("robots.txt"),
+	}
+
+	n := mantaray.New()
+	for _, c := range toAdd {
+		e := append(make([]byte, 32-len(c)), c...)
+		if err := n.Add(ctx, c, e, nil, nil); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+	}
+	ls := newMockLoadSaver()
+	if err := n.Save(ctx, ls); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []struct {
+		name     string
+		prefix   string
+		after    string
+		expected []string
+	}{
+		{
+			name:     "all",
+			expected: []string{"i", "img/", "img/1.png", "img/2.png", "img/test/o", "img/test/oho.png", "img/test/old/test.png", "img/test/old/test.png.backup", "index.html", "index.html.backup", "robots.txt"},
+		},
+		{
+			name:     "after entry",
+			after:    "img/test/oho.png",
+			expected: []string{"img/test/old/test.png", "img/test/old/test.png.backup", "index.html", "index.html.backup", "robots.txt"},
+		},
+		{
+			name:     "after missing path",
+			after:    "img/3",
+			expected: []string{"img/test/o", "img/test/oho.png", "img/test/old/test.png", "img/test/old/test.png.backup", "index.html", "index.html.backup", "robots.txt"},
+		},
+		{
+			name:     "prefix after entry",
+			prefix:   "img/",
+			after:    "img/1.png",
+			expected: []string{"img/2.png", "img/test/o", "img/test/oho.png", "img/test/old/test.png", "img/test/old/test.png.backup"},
+		},
+		{
+			name:     "prefix after before prefix",
+			prefix:   "img/test/",
+			after:    "a",
+			expected: []string{"img/test/o", "img/test/oho.png", "img/test/old/test.png", "img/test/old/test.png.backup"},
+		},
+		{
+			name:     "prefix after past prefix",
+			prefix:   "img/",
+			after:    "index.html",
+			expected: nil,
+		},
+		{
+			name:     "after last entry",
+			after:    "robots.txt",
+			expected: nil,
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var walked []string
+			walker := func(path []byte, node *mantaray.Node, err error) error {
+				if err != nil {
+					return err
+				}
+				walked = append(walked, string(path))
+				return nil
+			}
+
+			err := mantaray.NewNodeRef(n.Reference()).WalkPrefixAfter(ctx, []byte(tc.prefix), []byte(tc.after), ls, walker)
+			if err != nil {
+				t.Fatalf("no error expected, found: %s", err)
+			}
+
+			if fmt.Sprint(walked) != fmt.Sprint(tc.expected) {
+				t.Fatalf("got paths %v, want %v", walked, tc.expected)
+			}
+		})
+	}
+}
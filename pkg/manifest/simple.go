@@ -8,6 +8,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/ethersphere/bee/v2/pkg/file"
 	"github.com/ethersphere/bee/v2/pkg/manifest/simple"
@@ -144,6 +146,54 @@ func (m *simpleManifest) IterateAddresses(ctx context.Context, fn swarm.AddressI
 	return nil
 }
 
+func (m *simpleManifest) IterateEntries(_ context.Context, prefix string, fn IterateEntryFunc) error {
+	type pathEntry struct {
+		path  string
+		entry Entry
+	}
+	var entries []pathEntry
+
+	walker := func(path string, entry simple.Entry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !strings.HasPrefix(path, prefix) {
+			return nil
+		}
+
+		ref, err := swarm.ParseHexAddress(entry.Reference())
+		if err != nil {
+			return err
+		}
+
+		entries = append(entries, pathEntry{path, NewEntry(ref, entry.Metadata())})
+		return nil
+	}
+
+	err := m.manifest.WalkEntry("", walker)
+	if err != nil {
+		return fmt.Errorf("manifest iterate entries: %w", err)
+	}
+
+	sort.Slice(entries, func(i, j int) bool { return entries[i].path < entries[j].path })
+	for _, e := range entries {
+		if err := fn(e.path, e.entry); err != nil {
+			return fmt.Errorf("manifest iterate entries: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (m *simpleManifest) IterateEntriesAfter(ctx context.Context, prefix, after string, fn IterateEntryFunc) error {
+	return m.IterateEntries(ctx, prefix, func(path string, entry Entry) error {
+		if path <= after {
+			return nil
+		}
+		return fn(path, entry)
+	})
+}
+
 func (m *simpleManifest) load(ctx context.Context, reference swarm.Address) error {
 	buf, err := m.ls.Load(ctx, reference.Bytes())
 	if err != nil {
@@ -20,6 +20,7 @@ import (
 	"github.com/ethersphere/bee/v2/pkg/addressbook"
 	"github.com/ethersphere/bee/v2/pkg/crypto"
 	"github.com/ethersphere/bee/v2/pkg/feeds"
+	"github.com/ethersphere/bee/v2/pkg/feeds/chain"
 	"github.com/ethersphere/bee/v2/pkg/feeds/factory"
 	"github.com/ethersphere/bee/v2/pkg/file"
 	"github.com/ethersphere/bee/v2/pkg/file/joiner"
@@ -164,7 +165,7 @@ func bootstrapNode(
 	pricing.SetPaymentThresholdObserver(acc)
 
 	localStore, err := storer.New(ctx, "", &storer.Options{
-		CacheCapacity: 1_000_000,
+		CacheCapacity: storer.DefaultCacheCapacity,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("local store creation: %w", err)
@@ -333,6 +334,9 @@ func getLatestSnapshot(
 		return nil, err
 	}
 
+	if *t == feeds.Chain {
+		return chain.GetWrappedChunk(ctx, st, u)
+	}
 	return feeds.GetWrappedChunk(ctx, st, u)
 }
 
@@ -27,6 +27,7 @@ import (
 	"github.com/ethersphere/bee/v2/pkg/log"
 	mockP2P "github.com/ethersphere/bee/v2/pkg/p2p/mock"
 	mockPingPong "github.com/ethersphere/bee/v2/pkg/pingpong/mock"
+	"github.com/ethersphere/bee/v2/pkg/pinner"
 	"github.com/ethersphere/bee/v2/pkg/postage"
 	"github.com/ethersphere/bee/v2/pkg/postage/batchstore"
 	mockPost "github.com/ethersphere/bee/v2/pkg/postage/mock"
@@ -67,6 +68,7 @@ type DevBee struct {
 	localstoreCloser    io.Closer
 	apiCloser           io.Closer
 	pssCloser           io.Closer
+	pinnerCloser        io.Closer
 	accesscontrolCloser io.Closer
 	errorLogWriter      io.Writer
 	apiServer           *http.Server
@@ -184,7 +186,7 @@ func NewDevBee(logger log.Logger, o *DevOptions) (b *DevBee, err error) {
 
 	localStore, err := storer.New(context.Background(), "", &storer.Options{
 		Logger:        logger,
-		CacheCapacity: 1_000_000,
+		CacheCapacity: storer.DefaultCacheCapacity,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("localstore: %w", err)
@@ -198,6 +200,12 @@ func NewDevBee(logger log.Logger, o *DevOptions) (b *DevBee, err error) {
 
 	pssService := pss.New(mockKey, logger)
 	b.pssCloser = pssService
+	if err := pssService.SetInboxStore(stateStore, pss.DefaultInboxCapacity, pss.DefaultInboxTTL); err != nil {
+		return nil, fmt.Errorf("pss inbox: %w", err)
+	}
+	if err := pssService.SetGroupStore(stateStore); err != nil {
+		return nil, fmt.Errorf("pss groups: %w", err)
+	}
 
 	pssService.SetPushSyncer(mockPushsync.New(func(ctx context.Context, chunk swarm.Chunk) (*pushsync.Receipt, error) {
 		pssService.TryUnwrap(chunk)
@@ -314,6 +322,12 @@ func NewDevBee(logger log.Logger, o *DevOptions) (b *DevBee, err error) {
 	mockResolver := resolverMock.NewResolver()
 	mockSteward := new(mockSteward.Steward)
 
+	pinnerService, err := pinner.New(localStore, logger)
+	if err != nil {
+		return nil, fmt.Errorf("pinner: %w", err)
+	}
+	b.pinnerCloser = pinnerService
+
 	mockStaking := stakingContractMock.New(
 		stakingContractMock.WithDepositStake(func(ctx context.Context, stakedAmount *big.Int) (common.Hash, error) {
 			return common.Hash{}, staking.ErrNotImplemented
@@ -348,6 +362,7 @@ func NewDevBee(logger log.Logger, o *DevOptions) (b *DevBee, err error) {
 		PostageContract: postageContract,
 		Staking:         mockStaking,
 		Steward:         mockSteward,
+		Pinner:          pinnerService,
 		SyncStatus:      syncStatusFn,
 	}
 
@@ -430,6 +445,7 @@ func (b *DevBee) Shutdown() error {
 	}
 
 	tryClose(b.pssCloser, "pss")
+	tryClose(b.pinnerCloser, "pinner")
 	tryClose(b.accesscontrolCloser, "accesscontrol")
 	tryClose(b.tracerCloser, "tracer")
 	tryClose(b.stateStoreCloser, "statestore")
@@ -29,6 +29,7 @@ import (
 	"github.com/ethersphere/bee/v2/pkg/accounting"
 	"github.com/ethersphere/bee/v2/pkg/addressbook"
 	"github.com/ethersphere/bee/v2/pkg/api"
+	"github.com/ethersphere/bee/v2/pkg/auth"
 	"github.com/ethersphere/bee/v2/pkg/config"
 	"github.com/ethersphere/bee/v2/pkg/crypto"
 	"github.com/ethersphere/bee/v2/pkg/feeds/factory"
@@ -39,6 +40,7 @@ import (
 	"github.com/ethersphere/bee/v2/pkg/p2p"
 	"github.com/ethersphere/bee/v2/pkg/p2p/libp2p"
 	"github.com/ethersphere/bee/v2/pkg/pingpong"
+	"github.com/ethersphere/bee/v2/pkg/pinner"
 	"github.com/ethersphere/bee/v2/pkg/postage"
 	"github.com/ethersphere/bee/v2/pkg/postage/batchservice"
 	"github.com/ethersphere/bee/v2/pkg/postage/batchstore"
@@ -105,6 +107,7 @@ type Bee struct {
 	pullSyncCloser           io.Closer
 	pssCloser                io.Closer
 	gsocCloser               io.Closer
+	pinnerCloser             io.Closer
 	ethClientCloser          func()
 	transactionMonitorCloser io.Closer
 	transactionCloser        io.Closer
@@ -125,6 +128,8 @@ type Bee struct {
 type Options struct {
 	DataDir                       string
 	CacheCapacity                 uint64
+	CacheEvictionPolicy           string
+	PinQuota                      uint64
 	DBOpenFilesLimit              uint64
 	DBWriteBufferSize             uint64
 	DBBlockCacheCapacity          uint64
@@ -175,6 +180,12 @@ type Options struct {
 	TrxDebugMode                  bool
 	MinimumStorageRadius          uint
 	ReserveCapacityDoubling       int
+	Restricted                    bool
+	AdminPasswordHash             string
+	PssInboxCapacity              int
+	PssInboxTTL                   time.Duration
+	NameRegistryTLD               string
+	NameRegistryOwner             string
 }
 
 const (
@@ -456,6 +467,14 @@ func NewBee(
 		apiService.Mount()
 		apiService.SetProbe(probe)
 
+		if o.Restricted {
+			authenticator, err := auth.New(signer, o.AdminPasswordHash, stateStore)
+			if err != nil {
+				return nil, fmt.Errorf("api authenticator: %w", err)
+			}
+			apiService.SetAuthenticator(authenticator)
+		}
+
 		apiService.SetSwarmAddress(&swarmAddress)
 
 		apiServer := &http.Server{
@@ -716,6 +735,8 @@ func NewBee(
 	lo := &storer.Options{
 		Address:                   swarmAddress,
 		CacheCapacity:             o.CacheCapacity,
+		CacheEvictionPolicy:       o.CacheEvictionPolicy,
+		PinQuota:                  o.PinQuota,
 		LdbOpenFilesLimit:         o.DBOpenFilesLimit,
 		LdbBlockCacheCapacity:     o.DBBlockCacheCapacity,
 		LdbWriteBufferSize:        o.DBWriteBufferSize,
@@ -900,6 +921,12 @@ func NewBee(
 	pricing.SetPaymentThresholdObserver(acc)
 
 	pssService := pss.New(pssPrivateKey, logger)
+	if err := pssService.SetInboxStore(stateStore, o.PssInboxCapacity, o.PssInboxTTL); err != nil {
+		return nil, fmt.Errorf("pss inbox: %w", err)
+	}
+	if err := pssService.SetGroupStore(stateStore); err != nil {
+		return nil, fmt.Errorf("pss groups: %w", err)
+	}
 	gsocService := gsoc.New(logger)
 	b.pssCloser = pssService
 	b.gsocCloser = gsocService
@@ -1099,16 +1126,33 @@ func NewBee(
 		}
 
 	}
-	multiResolver := multiresolver.NewMultiResolver(
+	resolverOpts := []multiresolver.Option{
 		multiresolver.WithConnectionConfigs(o.ResolverConnectionCfgs),
 		multiresolver.WithLogger(o.Logger),
 		multiresolver.WithDefaultCIDResolver(),
-	)
+	}
+	if o.NameRegistryTLD != "" {
+		registryOwner := overlayEthAddress
+		if o.NameRegistryOwner != "" {
+			if !common.IsHexAddress(o.NameRegistryOwner) {
+				return nil, fmt.Errorf("invalid name registry owner %q", o.NameRegistryOwner)
+			}
+			registryOwner = common.HexToAddress(o.NameRegistryOwner)
+		}
+		resolverOpts = append(resolverOpts, multiresolver.WithNameRegistry(o.NameRegistryTLD, localStore.Download(true), registryOwner))
+	}
+	multiResolver := multiresolver.NewMultiResolver(resolverOpts...)
 	b.resolverCloser = multiResolver
 
 	feedFactory := factory.New(localStore.Download(true))
 	steward := steward.New(localStore, retrieval, localStore.Cache())
 
+	pinnerService, err := pinner.New(localStore, logger)
+	if err != nil {
+		return nil, fmt.Errorf("pinner: %w", err)
+	}
+	b.pinnerCloser = pinnerService
+
 	extraOpts := api.ExtraOptions{
 		Pingpong:        pingPong,
 		TopologyDriver:  kad,
@@ -1128,6 +1172,7 @@ func NewBee(
 		PostageContract: postageStampContractService,
 		Staking:         stakingContract,
 		Steward:         steward,
+		Pinner:          pinnerService,
 		SyncStatus:      syncStatusFn,
 		NodeStatus:      nodeStatus,
 		PinIntegrity:    localStore.PinIntegrity(),
@@ -1257,11 +1302,15 @@ func (b *Bee) Shutdown() error {
 	}
 
 	var wg sync.WaitGroup
-	wg.Add(8)
+	wg.Add(9)
 	go func() {
 		defer wg.Done()
 		tryClose(b.pssCloser, "pss")
 	}()
+	go func() {
+		defer wg.Done()
+		tryClose(b.pinnerCloser, "pinner")
+	}()
 	go func() {
 		defer wg.Done()
 		tryClose(b.gsocCloser, "gsoc")
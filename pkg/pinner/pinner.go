@@ -0,0 +1,372 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package pinner pins the content of the network in background jobs. The
+// chunks collected by a job are kept across restarts in the pending collection
+// of the pinned root, so the jobs interrupted by a restart are resumed and the
+// failed jobs can be restarted without fetching the collected chunks again.
+package pinner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/ethersphere/bee/v2/pkg/log"
+	"github.com/ethersphere/bee/v2/pkg/storage"
+	storer "github.com/ethersphere/bee/v2/pkg/storer"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+	"github.com/ethersphere/bee/v2/pkg/traversal"
+	"go.uber.org/atomic"
+	"golang.org/x/sync/semaphore"
+)
+
+// loggerName is the tree path name of the logger for this package.
+const loggerName = "pinner"
+
+// concurrentFetches is the number of the chunks fetched at once by a job.
+const concurrentFetches = 100
+
+var (
+	// ErrNotFound is returned when there is no job of the reference.
+	ErrNotFound = errors.New("pin job not found")
+	// ErrPinned is returned when the reference is already pinned.
+	ErrPinned = errors.New("reference already pinned")
+	// ErrCancelling is returned when the job of the reference is being
+	// canceled and the chunks it collected are not removed yet.
+	ErrCancelling = errors.New("pin job is being canceled")
+)
+
+// State is the state of a job.
+type State string
+
+const (
+	StateRunning    State = "running"
+	StateDone       State = "done"
+	StateFailed     State = "failed"
+	StateCancelling State = "cancelling"
+)
+
+// Job describes the progress of the pinning of a reference. The reference
+// identifies the job, so there is at most one job of a reference.
+type Job struct {
+	Reference swarm.Address
+	Label     string
+	State     State
+	// Fetched is the number of the chunks stored by the job.
+	Fetched uint64
+	// Remaining is the number of the chunks found by the traversal and not
+	// stored yet, the chunks of the content not traversed yet are unknown.
+	Remaining uint64
+	// Err is the error the job failed with.
+	Err error
+}
+
+// Storer is the storage of the pinned content.
+type Storer interface {
+	storer.PinStore
+	Download(cache bool) storage.Getter
+	Cache() storage.Putter
+}
+
+type Interface interface {
+	// Pin starts the job pinning the reference, or returns the job of the
+	// reference if it is running. The failed job of the reference is
+	// restarted, keeping the chunks it has already collected. ErrCancelling
+	// is returned while the job of the reference is being canceled.
+	Pin(root swarm.Address, label string, ttl time.Duration) (Job, error)
+	// Jobs returns the jobs sorted by the reference.
+	Jobs() []Job
+	// Job returns the job of the reference.
+	Job(root swarm.Address) (Job, error)
+	// Cancel stops the job of the reference and removes the chunks it
+	// collected. The finished job is only forgotten.
+	Cancel(root swarm.Address) error
+}
+
+var _ Interface = (*Service)(nil)
+
+// Service runs the pinning jobs.
+type Service struct {
+	store  Storer
+	logger log.Logger
+
+	mu   sync.Mutex
+	jobs map[string]*job
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// New returns a new Service and resumes the jobs of the pending collections.
+func New(st Storer, logger log.Logger) (*Service, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	s := &Service{
+		store:  st,
+		logger: logger.WithName(loggerName).Register(),
+		jobs:   make(map[string]*job),
+		ctx:    ctx,
+		cancel: cancel,
+	}
+
+	pending, err := st.PendingPins()
+	if err != nil {
+		cancel()
+		return nil, fmt.Errorf("pending pins: %w", err)
+	}
+	s.mu.Lock()
+	for _, p := range pending {
+		s.logger.Debug("resuming pin job", "reference", p.Reference, "chunks", p.Chunks)
+		s.start(p.Reference, p.Label, 0)
+	}
+	s.mu.Unlock()
+
+	return s, nil
+}
+
+// Pin implements the Interface interface.
+func (s *Service) Pin(root swarm.Address, label string, ttl time.Duration) (Job, error) {
+	has, err := s.store.HasPin(root)
+	if err != nil {
+		return Job{}, err
+	}
+	if has {
+		return Job{}, ErrPinned
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if j, ok := s.jobs[root.ByteString()]; ok {
+		switch job := j.job(); job.State {
+		case StateRunning:
+			return job, nil
+		case StateCancelling:
+			// the new job would resume the collection removed by the cleanup
+			return Job{}, ErrCancelling
+		}
+	}
+	return s.start(root, label, ttl).job(), nil
+}
+
+// Jobs implements the Interface interface.
+func (s *Service) Jobs() []Job {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	jobs := make([]Job, 0, len(s.jobs))
+	for _, j := range s.jobs {
+		jobs = append(jobs, j.job())
+	}
+	slices.SortFunc(jobs, func(a, b Job) int {
+		return a.Reference.Compare(b.Reference)
+	})
+	return jobs
+}
+
+// Job implements the Interface interface.
+func (s *Service) Job(root swarm.Address) (Job, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	j, ok := s.jobs[root.ByteString()]
+	if !ok {
+		return Job{}, ErrNotFound
+	}
+	return j.job(), nil
+}
+
+// Cancel implements the Interface interface. The job is kept in the
+// cancelling state until the chunks it collected are removed.
+func (s *Service) Cancel(root swarm.Address) error {
+	s.mu.Lock()
+	j, ok := s.jobs[root.ByteString()]
+	if !ok {
+		s.mu.Unlock()
+		return ErrNotFound
+	}
+	if !j.setCancelling() {
+		s.mu.Unlock()
+		return ErrCancelling
+	}
+	s.mu.Unlock()
+
+	j.cancel()
+	<-j.done
+
+	var err error
+	if j.state != StateDone && j.session != nil {
+		err = j.session.Cleanup()
+	}
+
+	s.mu.Lock()
+	delete(s.jobs, root.ByteString())
+	s.mu.Unlock()
+
+	return err
+}
+
+// Close stops the jobs, the chunks they collected are kept to resume them.
+func (s *Service) Close() error {
+	s.cancel()
+	s.wg.Wait()
+	return nil
+}
+
+// start starts the job pinning the reference.
+// It MUST be called with the mu held.
+func (s *Service) start(root swarm.Address, label string, ttl time.Duration) *job {
+	ctx, cancel := context.WithCancel(s.ctx)
+	j := &job{
+		reference: root,
+		label:     label,
+		state:     StateRunning,
+		cancel:    cancel,
+		done:      make(chan struct{}),
+	}
+
+	s.jobs[root.ByteString()] = j
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		defer close(j.done)
+
+		err := s.run(ctx, j, ttl)
+		if err != nil && !errors.Is(err, context.Canceled) {
+			s.logger.Debug("pin job failed", "reference", root, "error", err)
+		}
+		j.finish(err)
+	}()
+
+	return j
+}
+
+// run pins the content of the job.
+func (s *Service) run(ctx context.Context, j *job, ttl time.Duration) error {
+	session, err := s.store.PendingCollection(ctx, j.reference, j.label, ttl)
+	if err != nil {
+		return fmt.Errorf("pending collection: %w", err)
+	}
+	j.session = session
+
+	var (
+		getter    = s.store.Download(true)
+		traverser = traversal.New(getter, s.store.Cache())
+		sem       = semaphore.NewWeighted(concurrentFetches)
+		mu        sync.Mutex
+		errFetch  error
+		wg        sync.WaitGroup
+	)
+
+	err = traverser.Traverse(ctx, j.reference, func(address swarm.Address) error {
+		mu.Lock()
+		err := errFetch
+		mu.Unlock()
+		if err != nil {
+			return err
+		}
+		if err := sem.Acquire(ctx, 1); err != nil {
+			return err
+		}
+		j.discovered.Inc()
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer sem.Release(1)
+
+			err := func() error {
+				ch, err := getter.Get(ctx, address)
+				if err != nil {
+					return err
+				}
+				return session.Put(ctx, ch)
+			}()
+			if err != nil {
+				mu.Lock()
+				errFetch = errors.Join(errFetch, err)
+				mu.Unlock()
+				return
+			}
+			j.fetched.Inc()
+		}()
+		return nil
+	})
+	wg.Wait()
+
+	if err := errors.Join(err, errFetch); err != nil {
+		return err
+	}
+
+	err = session.Done(j.reference)
+	if err != nil {
+		return errors.Join(err, session.Cleanup())
+	}
+	return nil
+}
+
+type job struct {
+	reference  swarm.Address
+	label      string
+	session    storer.PutterSession
+	fetched    atomic.Uint64
+	discovered atomic.Uint64
+	cancel     context.CancelFunc
+	done       chan struct{}
+
+	mu         sync.Mutex
+	state      State
+	err        error
+	cancelling bool
+}
+
+// setCancelling marks the job as being canceled, it reports false if the job
+// is already being canceled.
+func (j *job) setCancelling() bool {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if j.cancelling {
+		return false
+	}
+	j.cancelling = true
+	return true
+}
+
+// finish records the result of the job.
+func (j *job) finish(err error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if err != nil {
+		j.state, j.err = StateFailed, err
+		return
+	}
+	j.state = StateDone
+}
+
+// job returns the description of the job.
+func (j *job) job() Job {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	state := j.state
+	if j.cancelling {
+		state = StateCancelling
+	}
+
+	fetched := j.fetched.Load()
+	return Job{
+		Reference: j.reference,
+		Label:     j.label,
+		State:     state,
+		Fetched:   fetched,
+		Remaining: j.discovered.Load() - min(fetched, j.discovered.Load()),
+		Err:       j.err,
+	}
+}
@@ -0,0 +1,275 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package pinner_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ethersphere/bee/v2/pkg/file/pipeline/builder"
+	"github.com/ethersphere/bee/v2/pkg/file/redundancy"
+	"github.com/ethersphere/bee/v2/pkg/log"
+	"github.com/ethersphere/bee/v2/pkg/pinner"
+	"github.com/ethersphere/bee/v2/pkg/spinlock"
+	"github.com/ethersphere/bee/v2/pkg/storage"
+	"github.com/ethersphere/bee/v2/pkg/storage/inmemchunkstore"
+	"github.com/ethersphere/bee/v2/pkg/storer"
+	mockstorer "github.com/ethersphere/bee/v2/pkg/storer/mock"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+	"github.com/ethersphere/bee/v2/pkg/util/testutil"
+)
+
+// upload stores the random data in the chunk store and returns its reference
+// and chunks.
+func upload(t *testing.T, cs storage.ChunkStore, size int) (swarm.Address, []swarm.Chunk) {
+	t.Helper()
+
+	ctx := redundancy.SetLevelInContext(context.Background(), redundancy.NONE)
+	data := make([]byte, size)
+	if _, err := rand.Read(data); err != nil {
+		t.Fatal(err)
+	}
+	src := inmemchunkstore.New()
+	addr, err := builder.FeedPipeline(ctx, builder.NewPipelineBuilder(ctx, src, false, 0), bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var chunks []swarm.Chunk
+	err = src.Iterate(ctx, func(ch swarm.Chunk) (bool, error) {
+		chunks = append(chunks, ch)
+		return false, cs.Put(ctx, ch)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return addr, chunks
+}
+
+// waitState waits for the job of the reference to reach the state.
+func waitState(t *testing.T, p *pinner.Service, root swarm.Address, state pinner.State) pinner.Job {
+	t.Helper()
+
+	var job pinner.Job
+	err := spinlock.Wait(5*time.Second, func() bool {
+		j, err := p.Job(root)
+		job = j
+		return err == nil && j.State == state
+	})
+	if err != nil {
+		t.Fatalf("job state %s, want %s", job.State, state)
+	}
+	return job
+}
+
+func TestPin(t *testing.T) {
+	t.Parallel()
+
+	cs := inmemchunkstore.New()
+	st := mockstorer.NewWithChunkStore(cs)
+	root, chunks := upload(t, cs, 20*swarm.ChunkSize)
+
+	p, err := pinner.New(st, log.Noop)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testutil.CleanupCloser(t, p)
+
+	if _, err := p.Pin(root, "label", 0); err != nil {
+		t.Fatal(err)
+	}
+	job := waitState(t, p, root, pinner.StateDone)
+	if job.Fetched != uint64(len(chunks)) || job.Remaining != 0 || job.Label != "label" {
+		t.Fatalf("unexpected job %+v", job)
+	}
+
+	if has, err := st.HasPin(root); err != nil || !has {
+		t.Fatalf("pin not found: %v", err)
+	}
+	if _, err := p.Pin(root, "label", 0); !errors.Is(err, pinner.ErrPinned) {
+		t.Fatalf("got error %v, want %v", err, pinner.ErrPinned)
+	}
+	if jobs := p.Jobs(); len(jobs) != 1 || !jobs[0].Reference.Equal(root) {
+		t.Fatalf("unexpected jobs %+v", jobs)
+	}
+
+	if err := p.Cancel(root); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := p.Job(root); !errors.Is(err, pinner.ErrNotFound) {
+		t.Fatalf("got error %v, want %v", err, pinner.ErrNotFound)
+	}
+	if has, err := st.HasPin(root); err != nil || !has {
+		t.Fatalf("pin of the forgotten job not found: %v", err)
+	}
+}
+
+func TestRestartFailed(t *testing.T) {
+	t.Parallel()
+
+	src := inmemchunkstore.New()
+	cs := inmemchunkstore.New()
+	st := mockstorer.NewWithChunkStore(cs)
+	root, chunks := upload(t, src, 20*swarm.ChunkSize)
+
+	// the chunks of the data are missing except the root
+	rootChunk, err := src.Get(context.Background(), root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := cs.Put(context.Background(), rootChunk); err != nil {
+		t.Fatal(err)
+	}
+
+	p, err := pinner.New(st, log.Noop)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testutil.CleanupCloser(t, p)
+
+	if _, err := p.Pin(root, "", 0); err != nil {
+		t.Fatal(err)
+	}
+	if job := waitState(t, p, root, pinner.StateFailed); !errors.Is(job.Err, storage.ErrNotFound) {
+		t.Fatalf("got error %v, want %v", job.Err, storage.ErrNotFound)
+	}
+	if pending, err := st.PendingPins(); err != nil || len(pending) != 1 {
+		t.Fatalf("unexpected pending pins %+v: %v", pending, err)
+	}
+
+	for _, ch := range chunks {
+		if err := cs.Put(context.Background(), ch); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := p.Pin(root, "", 0); err != nil {
+		t.Fatal(err)
+	}
+	waitState(t, p, root, pinner.StateDone)
+
+	if has, err := st.HasPin(root); err != nil || !has {
+		t.Fatalf("pin not found: %v", err)
+	}
+	if pending, err := st.PendingPins(); err != nil || len(pending) != 0 {
+		t.Fatalf("unexpected pending pins %+v: %v", pending, err)
+	}
+}
+
+func TestResume(t *testing.T) {
+	t.Parallel()
+
+	cs := inmemchunkstore.New()
+	st := mockstorer.NewWithChunkStore(cs)
+	root, _ := upload(t, cs, 5*swarm.ChunkSize)
+
+	if _, err := st.PendingCollection(context.Background(), root, "resumed", 0); err != nil {
+		t.Fatal(err)
+	}
+
+	p, err := pinner.New(st, log.Noop)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testutil.CleanupCloser(t, p)
+
+	if job := waitState(t, p, root, pinner.StateDone); job.Label != "resumed" {
+		t.Fatalf("unexpected job %+v", job)
+	}
+	if has, err := st.HasPin(root); err != nil || !has {
+		t.Fatalf("pin not found: %v", err)
+	}
+}
+
+func TestCancel(t *testing.T) {
+	t.Parallel()
+
+	st := mockstorer.New()
+	root := swarm.RandAddress(t)
+
+	p, err := pinner.New(st, log.Noop)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testutil.CleanupCloser(t, p)
+
+	if _, err := p.Pin(root, "", 0); err != nil {
+		t.Fatal(err)
+	}
+	waitState(t, p, root, pinner.StateFailed)
+
+	if err := p.Cancel(root); err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Cancel(root); !errors.Is(err, pinner.ErrNotFound) {
+		t.Fatalf("got error %v, want %v", err, pinner.ErrNotFound)
+	}
+	if pending, err := st.PendingPins(); err != nil || len(pending) != 0 {
+		t.Fatalf("unexpected pending pins %+v: %v", pending, err)
+	}
+}
+
+// blockingStorer returns the sessions whose cleanup waits for the release.
+type blockingStorer struct {
+	pinner.Storer
+	release chan struct{}
+}
+
+func (b blockingStorer) PendingCollection(ctx context.Context, root swarm.Address, label string, ttl time.Duration) (storer.PutterSession, error) {
+	session, err := b.Storer.PendingCollection(ctx, root, label, ttl)
+	if err != nil {
+		return nil, err
+	}
+	return blockingSession{session, b.release}, nil
+}
+
+type blockingSession struct {
+	storer.PutterSession
+	release chan struct{}
+}
+
+func (b blockingSession) Cleanup() error {
+	<-b.release
+	return b.PutterSession.Cleanup()
+}
+
+func TestPinWhileCancelling(t *testing.T) {
+	t.Parallel()
+
+	st := blockingStorer{mockstorer.New(), make(chan struct{})}
+	root := swarm.RandAddress(t)
+
+	p, err := pinner.New(st, log.Noop)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testutil.CleanupCloser(t, p)
+
+	if _, err := p.Pin(root, "", 0); err != nil {
+		t.Fatal(err)
+	}
+	waitState(t, p, root, pinner.StateFailed)
+
+	cancelled := make(chan error, 1)
+	go func() { cancelled <- p.Cancel(root) }()
+	waitState(t, p, root, pinner.StateCancelling)
+
+	if _, err := p.Pin(root, "", 0); !errors.Is(err, pinner.ErrCancelling) {
+		t.Fatalf("got error %v, want %v", err, pinner.ErrCancelling)
+	}
+	if err := p.Cancel(root); !errors.Is(err, pinner.ErrCancelling) {
+		t.Fatalf("got error %v, want %v", err, pinner.ErrCancelling)
+	}
+
+	close(st.release)
+	if err := <-cancelled; err != nil {
+		t.Fatal(err)
+	}
+	if _, err := p.Job(root); !errors.Is(err, pinner.ErrNotFound) {
+		t.Fatalf("got error %v, want %v", err, pinner.ErrNotFound)
+	}
+}
@@ -4,6 +4,16 @@
 
 package pss
 
+import "time"
+
 var (
 	Contains = contains
 )
+
+// SetRetryInterval sets the interval of the reliable message retries and
+// returns the function restoring it.
+func SetRetryInterval(d time.Duration) func() {
+	old := retryInterval
+	retryInterval = d
+	return func() { retryInterval = old }
+}
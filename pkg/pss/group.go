@@ -0,0 +1,505 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package pss
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/ethersphere/bee/v2/pkg/crypto"
+	"github.com/ethersphere/bee/v2/pkg/encryption"
+	"github.com/ethersphere/bee/v2/pkg/postage"
+	"github.com/ethersphere/bee/v2/pkg/storage"
+)
+
+var (
+	// ErrGroupNotFound is returned if the node neither owns nor joined the group.
+	ErrGroupNotFound = errors.New("pss: group not found")
+	// ErrGroupExists is returned on creating or joining a group for a topic that already has one.
+	ErrGroupExists = errors.New("pss: group already exists")
+	// ErrNotGroupOwner is returned if the members of a group are changed by a node that does not own it.
+	ErrNotGroupOwner = errors.New("pss: not the owner of the group")
+	// ErrNoGroupKey is returned on sending to a joined group whose key has not been received yet.
+	ErrNoGroupKey = errors.New("pss: group key not received")
+	// ErrGroupTooBig is returned if the members of a group do not fit in a key message.
+	ErrGroupTooBig = errors.New("pss: too many group members")
+)
+
+// groupKeyTopic is the topic of the messages that distribute the group keys to the members.
+var groupKeyTopic = NewTopic("pss-group-key")
+
+const (
+	signatureSize     = 65
+	groupKeyHeaderLen = signatureSize + len(Topic{}) + 8 + encryption.KeyLength
+	publicKeySize     = 33
+
+	groupKeyPrefix = "pss-group-"
+)
+
+// Member is a member of a group and the targets of its neighbourhood.
+type Member struct {
+	PublicKey *ecdsa.PublicKey
+	Targets   Targets
+}
+
+// Group is a set of members sharing a symmetric key for a topic. The messages
+// of the group are encrypted once for the key instead of once for every member.
+// Every change of the members rotates the key, so the removed members cannot
+// read the later messages.
+type Group struct {
+	Topic   Topic
+	Owner   *ecdsa.PublicKey
+	Epoch   uint64
+	Members []Member
+
+	key []byte
+}
+
+// Groups manages the groups of the node.
+type Groups interface {
+	// SetGroupStore restores the groups persisted in the store and persists
+	// the later changes of the groups in it.
+	SetGroupStore(store storage.StateStorer) error
+	// CreateGroup creates a group owned by the node and sends its key to the members.
+	CreateGroup(ctx context.Context, topic Topic, stamper postage.Stamper, members []Member) (*Group, error)
+	// UpdateGroup changes the members of an owned group and sends the rotated key to the members.
+	UpdateGroup(ctx context.Context, topic Topic, stamper postage.Stamper, add []Member, remove []*ecdsa.PublicKey) (*Group, error)
+	// JoinGroup accepts the keys of the group for the topic sent by the owner.
+	JoinGroup(topic Topic, owner *ecdsa.PublicKey) error
+	// LeaveGroup forgets the group for the topic.
+	LeaveGroup(topic Topic) error
+	// Group returns the group for the topic.
+	Group(topic Topic) (*Group, error)
+	// SendGroup sends the payload encrypted with the group key to the neighbourhoods of the members.
+	SendGroup(ctx context.Context, topic Topic, payload []byte, stamper postage.Stamper) error
+}
+
+func groupKey(topic Topic) string {
+	return groupKeyPrefix + hex.EncodeToString(topic[:])
+}
+
+// groupMember is the persisted form of a member of a group.
+type groupMember struct {
+	PublicKey []byte   `json:"publicKey"`
+	Targets   [][]byte `json:"targets"`
+}
+
+// groupItem is the persisted form of a group.
+type groupItem struct {
+	Topic   []byte        `json:"topic"`
+	Owner   []byte        `json:"owner"`
+	Epoch   uint64        `json:"epoch"`
+	Key     []byte        `json:"key,omitempty"`
+	Members []groupMember `json:"members"`
+}
+
+func (g *groupItem) MarshalBinary() ([]byte, error) {
+	return json.Marshal(g)
+}
+
+func (g *groupItem) UnmarshalBinary(data []byte) error {
+	return json.Unmarshal(data, g)
+}
+
+func newGroupItem(g *Group) *groupItem {
+	item := &groupItem{
+		Topic:   g.Topic[:],
+		Owner:   crypto.EncodeSecp256k1PublicKey(g.Owner),
+		Epoch:   g.Epoch,
+		Key:     g.key,
+		Members: make([]groupMember, 0, len(g.Members)),
+	}
+	for _, m := range g.Members {
+		gm := groupMember{PublicKey: crypto.EncodeSecp256k1PublicKey(m.PublicKey)}
+		for _, t := range m.Targets {
+			gm.Targets = append(gm.Targets, []byte(t))
+		}
+		item.Members = append(item.Members, gm)
+	}
+	return item
+}
+
+func (g *groupItem) group() (*Group, error) {
+	owner, err := btcec.ParsePubKey(g.Owner)
+	if err != nil {
+		return nil, fmt.Errorf("owner: %w", err)
+	}
+	if len(g.Topic) != len(Topic{}) {
+		return nil, errors.New("invalid topic")
+	}
+	group := &Group{
+		Owner: owner.ToECDSA(),
+		Epoch: g.Epoch,
+		key:   g.Key,
+	}
+	copy(group.Topic[:], g.Topic)
+	for _, gm := range g.Members {
+		pub, err := btcec.ParsePubKey(gm.PublicKey)
+		if err != nil {
+			return nil, fmt.Errorf("member: %w", err)
+		}
+		m := Member{PublicKey: pub.ToECDSA()}
+		for _, t := range gm.Targets {
+			m.Targets = append(m.Targets, Target(t))
+		}
+		group.Members = append(group.Members, m)
+	}
+	return group, nil
+}
+
+func (p *pss) SetGroupStore(store storage.StateStorer) error {
+	groups := make(map[Topic]*Group)
+	err := store.Iterate(groupKeyPrefix, func(key, val []byte) (bool, error) {
+		var item groupItem
+		if err := item.UnmarshalBinary(val); err != nil {
+			return true, fmt.Errorf("invalid group %q: %w", key, err)
+		}
+		g, err := item.group()
+		if err != nil {
+			return true, fmt.Errorf("invalid group %q: %w", key, err)
+		}
+		groups[g.Topic] = g
+		return false, nil
+	})
+	if err != nil {
+		return fmt.Errorf("pss: load groups: %w", err)
+	}
+
+	p.groupsMu.Lock()
+	defer p.groupsMu.Unlock()
+
+	for t, g := range groups {
+		if _, ok := p.groups[t]; !ok {
+			p.groups[t] = g
+		}
+	}
+	p.groupStore = store
+	return nil
+}
+
+// putGroup stores the group and persists it if the node has a group store.
+// It must be called with the groups lock held.
+func (p *pss) putGroup(g *Group) error {
+	if p.groupStore != nil {
+		if err := p.groupStore.Put(groupKey(g.Topic), newGroupItem(g)); err != nil {
+			return fmt.Errorf("pss: store group: %w", err)
+		}
+	}
+	p.groups[g.Topic] = g
+	return nil
+}
+
+func (p *pss) CreateGroup(ctx context.Context, topic Topic, stamper postage.Stamper, members []Member) (*Group, error) {
+	if p.key == nil {
+		return nil, ErrNotGroupOwner
+	}
+	if _, err := p.Group(topic); err == nil {
+		return nil, ErrGroupExists
+	}
+
+	g := &Group{
+		Topic: topic,
+		Owner: &p.key.PublicKey,
+	}
+	if err := p.rotateGroupKey(ctx, g, stamper, members); err != nil {
+		return nil, err
+	}
+
+	p.groupsMu.Lock()
+	defer p.groupsMu.Unlock()
+
+	if _, ok := p.groups[topic]; ok {
+		return nil, ErrGroupExists
+	}
+	if err := p.putGroup(g); err != nil {
+		return nil, err
+	}
+	return g.clone(), nil
+}
+
+func (p *pss) UpdateGroup(ctx context.Context, topic Topic, stamper postage.Stamper, add []Member, remove []*ecdsa.PublicKey) (*Group, error) {
+	g, err := p.Group(topic)
+	if err != nil {
+		return nil, err
+	}
+	if p.key == nil || !samePublicKey(g.Owner, &p.key.PublicKey) {
+		return nil, ErrNotGroupOwner
+	}
+
+	members := make([]Member, 0, len(g.Members)+len(add))
+	for _, m := range g.Members {
+		if !containsMember(add, m.PublicKey) && !containsKey(remove, m.PublicKey) {
+			members = append(members, m)
+		}
+	}
+	for _, m := range add {
+		if !containsKey(remove, m.PublicKey) {
+			members = append(members, m)
+		}
+	}
+
+	ng := &Group{
+		Topic: g.Topic,
+		Owner: g.Owner,
+		Epoch: g.Epoch + 1,
+	}
+	if err := p.rotateGroupKey(ctx, ng, stamper, members); err != nil {
+		return nil, err
+	}
+
+	p.groupsMu.Lock()
+	defer p.groupsMu.Unlock()
+
+	// the group was left or updated concurrently
+	if cur, ok := p.groups[topic]; !ok || cur.Epoch != g.Epoch {
+		return nil, ErrGroupNotFound
+	}
+	if err := p.putGroup(ng); err != nil {
+		return nil, err
+	}
+	return ng.clone(), nil
+}
+
+func (p *pss) JoinGroup(topic Topic, owner *ecdsa.PublicKey) error {
+	p.groupsMu.Lock()
+	defer p.groupsMu.Unlock()
+
+	if _, ok := p.groups[topic]; ok {
+		return ErrGroupExists
+	}
+	return p.putGroup(&Group{
+		Topic: topic,
+		Owner: owner,
+	})
+}
+
+func (p *pss) LeaveGroup(topic Topic) error {
+	p.groupsMu.Lock()
+	defer p.groupsMu.Unlock()
+
+	if _, ok := p.groups[topic]; !ok {
+		return ErrGroupNotFound
+	}
+	if p.groupStore != nil {
+		if err := p.groupStore.Delete(groupKey(topic)); err != nil {
+			return fmt.Errorf("pss: delete group: %w", err)
+		}
+	}
+	delete(p.groups, topic)
+	return nil
+}
+
+func (p *pss) Group(topic Topic) (*Group, error) {
+	p.groupsMu.Lock()
+	defer p.groupsMu.Unlock()
+
+	g, ok := p.groups[topic]
+	if !ok {
+		return nil, ErrGroupNotFound
+	}
+	return g.clone(), nil
+}
+
+func (p *pss) SendGroup(ctx context.Context, topic Topic, payload []byte, stamper postage.Stamper) error {
+	g, err := p.Group(topic)
+	if err != nil {
+		return err
+	}
+	if g.key == nil {
+		return ErrNoGroupKey
+	}
+	recipient := &crypto.Secp256k1PrivateKeyFromBytes(g.key).PublicKey
+
+	// the members in the same neighbourhood are reached with one message
+	sent := make(map[string]struct{})
+	for _, m := range g.Members {
+		k := string(joinTargets(m.Targets))
+		if _, ok := sent[k]; ok {
+			continue
+		}
+		sent[k] = struct{}{}
+		if err := p.Send(ctx, topic, payload, stamper, recipient, m.Targets); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// rotateGroupKey generates a new key for the group and sends it to the members.
+func (p *pss) rotateGroupKey(ctx context.Context, g *Group, stamper postage.Stamper, members []Member) error {
+	g.key = encryption.GenerateRandomKey(encryption.KeyLength)
+	g.Members = members
+
+	msg, err := p.marshalGroupKey(g)
+	if err != nil {
+		return err
+	}
+	for _, m := range members {
+		if samePublicKey(m.PublicKey, &p.key.PublicKey) {
+			continue
+		}
+		if err := p.Send(ctx, groupKeyTopic, msg, stamper, m.PublicKey, m.Targets); err != nil {
+			return fmt.Errorf("send group key: %w", err)
+		}
+	}
+	return nil
+}
+
+// marshalGroupKey serialises the key message of the group signed by the owner
+// - signature
+// - topic
+// - epoch
+// - key
+// - members: public key, number of targets, target length, targets
+func (p *pss) marshalGroupKey(g *Group) ([]byte, error) {
+	data := make([]byte, 0, MaxPayloadSize)
+	data = append(data, g.Topic[:]...)
+	data = binary.BigEndian.AppendUint64(data, g.Epoch)
+	data = append(data, g.key...)
+	for _, m := range g.Members {
+		if err := checkTargets(m.Targets); err != nil {
+			return nil, err
+		}
+		if len(m.Targets) > 255 || len(m.Targets[0]) > 255 {
+			return nil, ErrGroupTooBig
+		}
+		data = append(data, crypto.EncodeSecp256k1PublicKey(m.PublicKey)...)
+		data = append(data, byte(len(m.Targets)), byte(len(m.Targets[0])))
+		data = append(data, joinTargets(m.Targets)...)
+	}
+	if signatureSize+len(data) > MaxPayloadSize {
+		return nil, ErrGroupTooBig
+	}
+
+	sig, err := crypto.NewDefaultSigner(p.key).Sign(data)
+	if err != nil {
+		return nil, err
+	}
+	return append(sig, data...), nil
+}
+
+// unmarshalGroupKey verifies and deserialises the key message of a group.
+func unmarshalGroupKey(msg []byte) (*Group, error) {
+	if len(msg) < groupKeyHeaderLen {
+		return nil, errors.New("invalid group key message")
+	}
+	sig, data := msg[:signatureSize], msg[signatureSize:]
+	owner, err := crypto.Recover(sig, data)
+	if err != nil {
+		return nil, err
+	}
+
+	g := &Group{Owner: owner}
+	copy(g.Topic[:], data)
+	data = data[len(g.Topic):]
+	g.Epoch = binary.BigEndian.Uint64(data)
+	data = data[8:]
+	g.key = append([]byte(nil), data[:encryption.KeyLength]...)
+	data = data[encryption.KeyLength:]
+
+	for len(data) > 0 {
+		if len(data) < publicKeySize+2 {
+			return nil, errors.New("invalid group member")
+		}
+		pub, err := btcec.ParsePubKey(data[:publicKeySize])
+		if err != nil {
+			return nil, err
+		}
+		n, l := int(data[publicKeySize]), int(data[publicKeySize+1])
+		data = data[publicKeySize+2:]
+		if n == 0 || len(data) < n*l {
+			return nil, errors.New("invalid group member targets")
+		}
+		m := Member{PublicKey: pub.ToECDSA()}
+		for i := 0; i < n; i++ {
+			m.Targets = append(m.Targets, Target(data[i*l:(i+1)*l]))
+		}
+		data = data[n*l:]
+		g.Members = append(g.Members, m)
+	}
+	return g, nil
+}
+
+// handleGroupKey updates the joined group with the key received from its owner.
+func (p *pss) handleGroupKey(msg []byte) {
+	k, err := unmarshalGroupKey(msg)
+	if err != nil {
+		p.logger.Debug("invalid group key message", "error", err)
+		return
+	}
+
+	p.groupsMu.Lock()
+	defer p.groupsMu.Unlock()
+
+	g, ok := p.groups[k.Topic]
+	if !ok || !samePublicKey(g.Owner, k.Owner) {
+		return
+	}
+	// the messages can arrive out of order, the older keys are ignored
+	if g.key != nil && k.Epoch <= g.Epoch {
+		return
+	}
+	if err := p.putGroup(k); err != nil {
+		p.logger.Debug("store group key failed", "error", err)
+	}
+}
+
+// groupKeys returns the private keys of the groups with a key by their topics,
+// and whether the node is in any group, so it expects key messages.
+func (p *pss) groupKeys() (map[Topic]*ecdsa.PrivateKey, bool) {
+	p.groupsMu.Lock()
+	defer p.groupsMu.Unlock()
+
+	keys := make(map[Topic]*ecdsa.PrivateKey)
+	for t, g := range p.groups {
+		if g.key != nil {
+			keys[t] = crypto.Secp256k1PrivateKeyFromBytes(g.key)
+		}
+	}
+	return keys, len(p.groups) > 0
+}
+
+func (g *Group) clone() *Group {
+	c := *g
+	c.Members = append([]Member(nil), g.Members...)
+	return &c
+}
+
+func samePublicKey(a, b *ecdsa.PublicKey) bool {
+	return bytes.Equal(crypto.EncodeSecp256k1PublicKey(a), crypto.EncodeSecp256k1PublicKey(b))
+}
+
+func containsKey(keys []*ecdsa.PublicKey, key *ecdsa.PublicKey) bool {
+	for _, k := range keys {
+		if samePublicKey(k, key) {
+			return true
+		}
+	}
+	return false
+}
+
+func containsMember(members []Member, key *ecdsa.PublicKey) bool {
+	for _, m := range members {
+		if samePublicKey(m.PublicKey, key) {
+			return true
+		}
+	}
+	return false
+}
+
+func joinTargets(targets Targets) []byte {
+	var b []byte
+	for _, t := range targets {
+		b = append(b, t...)
+	}
+	return b
+}
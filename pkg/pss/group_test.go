@@ -0,0 +1,290 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package pss_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethersphere/bee/v2/pkg/crypto"
+	"github.com/ethersphere/bee/v2/pkg/log"
+	"github.com/ethersphere/bee/v2/pkg/pss"
+	"github.com/ethersphere/bee/v2/pkg/pushsync"
+	pushsyncmock "github.com/ethersphere/bee/v2/pkg/pushsync/mock"
+	"github.com/ethersphere/bee/v2/pkg/statestore/mock"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+	"github.com/ethersphere/bee/v2/pkg/util/testutil"
+)
+
+// network collects the chunks pushed by the pss services and delivers them
+// to the nodes in the neighbourhood of the chunks.
+type network struct {
+	mu      sync.Mutex
+	chunks  []swarm.Chunk
+	nodes   []pss.Interface
+	targets []pss.Target
+}
+
+func (n *network) newNode(t *testing.T, target pss.Target) (pss.Interface, *ecdsa.PrivateKey) {
+	t.Helper()
+
+	key, err := crypto.GenerateSecp256k1Key()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return n.newNodeWithKey(t, key, target), key
+}
+
+func (n *network) newNodeWithKey(t *testing.T, key *ecdsa.PrivateKey, target pss.Target) pss.Interface {
+	t.Helper()
+
+	p := pss.New(key, log.Noop)
+	testutil.CleanupCloser(t, p)
+	p.SetPushSyncer(pushsyncmock.New(func(_ context.Context, ch swarm.Chunk) (*pushsync.Receipt, error) {
+		n.mu.Lock()
+		defer n.mu.Unlock()
+		n.chunks = append(n.chunks, ch)
+		return nil, nil
+	}))
+	n.nodes = append(n.nodes, p)
+	n.targets = append(n.targets, target)
+	return p
+}
+
+// deliver hands the pushed chunks to the nodes of their neighbourhoods.
+func (n *network) deliver() {
+	n.mu.Lock()
+	chunks := n.chunks
+	n.chunks = nil
+	n.mu.Unlock()
+
+	for _, ch := range chunks {
+		for i, p := range n.nodes {
+			if bytes.HasPrefix(ch.Address().Bytes(), n.targets[i]) {
+				p.TryUnwrap(ch)
+			}
+		}
+	}
+}
+
+func TestGroup(t *testing.T) {
+	t.Parallel()
+
+	var (
+		ctx     = context.Background()
+		net     = &network{}
+		topic   = pss.NewTopic("group")
+		payload = []byte("group payload")
+		s       = &stamper{}
+	)
+	owner, ownerKey := net.newNode(t, pss.Target{0})
+	member1, key1 := net.newNode(t, pss.Target{1})
+	member2, key2 := net.newNode(t, pss.Target{2})
+
+	members := []pss.Member{
+		{PublicKey: &key1.PublicKey, Targets: pss.Targets{{1}}},
+		{PublicKey: &key2.PublicKey, Targets: pss.Targets{{2}}},
+	}
+	for _, m := range []pss.Interface{member1, member2} {
+		if err := m.JoinGroup(topic, &ownerKey.PublicKey); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if _, err := owner.CreateGroup(ctx, topic, s, members); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := owner.CreateGroup(ctx, topic, s, members); !errors.Is(err, pss.ErrGroupExists) {
+		t.Fatalf("got error %v, want %v", err, pss.ErrGroupExists)
+	}
+	net.deliver()
+
+	for _, m := range []pss.Interface{member1, member2} {
+		g, err := m.Group(topic)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if g.Epoch != 0 || len(g.Members) != 2 {
+			t.Fatalf("got epoch %d and %d members, want epoch 0 and 2 members", g.Epoch, len(g.Members))
+		}
+	}
+
+	received1 := make(chan []byte, 4)
+	member1.Register(topic, func(_ context.Context, m []byte) { received1 <- m })
+	received2 := make(chan []byte, 4)
+	member2.Register(topic, func(_ context.Context, m []byte) { received2 <- m })
+
+	t.Run("member sends", func(t *testing.T) {
+		if err := member2.SendGroup(ctx, topic, payload, s); err != nil {
+			t.Fatal(err)
+		}
+		net.deliver()
+
+		waitMessage(t, received1, payload)
+		waitMessage(t, received2, payload)
+	})
+
+	t.Run("removed member cannot read", func(t *testing.T) {
+		g, err := owner.UpdateGroup(ctx, topic, s, nil, []*ecdsa.PublicKey{&key2.PublicKey})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if g.Epoch != 1 || len(g.Members) != 1 {
+			t.Fatalf("got epoch %d and %d members, want epoch 1 and 1 member", g.Epoch, len(g.Members))
+		}
+		net.deliver()
+
+		if _, err := member2.UpdateGroup(ctx, topic, s, nil, nil); !errors.Is(err, pss.ErrNotGroupOwner) {
+			t.Fatalf("got error %v, want %v", err, pss.ErrNotGroupOwner)
+		}
+
+		g, err = member2.Group(topic)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if g.Epoch != 0 {
+			t.Fatalf("removed member got key of epoch %d", g.Epoch)
+		}
+
+		if err := member1.SendGroup(ctx, topic, payload, s); err != nil {
+			t.Fatal(err)
+		}
+		net.deliver()
+
+		waitMessage(t, received1, payload)
+		select {
+		case <-received2:
+			t.Fatal("removed member received message")
+		case <-time.After(100 * time.Millisecond):
+		}
+	})
+
+	t.Run("leave", func(t *testing.T) {
+		if err := member1.LeaveGroup(topic); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := member1.Group(topic); !errors.Is(err, pss.ErrGroupNotFound) {
+			t.Fatalf("got error %v, want %v", err, pss.ErrGroupNotFound)
+		}
+		if err := member1.SendGroup(ctx, topic, payload, s); !errors.Is(err, pss.ErrGroupNotFound) {
+			t.Fatalf("got error %v, want %v", err, pss.ErrGroupNotFound)
+		}
+	})
+}
+
+func TestGroupKeyFromStranger(t *testing.T) {
+	t.Parallel()
+
+	var (
+		ctx   = context.Background()
+		net   = &network{}
+		topic = pss.NewTopic("group")
+	)
+	owner, _ := net.newNode(t, pss.Target{0})
+	member, key := net.newNode(t, pss.Target{1})
+
+	other, err := crypto.GenerateSecp256k1Key()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := member.JoinGroup(topic, &other.PublicKey); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := owner.CreateGroup(ctx, topic, &stamper{}, []pss.Member{{PublicKey: &key.PublicKey, Targets: pss.Targets{{1}}}}); err != nil {
+		t.Fatal(err)
+	}
+	net.deliver()
+
+	if err := member.SendGroup(ctx, topic, []byte("payload"), &stamper{}); !errors.Is(err, pss.ErrNoGroupKey) {
+		t.Fatalf("got error %v, want %v", err, pss.ErrNoGroupKey)
+	}
+}
+
+func TestGroupRestored(t *testing.T) {
+	t.Parallel()
+
+	var (
+		ctx         = context.Background()
+		net         = &network{}
+		topic       = pss.NewTopic("group")
+		payload     = []byte("group payload")
+		s           = &stamper{}
+		ownerStore  = mock.NewStateStore()
+		memberStore = mock.NewStateStore()
+	)
+	owner, ownerKey := net.newNode(t, pss.Target{0})
+	member, memberKey := net.newNode(t, pss.Target{1})
+	if err := owner.SetGroupStore(ownerStore); err != nil {
+		t.Fatal(err)
+	}
+	if err := member.SetGroupStore(memberStore); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := member.JoinGroup(topic, &ownerKey.PublicKey); err != nil {
+		t.Fatal(err)
+	}
+	members := []pss.Member{{PublicKey: &memberKey.PublicKey, Targets: pss.Targets{{1}}}}
+	if _, err := owner.CreateGroup(ctx, topic, s, members); err != nil {
+		t.Fatal(err)
+	}
+	net.deliver()
+
+	// the restarted nodes keep the group and its key
+	owner = net.newNodeWithKey(t, ownerKey, pss.Target{0})
+	if err := owner.SetGroupStore(ownerStore); err != nil {
+		t.Fatal(err)
+	}
+	member = net.newNodeWithKey(t, memberKey, pss.Target{1})
+	if err := member.SetGroupStore(memberStore); err != nil {
+		t.Fatal(err)
+	}
+
+	g, err := owner.UpdateGroup(ctx, topic, s, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if g.Epoch != 1 || len(g.Members) != 1 {
+		t.Fatalf("got epoch %d and %d members, want epoch 1 and 1 member", g.Epoch, len(g.Members))
+	}
+	net.deliver()
+
+	received := make(chan []byte, 1)
+	member.Register(topic, func(_ context.Context, m []byte) { received <- m })
+	if err := owner.SendGroup(ctx, topic, payload, s); err != nil {
+		t.Fatal(err)
+	}
+	net.deliver()
+	waitMessage(t, received, payload)
+
+	if err := member.LeaveGroup(topic); err != nil {
+		t.Fatal(err)
+	}
+	member = net.newNodeWithKey(t, memberKey, pss.Target{1})
+	if err := member.SetGroupStore(memberStore); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := member.Group(topic); !errors.Is(err, pss.ErrGroupNotFound) {
+		t.Fatalf("got error %v, want %v", err, pss.ErrGroupNotFound)
+	}
+}
+
+func waitMessage(t *testing.T, c <-chan []byte, want []byte) {
+	t.Helper()
+
+	select {
+	case got := <-c:
+		if !bytes.Equal(got, want) {
+			t.Fatalf("message mismatch: expected %x, got %x", want, got)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("reached timeout while waiting for message")
+	}
+}
@@ -0,0 +1,293 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package pss
+
+import (
+	random "crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ethersphere/bee/v2/pkg/storage"
+)
+
+var (
+	// ErrInboxDisabled is returned if the node keeps no inboxes.
+	ErrInboxDisabled = errors.New("pss: inboxes disabled")
+	// ErrInboxNotFound is returned if the topic has no inbox.
+	ErrInboxNotFound = errors.New("pss: inbox not found")
+	// ErrInboxMessageNotFound is returned on acknowledging a message that is not in the inbox.
+	ErrInboxMessageNotFound = errors.New("pss: inbox message not found")
+)
+
+const (
+	// DefaultInboxCapacity is the default number of messages kept in an inbox.
+	DefaultInboxCapacity = 1000
+	// DefaultInboxTTL is the default time the messages are kept in an inbox.
+	DefaultInboxTTL = 24 * time.Hour
+
+	inboxTopicKeyPrefix   = "pss-inbox-topic-"
+	inboxMessageKeyPrefix = "pss-inbox-msg-"
+)
+
+// InboxMessage is a message kept in the inbox of a topic.
+type InboxMessage struct {
+	ID       string    `json:"id"`
+	Received time.Time `json:"received"`
+	Payload  []byte    `json:"payload"`
+}
+
+// Inbox keeps the messages of the topics received while no handler is
+// registered for them, until they are acknowledged or expire.
+type Inbox interface {
+	// SetInboxStore enables the inboxes persisted in the store. An inbox keeps
+	// at most capacity messages, the oldest ones are dropped first, and the
+	// messages are dropped after the ttl.
+	SetInboxStore(store storage.StateStorer, capacity int, ttl time.Duration) error
+	// EnableInbox starts keeping the messages of the topic.
+	EnableInbox(topic Topic) error
+	// DisableInbox stops keeping the messages of the topic and drops the kept ones.
+	DisableInbox(topic Topic) error
+	// InboxMessages returns the messages in the inbox of the topic, oldest first.
+	InboxMessages(topic Topic) ([]InboxMessage, error)
+	// AckInboxMessage removes the message from the inbox of the topic.
+	AckInboxMessage(topic Topic, id string) error
+}
+
+func inboxTopicKey(topic Topic) string {
+	return inboxTopicKeyPrefix + hex.EncodeToString(topic[:])
+}
+
+func inboxMessagePrefix(topic Topic) string {
+	return inboxMessageKeyPrefix + hex.EncodeToString(topic[:]) + "-"
+}
+
+func (m *InboxMessage) MarshalBinary() ([]byte, error) {
+	return json.Marshal(m)
+}
+
+func (m *InboxMessage) UnmarshalBinary(data []byte) error {
+	return json.Unmarshal(data, m)
+}
+
+func (p *pss) SetInboxStore(store storage.StateStorer, capacity int, ttl time.Duration) error {
+	if capacity <= 0 || ttl <= 0 {
+		return fmt.Errorf("pss: invalid inbox capacity %d or ttl %s", capacity, ttl)
+	}
+
+	topics := make(map[Topic][]string)
+	err := store.Iterate(inboxTopicKeyPrefix, func(key, _ []byte) (bool, error) {
+		b, err := hex.DecodeString(strings.TrimPrefix(string(key), inboxTopicKeyPrefix))
+		if err != nil || len(b) != len(Topic{}) {
+			return false, fmt.Errorf("invalid inbox key %q", key)
+		}
+		var t Topic
+		copy(t[:], b)
+		topics[t] = nil
+		return false, nil
+	})
+	if err != nil {
+		return fmt.Errorf("pss: load inboxes: %w", err)
+	}
+	for t := range topics {
+		prefix := inboxMessagePrefix(t)
+		var ids []string
+		err := store.Iterate(prefix, func(key, _ []byte) (bool, error) {
+			ids = append(ids, strings.TrimPrefix(string(key), prefix))
+			return false, nil
+		})
+		if err != nil {
+			return fmt.Errorf("pss: load inbox messages: %w", err)
+		}
+		sort.Strings(ids)
+		topics[t] = ids
+	}
+
+	p.inboxMu.Lock()
+	defer p.inboxMu.Unlock()
+
+	p.inboxStore = store
+	p.inboxCapacity = capacity
+	p.inboxTTL = ttl
+	p.inboxTopics = topics
+	return nil
+}
+
+func (p *pss) EnableInbox(topic Topic) error {
+	p.inboxMu.Lock()
+	defer p.inboxMu.Unlock()
+
+	if p.inboxStore == nil {
+		return ErrInboxDisabled
+	}
+	if err := p.inboxStore.Put(inboxTopicKey(topic), time.Now().Unix()); err != nil {
+		return err
+	}
+	if _, ok := p.inboxTopics[topic]; !ok {
+		p.inboxTopics[topic] = nil
+	}
+	return nil
+}
+
+func (p *pss) DisableInbox(topic Topic) error {
+	p.inboxMu.Lock()
+	defer p.inboxMu.Unlock()
+
+	if err := p.checkInbox(topic); err != nil {
+		return err
+	}
+	for _, id := range p.inboxTopics[topic] {
+		if err := p.inboxStore.Delete(inboxMessagePrefix(topic) + id); err != nil {
+			return err
+		}
+	}
+	if err := p.inboxStore.Delete(inboxTopicKey(topic)); err != nil {
+		return err
+	}
+	delete(p.inboxTopics, topic)
+	return nil
+}
+
+func (p *pss) InboxMessages(topic Topic) ([]InboxMessage, error) {
+	p.inboxMu.Lock()
+	defer p.inboxMu.Unlock()
+
+	if err := p.checkInbox(topic); err != nil {
+		return nil, err
+	}
+	if err := p.expireInbox(topic, 0); err != nil {
+		return nil, err
+	}
+	return p.inboxMessages(topic)
+}
+
+func (p *pss) AckInboxMessage(topic Topic, id string) error {
+	p.inboxMu.Lock()
+	defer p.inboxMu.Unlock()
+
+	if err := p.checkInbox(topic); err != nil {
+		return err
+	}
+	ids := p.inboxTopics[topic]
+	i := sort.SearchStrings(ids, id)
+	if i == len(ids) || ids[i] != id {
+		return ErrInboxMessageNotFound
+	}
+	if err := p.inboxStore.Delete(inboxMessagePrefix(topic) + id); err != nil {
+		return err
+	}
+	p.inboxTopics[topic] = append(ids[:i], ids[i+1:]...)
+	return nil
+}
+
+// inboxes returns the topics with an inbox.
+func (p *pss) inboxes() []Topic {
+	p.inboxMu.Lock()
+	defer p.inboxMu.Unlock()
+
+	ts := make([]Topic, 0, len(p.inboxTopics))
+	for t := range p.inboxTopics {
+		ts = append(ts, t)
+	}
+	return ts
+}
+
+// storeInbox keeps the message in the inbox of the topic if it has one.
+func (p *pss) storeInbox(topic Topic, msg []byte) {
+	p.inboxMu.Lock()
+	defer p.inboxMu.Unlock()
+
+	if p.checkInbox(topic) != nil {
+		return
+	}
+
+	// the ids are ordered by the time of arrival
+	id := make([]byte, 12)
+	binary.BigEndian.PutUint64(id, uint64(time.Now().UnixNano()))
+	if _, err := random.Read(id[8:]); err != nil {
+		p.logger.Debug("inbox message id", "error", err)
+		return
+	}
+	m := &InboxMessage{
+		ID:       hex.EncodeToString(id),
+		Received: time.Now(),
+		Payload:  msg,
+	}
+
+	if err := p.expireInbox(topic, 1); err != nil {
+		p.logger.Debug("expire inbox messages failed", "error", err)
+		return
+	}
+	if err := p.inboxStore.Put(inboxMessagePrefix(topic)+m.ID, m); err != nil {
+		p.logger.Debug("store inbox message failed", "error", err)
+		return
+	}
+	ids := p.inboxTopics[topic]
+	i := sort.SearchStrings(ids, m.ID)
+	p.inboxTopics[topic] = slices.Insert(ids, i, m.ID)
+	p.metrics.InboxMessagesCounter.Inc()
+}
+
+// expireInbox drops the expired messages of the topic and the oldest ones
+// exceeding the capacity reduced by the reserved slots. The messages are not
+// read, their arrival time is taken from their IDs.
+func (p *pss) expireInbox(topic Topic, reserved int) error {
+	ids := p.inboxTopics[topic]
+	n := 0
+	for ; n < len(ids); n++ {
+		if len(ids)-n <= p.inboxCapacity-reserved && time.Since(inboxMessageTime(ids[n])) < p.inboxTTL {
+			break
+		}
+		if err := p.inboxStore.Delete(inboxMessagePrefix(topic) + ids[n]); err != nil {
+			p.inboxTopics[topic] = ids[n:]
+			return err
+		}
+	}
+	p.inboxTopics[topic] = ids[n:]
+	return nil
+}
+
+// inboxMessageTime returns the arrival time of the message encoded in its ID.
+func inboxMessageTime(id string) time.Time {
+	b, err := hex.DecodeString(id)
+	if err != nil || len(b) < 8 {
+		return time.Time{}
+	}
+	return time.Unix(0, int64(binary.BigEndian.Uint64(b)))
+}
+
+// inboxMessages returns all messages stored for the topic, oldest first.
+func (p *pss) inboxMessages(topic Topic) ([]InboxMessage, error) {
+	var msgs []InboxMessage
+	err := p.inboxStore.Iterate(inboxMessagePrefix(topic), func(_, val []byte) (bool, error) {
+		var m InboxMessage
+		if err := m.UnmarshalBinary(val); err != nil {
+			return true, err
+		}
+		msgs = append(msgs, m)
+		return false, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(msgs, func(i, j int) bool { return msgs[i].ID < msgs[j].ID })
+	return msgs, nil
+}
+
+func (p *pss) checkInbox(topic Topic) error {
+	if p.inboxStore == nil {
+		return ErrInboxDisabled
+	}
+	if _, ok := p.inboxTopics[topic]; !ok {
+		return ErrInboxNotFound
+	}
+	return nil
+}
@@ -0,0 +1,138 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package pss_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/ethersphere/bee/v2/pkg/crypto"
+	"github.com/ethersphere/bee/v2/pkg/log"
+	"github.com/ethersphere/bee/v2/pkg/pss"
+	"github.com/ethersphere/bee/v2/pkg/statestore/mock"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+	"github.com/ethersphere/bee/v2/pkg/util/testutil"
+)
+
+func TestInbox(t *testing.T) {
+	t.Parallel()
+
+	privkey, err := crypto.GenerateSecp256k1Key()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var (
+		store   = mock.NewStateStore()
+		topic   = pss.NewTopic("inbox")
+		targets = pss.Targets{{1}}
+	)
+
+	wrap := func(t *testing.T, payload []byte) swarm.Chunk {
+		t.Helper()
+		ch, err := pss.Wrap(context.Background(), topic, payload, &privkey.PublicKey, targets)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return ch
+	}
+
+	p := pss.New(privkey, log.Noop)
+	testutil.CleanupCloser(t, p)
+
+	if err := p.EnableInbox(topic); !errors.Is(err, pss.ErrInboxDisabled) {
+		t.Fatalf("got error %v, want %v", err, pss.ErrInboxDisabled)
+	}
+	if err := p.SetInboxStore(store, 2, time.Hour); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := p.InboxMessages(topic); !errors.Is(err, pss.ErrInboxNotFound) {
+		t.Fatalf("got error %v, want %v", err, pss.ErrInboxNotFound)
+	}
+	if err := p.EnableInbox(topic); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("kept without handler", func(t *testing.T) {
+		for i := 0; i < 3; i++ {
+			p.TryUnwrap(wrap(t, []byte(fmt.Sprintf("message %d", i))))
+		}
+
+		// the oldest message is dropped over the capacity
+		msgs, err := p.InboxMessages(topic)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(msgs) != 2 {
+			t.Fatalf("got %d messages, want 2", len(msgs))
+		}
+		for i, m := range msgs {
+			if want := []byte(fmt.Sprintf("message %d", i+1)); !bytes.Equal(m.Payload, want) {
+				t.Fatalf("got message %q, want %q", m.Payload, want)
+			}
+		}
+
+		if err := p.AckInboxMessage(topic, msgs[0].ID); err != nil {
+			t.Fatal(err)
+		}
+		if err := p.AckInboxMessage(topic, msgs[0].ID); !errors.Is(err, pss.ErrInboxMessageNotFound) {
+			t.Fatalf("got error %v, want %v", err, pss.ErrInboxMessageNotFound)
+		}
+		if msgs, _ = p.InboxMessages(topic); len(msgs) != 1 {
+			t.Fatalf("got %d messages, want 1", len(msgs))
+		}
+	})
+
+	t.Run("handed to handler", func(t *testing.T) {
+		received := make(chan []byte, 1)
+		cleanup := p.Register(topic, func(_ context.Context, m []byte) { received <- m })
+		defer cleanup()
+
+		payload := []byte("handled")
+		p.TryUnwrap(wrap(t, payload))
+		waitMessage(t, received, payload)
+
+		if msgs, _ := p.InboxMessages(topic); len(msgs) != 1 {
+			t.Fatalf("got %d messages, want 1", len(msgs))
+		}
+	})
+
+	t.Run("restored", func(t *testing.T) {
+		p2 := pss.New(privkey, log.Noop)
+		testutil.CleanupCloser(t, p2)
+		if err := p2.SetInboxStore(store, 2, time.Nanosecond); err != nil {
+			t.Fatal(err)
+		}
+
+		// the inbox topic is restored, and the messages expire with the ttl
+		msgs, err := p2.InboxMessages(topic)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(msgs) != 0 {
+			t.Fatalf("got %d messages, want expired", len(msgs))
+		}
+	})
+
+	t.Run("disable", func(t *testing.T) {
+		p.TryUnwrap(wrap(t, []byte("message")))
+
+		if err := p.DisableInbox(topic); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := p.InboxMessages(topic); !errors.Is(err, pss.ErrInboxNotFound) {
+			t.Fatalf("got error %v, want %v", err, pss.ErrInboxNotFound)
+		}
+		if err := p.EnableInbox(topic); err != nil {
+			t.Fatal(err)
+		}
+		if msgs, _ := p.InboxMessages(topic); len(msgs) != 0 {
+			t.Fatalf("got %d messages, want the kept messages dropped", len(msgs))
+		}
+	})
+}
@@ -12,6 +12,9 @@ import (
 type metrics struct {
 	TotalMessagesSentCounter prometheus.Counter
 	MessageMiningDuration    prometheus.Gauge
+	MessageRetriesCounter    prometheus.Counter
+	MessageAcksCounter       prometheus.Counter
+	InboxMessagesCounter     prometheus.Counter
 }
 
 func newMetrics() metrics {
@@ -30,6 +33,24 @@ func newMetrics() metrics {
 			Name:      "mining_duration",
 			Help:      "Time duration to mine a message.",
 		}),
+		MessageRetriesCounter: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: m.Namespace,
+			Subsystem: subsystem,
+			Name:      "message_retries",
+			Help:      "Total reliable messages sent again for missing acknowledgement.",
+		}),
+		MessageAcksCounter: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: m.Namespace,
+			Subsystem: subsystem,
+			Name:      "message_acks",
+			Help:      "Total acknowledgements received for reliable messages.",
+		}),
+		InboxMessagesCounter: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: m.Namespace,
+			Subsystem: subsystem,
+			Name:      "inbox_messages",
+			Help:      "Total messages kept in the inboxes for missing handlers.",
+		}),
 	}
 }
 
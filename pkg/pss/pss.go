@@ -20,6 +20,7 @@ import (
 	"github.com/ethersphere/bee/v2/pkg/log"
 	"github.com/ethersphere/bee/v2/pkg/postage"
 	"github.com/ethersphere/bee/v2/pkg/pushsync"
+	"github.com/ethersphere/bee/v2/pkg/storage"
 	"github.com/ethersphere/bee/v2/pkg/swarm"
 	"github.com/ethersphere/bee/v2/pkg/topology"
 )
@@ -39,6 +40,9 @@ type Sender interface {
 
 type Interface interface {
 	Sender
+	Groups
+	Reliable
+	Inbox
 	// Register a Handler for a given Topic.
 	Register(Topic, Handler) func()
 	// TryUnwrap tries to unwrap a wrapped trojan message.
@@ -53,24 +57,58 @@ type pss struct {
 	pusher     pushsync.PushSyncer
 	handlers   map[Topic][]*Handler
 	handlersMu sync.Mutex
-	metrics    metrics
-	logger     log.Logger
-	quit       chan struct{}
+	groups     map[Topic]*Group
+	groupStore storage.StateStorer
+	groupsMu   sync.Mutex
+	// deliveries are the reliable messages sent by the node
+	deliveries   map[MessageID]*delivery
+	deliveriesMu sync.Mutex
+	// ackers are the stampers of the acknowledgements by topics
+	ackers   map[Topic][]*postage.Stamper
+	ackersMu sync.Mutex
+	// seen are the IDs of the received reliable messages
+	seen   map[MessageID]time.Time
+	seenMu sync.Mutex
+	// inboxTopics are the IDs of the kept messages, oldest first, by the
+	// topics whose messages are kept while no handler is registered
+	inboxTopics   map[Topic][]string
+	inboxStore    storage.StateStorer
+	inboxCapacity int
+	inboxTTL      time.Duration
+	inboxMu       sync.Mutex
+	metrics       metrics
+	logger        log.Logger
+	quit          chan struct{}
+	closed        bool
+	closeMu       sync.Mutex
+	wg            sync.WaitGroup
 }
 
 // New returns a new pss service.
 func New(key *ecdsa.PrivateKey, logger log.Logger) Interface {
 	return &pss{
-		key:      key,
-		logger:   logger.WithName(loggerName).Register(),
-		handlers: make(map[Topic][]*Handler),
-		metrics:  newMetrics(),
-		quit:     make(chan struct{}),
+		key:         key,
+		logger:      logger.WithName(loggerName).Register(),
+		handlers:    make(map[Topic][]*Handler),
+		groups:      make(map[Topic]*Group),
+		deliveries:  make(map[MessageID]*delivery),
+		ackers:      make(map[Topic][]*postage.Stamper),
+		seen:        make(map[MessageID]time.Time),
+		inboxTopics: make(map[Topic][]string),
+		metrics:     newMetrics(),
+		quit:        make(chan struct{}),
 	}
 }
 
 func (ps *pss) Close() error {
-	close(ps.quit)
+	ps.closeMu.Lock()
+	if !ps.closed {
+		ps.closed = true
+		close(ps.quit)
+	}
+	ps.closeMu.Unlock()
+	ps.wg.Wait()
+
 	ps.handlersMu.Lock()
 	defer ps.handlersMu.Unlock()
 
@@ -79,6 +117,22 @@ func (ps *pss) Close() error {
 	return nil
 }
 
+// goTracked runs f in a goroutine that Close waits for. No goroutine is
+// started once the service is closing.
+func (ps *pss) goTracked(f func()) {
+	ps.closeMu.Lock()
+	defer ps.closeMu.Unlock()
+
+	if ps.closed {
+		return
+	}
+	ps.wg.Add(1)
+	go func() {
+		defer ps.wg.Done()
+		f()
+	}()
+}
+
 func (ps *pss) SetPushSyncer(pushSyncer pushsync.PushSyncer) {
 	ps.pusher = pushSyncer
 }
@@ -157,12 +211,60 @@ func (p *pss) TryUnwrap(c swarm.Chunk) {
 		return // chunk not full
 	}
 	ctx := context.Background()
-	topic, msg, err := Unwrap(ctx, p.key, c, p.topics())
+	topics := p.topics()
+	for _, t := range p.inboxes() {
+		if !containsTopic(topics, t) {
+			topics = append(topics, t)
+		}
+	}
+	// the reliable messages of the topics are sent on their own topics
+	reliable := make(map[Topic]Topic, len(topics))
+	for _, t := range topics {
+		reliable[reliableTopic(t)] = t
+	}
+	for rt := range reliable {
+		topics = append(topics, rt)
+	}
+	if p.pendingDeliveries() {
+		topics = append(topics, ackTopic)
+	}
+	groupKeys, joined := p.groupKeys()
+	if joined {
+		topics = append(topics, groupKeyTopic)
+	}
+	topic, msg, err := Unwrap(ctx, p.key, c, topics)
 	if err != nil {
 		return // cannot unwrap
 	}
+	if msg == nil {
+		// the message can be encrypted with the key of a group
+		for t, key := range groupKeys {
+			topic, msg, err = Unwrap(ctx, key, c, []Topic{t})
+			if err == nil && msg != nil {
+				break
+			}
+		}
+		if msg == nil {
+			return // cannot unwrap
+		}
+	}
+	switch topic {
+	case groupKeyTopic:
+		p.handleGroupKey(msg)
+		return
+	case ackTopic:
+		p.handleAck(msg)
+		return
+	}
+	if t, ok := reliable[topic]; ok {
+		if msg, ok = p.handleReliable(t, msg); !ok {
+			return
+		}
+		topic = t
+	}
 	h := p.getHandlers(topic)
-	if h == nil {
+	if len(h) == 0 {
+		p.storeInbox(topic, msg)
 		return // no handler
 	}
 
@@ -189,6 +291,15 @@ func (p *pss) TryUnwrap(c swarm.Chunk) {
 	}()
 }
 
+func containsTopic(topics []Topic, topic Topic) bool {
+	for _, t := range topics {
+		if t == topic {
+			return true
+		}
+	}
+	return false
+}
+
 func (p *pss) getHandlers(topic Topic) []*Handler {
 	p.handlersMu.Lock()
 	defer p.handlersMu.Unlock()
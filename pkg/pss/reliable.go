@@ -0,0 +1,346 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package pss
+
+import (
+	"context"
+	"crypto/ecdsa"
+	random "crypto/rand"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/ethersphere/bee/v2/pkg/crypto"
+	"github.com/ethersphere/bee/v2/pkg/postage"
+)
+
+// ErrMessageNotFound is returned if the delivery of the message is not tracked by the node.
+var ErrMessageNotFound = errors.New("pss: message not found")
+
+// ackTopic is the topic of the acknowledgements of the reliable messages.
+var ackTopic = NewTopic("pss-ack")
+
+const (
+	// MessageIDSize is the size of the identifier of the reliable messages.
+	MessageIDSize = 32
+
+	ackMessageSize = MessageIDSize + signatureSize
+)
+
+var (
+	// retryInterval is the time the sender waits for the acknowledgement before sending the message again.
+	retryInterval = 30 * time.Second
+	// deliveryTTL is the time the finished deliveries and the received message IDs are remembered.
+	deliveryTTL = time.Hour
+)
+
+// MessageID identifies a reliable message.
+type MessageID [MessageIDSize]byte
+
+func (id MessageID) String() string {
+	return hex.EncodeToString(id[:])
+}
+
+// DeliveryState is the state of the delivery of a reliable message.
+type DeliveryState string
+
+const (
+	DeliveryPending DeliveryState = "pending"
+	DeliveryAcked   DeliveryState = "acked"
+	DeliveryExpired DeliveryState = "expired"
+)
+
+// Delivery is the status of the delivery of a reliable message.
+type Delivery struct {
+	ID       MessageID
+	State    DeliveryState
+	Attempts int
+	Deadline time.Time
+	AckedAt  time.Time
+}
+
+type delivery struct {
+	Delivery
+	// signer is the expected signer of the acknowledgement, nil if the
+	// message is readable by anyone knowing the topic.
+	signer *ecdsa.PublicKey
+	acked  chan struct{}
+}
+
+// Reliable sends messages that are acknowledged by the recipients.
+type Reliable interface {
+	// SendReliable sends the payload and sends it again with a newly mined
+	// chunk until the recipient acknowledges it or the deadline passes. The
+	// acknowledgement is sent back to the ack targets.
+	SendReliable(ctx context.Context, topic Topic, payload []byte, stamper postage.Stamper, recipient *ecdsa.PublicKey, targets, ackTargets Targets, deadline time.Time) (MessageID, error)
+	// Delivery returns the delivery status of the reliable message.
+	Delivery(id MessageID) (*Delivery, error)
+	// Acknowledge acknowledges the reliable messages received on the topic
+	// with the stamper until the returned cleanup function is called.
+	Acknowledge(topic Topic, stamper postage.Stamper) (cleanup func())
+}
+
+// reliableTopic returns the topic the reliable messages of the topic are sent on,
+// so they are not mistaken for plain messages by the nodes not expecting them.
+func reliableTopic(topic Topic) Topic {
+	return NewTopic("pss-reliable-" + hex.EncodeToString(topic[:]))
+}
+
+func (p *pss) SendReliable(ctx context.Context, topic Topic, payload []byte, stamper postage.Stamper, recipient *ecdsa.PublicKey, targets, ackTargets Targets, deadline time.Time) (MessageID, error) {
+	var id MessageID
+	if err := checkTargets(ackTargets); err != nil {
+		return id, err
+	}
+	if len(ackTargets) > 255 || len(ackTargets[0]) > 255 {
+		return id, ErrPayloadTooBig
+	}
+	if _, err := random.Read(id[:]); err != nil {
+		return id, err
+	}
+
+	msg := make([]byte, 0, MaxPayloadSize)
+	msg = append(msg, id[:]...)
+	msg = append(msg, crypto.EncodeSecp256k1PublicKey(&p.key.PublicKey)...)
+	msg = append(msg, byte(len(ackTargets)), byte(len(ackTargets[0])))
+	msg = append(msg, joinTargets(ackTargets)...)
+	msg = append(msg, payload...)
+
+	// the first attempt is synchronous so the caller learns about the invalid requests
+	if err := p.Send(ctx, reliableTopic(topic), msg, stamper, recipient, targets); err != nil {
+		return id, err
+	}
+
+	d := &delivery{
+		Delivery: Delivery{
+			ID:       id,
+			State:    DeliveryPending,
+			Attempts: 1,
+			Deadline: deadline,
+		},
+		acked: make(chan struct{}),
+	}
+	if !samePublicKey(recipient, &crypto.Secp256k1PrivateKeyFromBytes(topic[:]).PublicKey) {
+		d.signer = recipient
+	}
+
+	p.deliveriesMu.Lock()
+	for k, v := range p.deliveries {
+		if v.State != DeliveryPending && time.Since(v.Deadline) > deliveryTTL {
+			delete(p.deliveries, k)
+		}
+	}
+	p.deliveries[id] = d
+	p.deliveriesMu.Unlock()
+
+	p.goTracked(func() { p.retry(d, topic, msg, stamper, recipient, targets) })
+
+	return id, nil
+}
+
+// retry sends the message again until it is acknowledged or the deadline passes.
+func (p *pss) retry(d *delivery, topic Topic, msg []byte, stamper postage.Stamper, recipient *ecdsa.PublicKey, targets Targets) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		select {
+		case <-p.quit:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	for {
+		wait := retryInterval
+		if left := time.Until(d.Deadline); left < wait {
+			wait = left
+		}
+		select {
+		case <-d.acked:
+			return
+		case <-p.quit:
+			return
+		case <-time.After(wait):
+		}
+
+		p.deliveriesMu.Lock()
+		if d.State != DeliveryPending {
+			p.deliveriesMu.Unlock()
+			return
+		}
+		if !time.Now().Before(d.Deadline) {
+			d.State = DeliveryExpired
+			p.deliveriesMu.Unlock()
+			return
+		}
+		d.Attempts++
+		p.deliveriesMu.Unlock()
+
+		p.metrics.MessageRetriesCounter.Inc()
+		if err := p.Send(ctx, reliableTopic(topic), msg, stamper, recipient, targets); err != nil {
+			p.logger.Debug("resend reliable message failed", "id", d.ID, "error", err)
+		}
+	}
+}
+
+func (p *pss) Delivery(id MessageID) (*Delivery, error) {
+	p.deliveriesMu.Lock()
+	defer p.deliveriesMu.Unlock()
+
+	d, ok := p.deliveries[id]
+	if !ok {
+		return nil, ErrMessageNotFound
+	}
+	c := d.Delivery
+	return &c, nil
+}
+
+func (p *pss) Acknowledge(topic Topic, stamper postage.Stamper) (cleanup func()) {
+	p.ackersMu.Lock()
+	defer p.ackersMu.Unlock()
+
+	s := &stamper
+	p.ackers[topic] = append(p.ackers[topic], s)
+
+	return func() {
+		p.ackersMu.Lock()
+		defer p.ackersMu.Unlock()
+
+		a := p.ackers[topic]
+		for i := 0; i < len(a); i++ {
+			if a[i] == s {
+				p.ackers[topic] = append(a[:i], a[i+1:]...)
+				break
+			}
+		}
+		if len(p.ackers[topic]) == 0 {
+			delete(p.ackers, topic)
+		}
+	}
+}
+
+func (p *pss) acker(topic Topic) postage.Stamper {
+	p.ackersMu.Lock()
+	defer p.ackersMu.Unlock()
+
+	if a := p.ackers[topic]; len(a) > 0 {
+		return *a[0]
+	}
+	return nil
+}
+
+// pendingDeliveries reports whether the node waits for acknowledgements.
+func (p *pss) pendingDeliveries() bool {
+	p.deliveriesMu.Lock()
+	defer p.deliveriesMu.Unlock()
+
+	for _, d := range p.deliveries {
+		if d.State == DeliveryPending {
+			return true
+		}
+	}
+	return false
+}
+
+// handleReliable acknowledges the reliable message received on the topic and
+// returns its payload, or false if the message was already received.
+func (p *pss) handleReliable(topic Topic, msg []byte) ([]byte, bool) {
+	if len(msg) < MessageIDSize+publicKeySize+2 {
+		p.logger.Debug("invalid reliable message")
+		return nil, false
+	}
+	var id MessageID
+	copy(id[:], msg)
+	pub, err := btcec.ParsePubKey(msg[MessageIDSize : MessageIDSize+publicKeySize])
+	if err != nil {
+		p.logger.Debug("invalid reliable message sender", "error", err)
+		return nil, false
+	}
+	data := msg[MessageIDSize+publicKeySize:]
+	n, l := int(data[0]), int(data[1])
+	data = data[2:]
+	if n == 0 || len(data) < n*l {
+		p.logger.Debug("invalid reliable message ack targets")
+		return nil, false
+	}
+	var targets Targets
+	for i := 0; i < n; i++ {
+		targets = append(targets, Target(data[i*l:(i+1)*l]))
+	}
+	payload := data[n*l:]
+
+	// the message is acknowledged every time, the earlier ack could have been lost
+	if stamper := p.acker(topic); stamper != nil {
+		p.goTracked(func() { p.ack(id, stamper, pub.ToECDSA(), targets) })
+	}
+
+	p.seenMu.Lock()
+	defer p.seenMu.Unlock()
+
+	for k, t := range p.seen {
+		if time.Since(t) > deliveryTTL {
+			delete(p.seen, k)
+		}
+	}
+	if _, ok := p.seen[id]; ok {
+		return nil, false
+	}
+	p.seen[id] = time.Now()
+	return payload, true
+}
+
+// ack sends the signed acknowledgement of the message to its sender.
+func (p *pss) ack(id MessageID, stamper postage.Stamper, sender *ecdsa.PublicKey, targets Targets) {
+	sig, err := crypto.NewDefaultSigner(p.key).Sign(id[:])
+	if err != nil {
+		p.logger.Debug("sign ack failed", "id", id, "error", err)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), retryInterval)
+	defer cancel()
+	go func() {
+		select {
+		case <-p.quit:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	if err := p.Send(ctx, ackTopic, append(id[:], sig...), stamper, sender, targets); err != nil {
+		p.logger.Debug("send ack failed", "id", id, "error", err)
+	}
+}
+
+// handleAck marks the message acknowledged by its recipient delivered.
+func (p *pss) handleAck(msg []byte) {
+	if len(msg) != ackMessageSize {
+		p.logger.Debug("invalid ack message")
+		return
+	}
+	var id MessageID
+	copy(id[:], msg)
+	signer, err := crypto.Recover(msg[MessageIDSize:], id[:])
+	if err != nil {
+		p.logger.Debug("invalid ack signature", "id", id, "error", err)
+		return
+	}
+
+	p.deliveriesMu.Lock()
+	defer p.deliveriesMu.Unlock()
+
+	d, ok := p.deliveries[id]
+	if !ok || d.State != DeliveryPending {
+		return
+	}
+	if d.signer != nil && !samePublicKey(d.signer, signer) {
+		p.logger.Debug("ack not signed by the recipient", "id", id)
+		return
+	}
+	p.metrics.MessageAcksCounter.Inc()
+	d.State = DeliveryAcked
+	d.AckedAt = time.Now()
+	close(d.acked)
+}
@@ -0,0 +1,130 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package pss_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethersphere/bee/v2/pkg/pss"
+)
+
+// nolint:paralleltest
+func TestSendReliable(t *testing.T) {
+	t.Cleanup(pss.SetRetryInterval(50 * time.Millisecond))
+
+	var (
+		ctx     = context.Background()
+		net     = &network{}
+		topic   = pss.NewTopic("reliable")
+		payload = []byte("reliable payload")
+		s       = &stamper{}
+	)
+	sender, _ := net.newNode(t, pss.Target{0})
+	recipient, key := net.newNode(t, pss.Target{1})
+
+	received := make(chan []byte, 4)
+	recipient.Register(topic, func(_ context.Context, m []byte) { received <- m })
+
+	t.Run("acked", func(t *testing.T) {
+		cleanup := recipient.Acknowledge(topic, s)
+		defer cleanup()
+
+		id, err := sender.SendReliable(ctx, topic, payload, s, &key.PublicKey, pss.Targets{{1}}, pss.Targets{{0}}, time.Now().Add(time.Minute))
+		if err != nil {
+			t.Fatal(err)
+		}
+		d, err := sender.Delivery(id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if d.State != pss.DeliveryPending {
+			t.Fatalf("got state %s, want %s", d.State, pss.DeliveryPending)
+		}
+
+		// the message reaches the recipient that sends the ack back
+		net.deliver()
+		waitMessage(t, received, payload)
+		waitDelivery(t, net, sender, id, pss.DeliveryAcked)
+
+		// the retried messages are not handed to the handlers again
+		select {
+		case <-received:
+			t.Fatal("duplicate message received")
+		case <-time.After(100 * time.Millisecond):
+		}
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		id, err := sender.SendReliable(ctx, topic, payload, s, &key.PublicKey, pss.Targets{{1}}, pss.Targets{{0}}, time.Now().Add(2*time.Second))
+		if err != nil {
+			t.Fatal(err)
+		}
+		net.deliver()
+		waitMessage(t, received, payload)
+
+		d := waitDelivery(t, net, sender, id, pss.DeliveryExpired)
+		if d.Attempts < 2 {
+			t.Fatalf("got %d attempts, want the message retried", d.Attempts)
+		}
+	})
+
+	t.Run("unknown", func(t *testing.T) {
+		if _, err := sender.Delivery(pss.MessageID{}); !errors.Is(err, pss.ErrMessageNotFound) {
+			t.Fatalf("got error %v, want %v", err, pss.ErrMessageNotFound)
+		}
+	})
+}
+
+func TestSendReliableClose(t *testing.T) {
+	t.Parallel()
+
+	var (
+		ctx   = context.Background()
+		net   = &network{}
+		topic = pss.NewTopic("reliable")
+		s     = &stamper{}
+	)
+	sender, _ := net.newNode(t, pss.Target{0})
+	_, key := net.newNode(t, pss.Target{1})
+
+	// the messages sent while closing do not start retries after Close waited for them
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 2; j++ {
+				_, _ = sender.SendReliable(ctx, topic, []byte("payload"), s, &key.PublicKey, pss.Targets{{1}}, pss.Targets{{0}}, time.Now().Add(time.Minute))
+			}
+		}()
+	}
+	if err := sender.Close(); err != nil {
+		t.Fatal(err)
+	}
+	wg.Wait()
+}
+
+func waitDelivery(t *testing.T, net *network, p pss.Interface, id pss.MessageID, state pss.DeliveryState) *pss.Delivery {
+	t.Helper()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		net.deliver()
+		d, err := p.Delivery(id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if d.State == state {
+			return d
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("delivery %s did not reach state %s", id, state)
+	return nil
+}
@@ -251,6 +251,32 @@ func mine(ctx context.Context, odd bool, f func(nonce []byte) (swarm.Chunk, erro
 	return r, nil
 }
 
+// MineID mines a 32 byte identifier such that the address derived from it by
+// the address function has one of the targets as its prefix. It is used for
+// placing single owner chunks in the neighbourhood of the targets.
+func MineID(ctx context.Context, targets Targets, address func(id []byte) (swarm.Address, error)) ([]byte, error) {
+	if err := checkTargets(targets); err != nil {
+		return nil, err
+	}
+	targetsLen := len(targets[0])
+
+	f := func(nonce []byte) (swarm.Chunk, error) {
+		addr, err := address(nonce)
+		if err != nil {
+			return nil, err
+		}
+		if !contains(targets, addr.Bytes()[:targetsLen]) {
+			return nil, nil
+		}
+		return swarm.NewChunk(addr, bytes.Clone(nonce)), nil
+	}
+	ch, err := mine(ctx, false, f)
+	if err != nil {
+		return nil, err
+	}
+	return ch.Data(), nil
+}
+
 // extracts ephemeral public key from the chunk data to use with el-Gamal
 func extractPublicKey(chunkData []byte) (*ecdsa.PublicKey, error) {
 	pubkeyBytes := make([]byte, 33)
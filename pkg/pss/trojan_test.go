@@ -115,3 +115,36 @@ func TestUnwrapTopicEncrypted(t *testing.T) {
 		t.Fatalf("topic mismatch: expected %x, got %x", topic[:], unwrapTopic[:])
 	}
 }
+
+func TestMineID(t *testing.T) {
+	t.Parallel()
+
+	owner := make([]byte, 20)
+	targets := newTargets(2, 1)
+	address := func(id []byte) (swarm.Address, error) {
+		h, err := crypto.LegacyKeccak256(append(append([]byte(nil), id...), owner...))
+		if err != nil {
+			return swarm.ZeroAddress, err
+		}
+		return swarm.NewAddress(h), nil
+	}
+
+	id, err := pss.MineID(context.Background(), targets, address)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(id) != 32 {
+		t.Fatalf("got id length %d, want 32", len(id))
+	}
+	addr, err := address(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !pss.Contains(targets, addr.Bytes()[:1]) {
+		t.Fatalf("address %s has none of the targets as prefix", addr)
+	}
+
+	if _, err := pss.MineID(context.Background(), nil, address); err == nil {
+		t.Fatal("expected error for empty targets")
+	}
+}
@@ -0,0 +1,144 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package dnslink implements a name resolution client that resolves the names
+// from the DNS TXT records of the form
+//
+//	_swarm.example.com TXT "swarm=<reference>"
+package dnslink
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/ethersphere/bee/v2/pkg/resolver"
+	"github.com/ethersphere/bee/v2/pkg/resolver/client"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+)
+
+const (
+	// Scheme is the scheme of the endpoints served by the client, eg.
+	// dns://1.1.1.1:53, or dns:// for the resolver of the system.
+	Scheme = "dns"
+
+	recordPrefix = "_swarm."
+	valuePrefix  = "swarm="
+
+	defaultTimeout = 10 * time.Second
+	defaultPort    = "53"
+)
+
+// Address is the swarm bzz address.
+type Address = swarm.Address
+
+// Make sure Client implements the resolver.Client interface.
+var _ client.Interface = (*Client)(nil)
+
+var (
+	// ErrInvalidEndpoint denotes that the endpoint is not a DNS server address.
+	ErrInvalidEndpoint = errors.New("invalid endpoint")
+	// ErrResolveFailed denotes that a name could not be resolved.
+	ErrResolveFailed = errors.New("resolve failed")
+)
+
+// Client is a name resolution client that looks up the swarm references in
+// the DNS TXT records.
+type Client struct {
+	endpoint string
+	resolver *net.Resolver
+	timeout  time.Duration
+}
+
+// Option is a function that applies an option to a Client.
+type Option func(*Client)
+
+// WithTimeout will set the time limit of a lookup.
+func WithTimeout(d time.Duration) Option {
+	return func(c *Client) {
+		c.timeout = d
+	}
+}
+
+// NewClient will return a new Client querying the DNS server of the endpoint.
+func NewClient(endpoint string, opts ...Option) (client.Interface, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil || u.Scheme != Scheme {
+		return nil, fmt.Errorf("%s: %w", endpoint, ErrInvalidEndpoint)
+	}
+
+	c := &Client{
+		endpoint: endpoint,
+		resolver: net.DefaultResolver,
+		timeout:  defaultTimeout,
+	}
+
+	// Apply all options to the Client.
+	for _, o := range opts {
+		o(c)
+	}
+
+	if u.Host != "" {
+		server := u.Host
+		if u.Port() == "" {
+			server = net.JoinHostPort(u.Hostname(), defaultPort)
+		}
+		c.resolver = &net.Resolver{
+			PreferGo: true,
+			Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
+				var d net.Dialer
+				return d.DialContext(ctx, network, server)
+			},
+		}
+	}
+
+	return c, nil
+}
+
+// IsConnected always returns true, the DNS queries need no connection.
+func (c *Client) IsConnected() bool {
+	return true
+}
+
+// Endpoint returns the endpoint of the DNS server.
+func (c *Client) Endpoint() string {
+	return c.endpoint
+}
+
+// Resolve implements the resolver.Client interface.
+func (c *Client) Resolve(name string) (Address, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	records, err := c.resolver.LookupTXT(ctx, recordPrefix+strings.TrimSuffix(name, "."))
+	if err != nil {
+		var dnsErr *net.DNSError
+		if errors.As(err, &dnsErr) && dnsErr.IsNotFound {
+			return swarm.ZeroAddress, fmt.Errorf("%w: %w", err, resolver.ErrNotFound)
+		}
+		return swarm.ZeroAddress, fmt.Errorf("%w: %w: %w", err, ErrResolveFailed, resolver.ErrServiceNotAvailable)
+	}
+
+	for _, r := range records {
+		if !strings.HasPrefix(r, valuePrefix) {
+			continue
+		}
+		addr, err := swarm.ParseHexAddress(strings.TrimPrefix(r, valuePrefix))
+		if err != nil {
+			return swarm.ZeroAddress, fmt.Errorf("parse record %s: %w", r, resolver.ErrInvalidContentHash)
+		}
+		return addr, nil
+	}
+
+	return swarm.ZeroAddress, fmt.Errorf("no swarm record for %s: %w", name, resolver.ErrNotFound)
+}
+
+// Close is a noop, the client holds no connections.
+func (c *Client) Close() error {
+	return nil
+}
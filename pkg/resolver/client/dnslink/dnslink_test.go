@@ -0,0 +1,142 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dnslink_test
+
+import (
+	"errors"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/ethersphere/bee/v2/pkg/resolver"
+	"github.com/ethersphere/bee/v2/pkg/resolver/client/dnslink"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+// newServer starts a DNS server answering the TXT queries from the records
+// and returns its endpoint.
+func newServer(t *testing.T, records map[string][]string) string {
+	t.Helper()
+
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+
+	go func() {
+		buf := make([]byte, 512)
+		for {
+			n, addr, err := conn.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+			var req dnsmessage.Message
+			if err := req.Unpack(buf[:n]); err != nil || len(req.Questions) != 1 {
+				continue
+			}
+			q := req.Questions[0]
+			res := dnsmessage.Message{
+				Header:    dnsmessage.Header{ID: req.ID, Response: true, Authoritative: true},
+				Questions: req.Questions,
+			}
+			txts, ok := records[strings.TrimSuffix(q.Name.String(), ".")]
+			switch {
+			case !ok:
+				res.RCode = dnsmessage.RCodeNameError
+			case q.Type == dnsmessage.TypeTXT:
+				for _, txt := range txts {
+					res.Answers = append(res.Answers, dnsmessage.Resource{
+						Header: dnsmessage.ResourceHeader{Name: q.Name, Type: dnsmessage.TypeTXT, Class: dnsmessage.ClassINET},
+						Body:   &dnsmessage.TXTResource{TXT: []string{txt}},
+					})
+				}
+			}
+			b, err := res.Pack()
+			if err != nil {
+				continue
+			}
+			_, _ = conn.WriteTo(b, addr)
+		}
+	}()
+
+	return "dns://" + conn.LocalAddr().String()
+}
+
+func TestNewClient(t *testing.T) {
+	t.Parallel()
+
+	for _, endpoint := range []string{"https://example.com", "example.com", "dns ://"} {
+		if _, err := dnslink.NewClient(endpoint); !errors.Is(err, dnslink.ErrInvalidEndpoint) {
+			t.Fatalf("%s: got error %v, want %v", endpoint, err, dnslink.ErrInvalidEndpoint)
+		}
+	}
+
+	cl, err := dnslink.NewClient("dns://")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !cl.IsConnected() || cl.Endpoint() != "dns://" {
+		t.Fatalf("unexpected client %s", cl.Endpoint())
+	}
+}
+
+func TestResolve(t *testing.T) {
+	t.Parallel()
+
+	addr := swarm.RandAddress(t)
+	endpoint := newServer(t, map[string][]string{
+		"_swarm.example.com": {"v=spf1 -all", "swarm=" + addr.String()},
+		"_swarm.invalid.com": {"swarm=notahexaddress"},
+		"_swarm.other.com":   {"v=spf1 -all"},
+	})
+
+	cl, err := dnslink.NewClient(endpoint)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = cl.Close() })
+
+	testCases := []struct {
+		name     string
+		wantAddr swarm.Address
+		wantErr  error
+	}{
+		{
+			name:     "example.com",
+			wantAddr: addr,
+		},
+		{
+			name:     "example.com.",
+			wantAddr: addr,
+		},
+		{
+			name:    "invalid.com",
+			wantErr: resolver.ErrInvalidContentHash,
+		},
+		{
+			name:    "other.com",
+			wantErr: resolver.ErrNotFound,
+		},
+		{
+			name:    "unknown.com",
+			wantErr: resolver.ErrNotFound,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := cl.Resolve(tc.name)
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("got error %v, want %v", err, tc.wantErr)
+			}
+			if !got.Equal(tc.wantAddr) && tc.wantErr == nil {
+				t.Fatalf("got address %s, want %s", got, tc.wantAddr)
+			}
+		})
+	}
+}
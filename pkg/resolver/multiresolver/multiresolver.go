@@ -10,10 +10,14 @@ import (
 	"path"
 	"strings"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethersphere/bee/v2/pkg/log"
 	"github.com/ethersphere/bee/v2/pkg/resolver"
 	"github.com/ethersphere/bee/v2/pkg/resolver/cidv1"
+	"github.com/ethersphere/bee/v2/pkg/resolver/client/dnslink"
 	"github.com/ethersphere/bee/v2/pkg/resolver/client/ens"
+	"github.com/ethersphere/bee/v2/pkg/resolver/registry"
+	storage "github.com/ethersphere/bee/v2/pkg/storage"
 	"github.com/hashicorp/go-multierror"
 )
 
@@ -79,6 +83,10 @@ func NewMultiResolver(opts ...Option) *MultiResolver {
 
 		// NOTE: if we want to create a specific client based on the TLD
 		// we can do it here.
+		if strings.HasPrefix(c.Endpoint, dnslink.Scheme+"://") {
+			mr.connectDNSClient(c.TLD, c.Endpoint)
+			continue
+		}
 		mr.connectENSClient(c.TLD, c.Address, c.Endpoint)
 	}
 
@@ -112,6 +120,15 @@ func WithDefaultCIDResolver() Option {
 	}
 }
 
+// WithNameRegistry will resolve the names with the given TLD from the Swarm
+// name registry of the owner, looked up through the getter.
+func WithNameRegistry(tld string, getter storage.Getter, owner common.Address) Option {
+	return func(mr *MultiResolver) {
+		tld = registry.NormalizeTLD(tld)
+		mr.PushResolver(tld, registry.New(getter, owner, tld))
+	}
+}
+
 // PushResolver will push a new Resolver to the name resolution chain for the
 // given TLD. An empty TLD will push to the default resolver chain.
 func (mr *MultiResolver) PushResolver(tld string, r resolver.Interface) {
@@ -210,3 +227,17 @@ func (mr *MultiResolver) connectENSClient(tld, address, endpoint string) {
 		mr.PushResolver(tld, ensCl)
 	}
 }
+
+func (mr *MultiResolver) connectDNSClient(tld, endpoint string) {
+	log := mr.logger
+
+	log.Debug("connecting to dns endpoint", "tld", tld, "endpoint", endpoint)
+
+	dnsCl, err := dnslink.NewClient(endpoint)
+	if err != nil {
+		log.Error(err, "resolver on dns endpoint failed", "tld", tld, "endpoint", endpoint)
+	} else {
+		log.Info("connected", "tld", tld, "endpoint", endpoint)
+		mr.PushResolver(tld, dnsCl)
+	}
+}
@@ -10,10 +10,14 @@ import (
 	"reflect"
 	"testing"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethersphere/bee/v2/pkg/log"
 	"github.com/ethersphere/bee/v2/pkg/resolver"
+	"github.com/ethersphere/bee/v2/pkg/resolver/client"
 	"github.com/ethersphere/bee/v2/pkg/resolver/mock"
 	"github.com/ethersphere/bee/v2/pkg/resolver/multiresolver"
+	"github.com/ethersphere/bee/v2/pkg/resolver/registry"
+	"github.com/ethersphere/bee/v2/pkg/storage/inmemchunkstore"
 	"github.com/ethersphere/bee/v2/pkg/swarm"
 )
 
@@ -113,6 +117,41 @@ func TestPushResolver(t *testing.T) {
 	})
 }
 
+func TestDNSClient(t *testing.T) {
+	t.Parallel()
+
+	mr := multiresolver.NewMultiResolver(
+		multiresolver.WithConnectionConfigs([]multiresolver.ConnectionConfig{
+			{TLD: ".com", Endpoint: "dns://127.0.0.1:53"},
+		}),
+	)
+
+	if got := mr.ChainCount(".com"); got != 1 {
+		t.Fatalf("got %d resolvers for .com, want 1", got)
+	}
+	if got := mr.GetChain(".com")[0].(client.Interface).Endpoint(); got != "dns://127.0.0.1:53" {
+		t.Fatalf("got endpoint %s, want dns://127.0.0.1:53", got)
+	}
+}
+
+func TestNameRegistry(t *testing.T) {
+	t.Parallel()
+
+	mr := multiresolver.NewMultiResolver(
+		multiresolver.WithNameRegistry("Swarm", inmemchunkstore.New(), common.Address{}),
+	)
+
+	if got := mr.ChainCount(".swarm"); got != 1 {
+		t.Fatalf("got %d resolvers for .swarm, want 1", got)
+	}
+	if _, ok := mr.GetChain(".swarm")[0].(*registry.Resolver); !ok {
+		t.Fatal("name registry not pushed to the .swarm chain")
+	}
+	if _, err := mr.Resolve("name.swarm"); !errors.Is(err, resolver.ErrNotFound) {
+		t.Fatalf("got error %v, want %v", err, resolver.ErrNotFound)
+	}
+}
+
 func TestResolve(t *testing.T) {
 	t.Parallel()
 
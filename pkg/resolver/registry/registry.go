@@ -0,0 +1,240 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package registry implements a Swarm native name registry. The names of a
+// registry are single owner chunks of the registry owner, each holding a
+// record that points to a feed. The content of a name is the latest update of
+// its feed, so the name is mutable without changing the record.
+//
+// The record of a name is an ordinary single owner chunk with the identifier
+// returned by ID and the payload returned by Record.MarshalBinary, so it can
+// be uploaded with any single owner chunk upload method.
+package registry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethersphere/bee/v2/pkg/cac"
+	"github.com/ethersphere/bee/v2/pkg/crypto"
+	"github.com/ethersphere/bee/v2/pkg/feeds"
+	"github.com/ethersphere/bee/v2/pkg/feeds/factory"
+	"github.com/ethersphere/bee/v2/pkg/resolver"
+	"github.com/ethersphere/bee/v2/pkg/soc"
+	storage "github.com/ethersphere/bee/v2/pkg/storage"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+)
+
+// Make sure Resolver implements the resolver.Interface interface.
+var _ resolver.Interface = (*Resolver)(nil)
+
+var (
+	// ErrInvalidRecord denotes that the record of a name is malformed.
+	ErrInvalidRecord = errors.New("invalid name record")
+	// ErrNameRegistered denotes that the name is registered with another record.
+	ErrNameRegistered = errors.New("name already registered")
+)
+
+const (
+	// idPrefix separates the identifiers of the name records from the other
+	// single owner chunks of the registry owner.
+	idPrefix = "swarm-name:"
+
+	// defaultTimeout is the time limit of resolving a name.
+	defaultTimeout = 30 * time.Second
+
+	minRecordSize = 1 + common.AddressLength + 1
+)
+
+// Record points to the feed holding the content of a name.
+type Record struct {
+	Type  feeds.Type
+	Owner common.Address
+	Topic []byte
+}
+
+// MarshalBinary serializes the record as type | owner | topic.
+func (r Record) MarshalBinary() ([]byte, error) {
+	if len(r.Topic) == 0 || minRecordSize+len(r.Topic)-1 > swarm.ChunkSize {
+		return nil, ErrInvalidRecord
+	}
+	b := make([]byte, 0, minRecordSize-1+len(r.Topic))
+	b = append(b, byte(r.Type))
+	b = append(b, r.Owner.Bytes()...)
+	return append(b, r.Topic...), nil
+}
+
+// UnmarshalBinary deserializes the record.
+func (r *Record) UnmarshalBinary(b []byte) error {
+	if len(b) < minRecordSize || feeds.Type(b[0]).String() == "" {
+		return ErrInvalidRecord
+	}
+	r.Type = feeds.Type(b[0])
+	r.Owner = common.BytesToAddress(b[1 : 1+common.AddressLength])
+	r.Topic = append([]byte{}, b[1+common.AddressLength:]...)
+	return nil
+}
+
+// ID returns the single owner chunk identifier of the record of the name.
+// The names are case insensitive.
+func ID(name string) ([]byte, error) {
+	return crypto.LegacyKeccak256([]byte(idPrefix + strings.ToLower(name)))
+}
+
+// RecordChunk returns the record of the name signed by the registry owner.
+func RecordChunk(signer crypto.Signer, name string, r Record) (swarm.Chunk, error) {
+	id, err := ID(name)
+	if err != nil {
+		return nil, err
+	}
+	payload, err := r.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	ch, err := cac.New(payload)
+	if err != nil {
+		return nil, err
+	}
+	return soc.New(id, ch).Sign(signer)
+}
+
+// Register stores the record of the name signed by the registry owner. The
+// record of a name can not be changed, registering it again with the same
+// record is a no-op.
+func Register(ctx context.Context, store storage.ChunkStore, signer crypto.Signer, name string, r Record) error {
+	ch, err := RecordChunk(signer, name, r)
+	if err != nil {
+		return err
+	}
+	switch got, err := store.Get(ctx, ch.Address()); {
+	case err == nil:
+		if !got.Equal(ch) {
+			return fmt.Errorf("%s: %w", name, ErrNameRegistered)
+		}
+		return nil
+	case !errors.Is(err, storage.ErrNotFound):
+		return err
+	}
+	return store.Put(ctx, ch)
+}
+
+// Resolver resolves the names of a registry.
+type Resolver struct {
+	getter  storage.Getter
+	feeds   feeds.Factory
+	owner   common.Address
+	tld     string
+	timeout time.Duration
+}
+
+// Option is a function that applies an option to a Resolver.
+type Option func(*Resolver)
+
+// WithTimeout sets the time limit of resolving a name.
+func WithTimeout(d time.Duration) Option {
+	return func(r *Resolver) {
+		r.timeout = d
+	}
+}
+
+// New returns a resolver of the names of the registry owned by the owner.
+// The names are resolved with the TLD stripped.
+func New(getter storage.Getter, owner common.Address, tld string, opts ...Option) *Resolver {
+	r := &Resolver{
+		getter:  getter,
+		feeds:   factory.New(getter),
+		owner:   owner,
+		tld:     NormalizeTLD(tld),
+		timeout: defaultTimeout,
+	}
+	for _, o := range opts {
+		o(r)
+	}
+	return r
+}
+
+// Resolve returns the reference of the latest update of the feed of the name.
+func (r *Resolver) Resolve(name string) (swarm.Address, error) {
+	label := strings.ToLower(name)
+	if !strings.HasSuffix(label, r.tld) {
+		return swarm.ZeroAddress, fmt.Errorf("name %s: %w", name, resolver.ErrParse)
+	}
+	if label = strings.TrimSuffix(label, r.tld); label == "" {
+		return swarm.ZeroAddress, fmt.Errorf("name %s: %w", name, resolver.ErrParse)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	rec, err := r.record(ctx, label)
+	if err != nil {
+		return swarm.ZeroAddress, fmt.Errorf("name %s: %w", name, err)
+	}
+
+	l, err := r.feeds.NewLookup(rec.Type, feeds.New(rec.Topic, rec.Owner))
+	if err != nil {
+		return swarm.ZeroAddress, fmt.Errorf("name %s: %w", name, err)
+	}
+	ch, err := feeds.Latest(ctx, l, 0)
+	if err != nil {
+		return swarm.ZeroAddress, fmt.Errorf("name %s: feed lookup: %w", name, err)
+	}
+	if ch == nil {
+		return swarm.ZeroAddress, fmt.Errorf("name %s: no feed update: %w", name, resolver.ErrNotFound)
+	}
+	wc, err := feeds.FromChunk(ch)
+	if err != nil {
+		return swarm.ZeroAddress, fmt.Errorf("name %s: %w", name, resolver.ErrInvalidContentHash)
+	}
+	if _, ref, err := feeds.LegacyPayload(wc); err == nil {
+		return ref, nil
+	}
+	return wc.Address(), nil
+}
+
+// record returns the record of the name.
+func (r *Resolver) record(ctx context.Context, name string) (*Record, error) {
+	id, err := ID(name)
+	if err != nil {
+		return nil, err
+	}
+	addr, err := soc.CreateAddress(id, r.owner.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	ch, err := r.getter.Get(ctx, addr)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, resolver.ErrNotFound
+		}
+		return nil, err
+	}
+	s, err := soc.FromChunk(ch)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidRecord, err)
+	}
+	rec := new(Record)
+	if err := rec.UnmarshalBinary(s.WrappedChunk().Data()[swarm.SpanSize:]); err != nil {
+		return nil, err
+	}
+	return rec, nil
+}
+
+// Close is a no-op, the registry holds no connections.
+func (r *Resolver) Close() error {
+	return nil
+}
+
+// NormalizeTLD returns the TLD with the leading dot, the form the names are
+// routed by.
+func NormalizeTLD(tld string) string {
+	if tld == "" || strings.HasPrefix(tld, ".") {
+		return strings.ToLower(tld)
+	}
+	return "." + strings.ToLower(tld)
+}
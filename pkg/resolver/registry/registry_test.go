@@ -0,0 +1,146 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package registry_test
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethersphere/bee/v2/pkg/crypto"
+	"github.com/ethersphere/bee/v2/pkg/feeds"
+	"github.com/ethersphere/bee/v2/pkg/feeds/sequence"
+	"github.com/ethersphere/bee/v2/pkg/resolver"
+	"github.com/ethersphere/bee/v2/pkg/resolver/registry"
+	"github.com/ethersphere/bee/v2/pkg/storage/inmemchunkstore"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+)
+
+func newSigner(t *testing.T) crypto.Signer {
+	t.Helper()
+
+	pk, err := crypto.GenerateSecp256k1Key()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return crypto.NewDefaultSigner(pk)
+}
+
+func TestRecord(t *testing.T) {
+	t.Parallel()
+
+	want := registry.Record{
+		Type:  feeds.Epoch,
+		Owner: common.BytesToAddress(swarm.RandAddress(t).Bytes()),
+		Topic: []byte("topic"),
+	}
+	b, err := want.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got registry.Record
+	if err := got.UnmarshalBinary(b); err != nil {
+		t.Fatal(err)
+	}
+	if got.Type != want.Type || got.Owner != want.Owner || string(got.Topic) != string(want.Topic) {
+		t.Fatalf("got record %+v, want %+v", got, want)
+	}
+
+	for _, b := range [][]byte{nil, b[:21], append([]byte{0xff}, b[1:]...)} {
+		if err := got.UnmarshalBinary(b); !errors.Is(err, registry.ErrInvalidRecord) {
+			t.Fatalf("got error %v, want %v", err, registry.ErrInvalidRecord)
+		}
+	}
+}
+
+func TestResolve(t *testing.T) {
+	t.Parallel()
+
+	var (
+		ctx            = context.Background()
+		store          = inmemchunkstore.New()
+		registrySigner = newSigner(t)
+		feedSigner     = newSigner(t)
+		topic          = []byte("website")
+	)
+	registryOwner, err := registrySigner.EthereumAddress()
+	if err != nil {
+		t.Fatal(err)
+	}
+	feedOwner, err := feedSigner.EthereumAddress()
+	if err != nil {
+		t.Fatal(err)
+	}
+	updater, err := sequence.NewUpdater(store, feedSigner, topic)
+	if err != nil {
+		t.Fatal(err)
+	}
+	update := func(t *testing.T, at int64) swarm.Address {
+		t.Helper()
+		ref := swarm.RandAddress(t)
+		payload := make([]byte, 8, 8+swarm.HashSize)
+		binary.BigEndian.PutUint64(payload, uint64(at))
+		if err := updater.Update(ctx, at, append(payload, ref.Bytes()...)); err != nil {
+			t.Fatal(err)
+		}
+		return ref
+	}
+
+	rec := registry.Record{Type: feeds.Sequence, Owner: feedOwner, Topic: topic}
+	if err := registry.Register(ctx, store, registrySigner, "mysite", rec); err != nil {
+		t.Fatal(err)
+	}
+
+	r := registry.New(store, registryOwner, "swarm")
+
+	t.Run("no update", func(t *testing.T) {
+		if _, err := r.Resolve("mysite.swarm"); !errors.Is(err, resolver.ErrNotFound) {
+			t.Fatalf("got error %v, want %v", err, resolver.ErrNotFound)
+		}
+	})
+
+	t.Run("latest update", func(t *testing.T) {
+		update(t, 1)
+		want := update(t, 2)
+
+		got, err := r.Resolve("MySite.swarm")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !got.Equal(want) {
+			t.Fatalf("got address %s, want %s", got, want)
+		}
+	})
+
+	t.Run("not registered", func(t *testing.T) {
+		if _, err := r.Resolve("other.swarm"); !errors.Is(err, resolver.ErrNotFound) {
+			t.Fatalf("got error %v, want %v", err, resolver.ErrNotFound)
+		}
+		other := registry.New(store, feedOwner, ".swarm")
+		if _, err := other.Resolve("mysite.swarm"); !errors.Is(err, resolver.ErrNotFound) {
+			t.Fatalf("got error %v, want %v", err, resolver.ErrNotFound)
+		}
+	})
+
+	t.Run("invalid name", func(t *testing.T) {
+		for _, name := range []string{"mysite.eth", ".swarm", "mysite"} {
+			if _, err := r.Resolve(name); !errors.Is(err, resolver.ErrParse) {
+				t.Fatalf("%s: got error %v, want %v", name, err, resolver.ErrParse)
+			}
+		}
+	})
+
+	t.Run("register again", func(t *testing.T) {
+		if err := registry.Register(ctx, store, registrySigner, "mysite", rec); err != nil {
+			t.Fatal(err)
+		}
+		rec := registry.Record{Type: feeds.Sequence, Owner: feedOwner, Topic: []byte("other")}
+		if err := registry.Register(ctx, store, registrySigner, "MYSITE", rec); !errors.Is(err, registry.ErrNameRegistered) {
+			t.Fatalf("got error %v, want %v", err, registry.ErrNameRegistered)
+		}
+	})
+}
@@ -40,8 +40,8 @@ func (db *DB) cacheWorker(ctx context.Context) {
 			}
 
 			evict := size - capc
-			if evict < db.reserveOptions.cacheMinEvictCount { // evict at least a min count
-				evict = db.reserveOptions.cacheMinEvictCount
+			if minEvict := db.reserveOptions.cacheMinEvictCount * swarm.ChunkWithSpanSize; evict < minEvict { // evict at least a min size
+				evict = minEvict
 			}
 
 			dur := captureDuration(time.Now())
@@ -51,7 +51,7 @@ func (db *DB) cacheWorker(ctx context.Context) {
 				db.metrics.MethodCalls.WithLabelValues("cachestore", "RemoveOldest", "failure").Inc()
 				db.logger.Warning("cache eviction failure", "error", err)
 			} else {
-				db.logger.Debug("cache eviction finished", "evicted", evict, "duration_sec", dur())
+				db.logger.Debug("cache eviction finished", "evicted_bytes", evict, "duration_sec", dur())
 				db.metrics.MethodCalls.WithLabelValues("cachestore", "RemoveOldest", "success").Inc()
 			}
 			db.triggerCacheEviction()
@@ -65,11 +65,18 @@ func (db *DB) cacheWorker(ctx context.Context) {
 func (db *DB) Lookup() storage.Getter {
 	return getterWithMetrics{
 		storage.GetterFunc(func(ctx context.Context, address swarm.Address) (swarm.Chunk, error) {
-			ch, err := db.cacheObj.Getter(db.storage).Get(ctx, address)
+			ch, hit, err := db.cacheObj.Get(ctx, db.storage, address)
 			switch {
 			case err == nil:
+				// the chunks of the other components are found without a cache entry
+				if hit {
+					db.metrics.CacheHits.WithLabelValues(db.cacheObj.Policy().String()).Inc()
+				} else {
+					db.metrics.CacheMisses.WithLabelValues(db.cacheObj.Policy().String()).Inc()
+				}
 				return ch, nil
 			case errors.Is(err, storage.ErrNotFound):
+				db.metrics.CacheMisses.WithLabelValues(db.cacheObj.Policy().String()).Inc()
 				// here we would ideally have nothing to do but just to return this
 				// error to the client. The commit is mainly done to end the txn.
 				return nil, err
@@ -120,7 +127,8 @@ func (db *DB) triggerCacheEviction() {
 		size = db.cacheObj.Size()
 		capc = db.cacheObj.Capacity()
 	)
-	db.metrics.CacheSize.Set(float64(size))
+	db.metrics.CacheSize.Set(float64(db.cacheObj.Count()))
+	db.metrics.CacheBytes.Set(float64(size))
 
 	if size > capc {
 		db.events.Trigger(cacheOverCapacity)
@@ -96,7 +96,7 @@ func TestCacheStore(t *testing.T) {
 		testCacheStore(t, func() (*storer.DB, error) {
 
 			opts := dbTestOps(swarm.RandAddress(t), 100, nil, nil, time.Second)
-			opts.CacheCapacity = 10
+			opts.CacheCapacity = 10 * swarm.ChunkWithSpanSize
 
 			return storer.New(context.Background(), "", opts)
 		})
@@ -105,16 +105,37 @@ func TestCacheStore(t *testing.T) {
 		t.Parallel()
 
 		opts := dbTestOps(swarm.RandAddress(t), 100, nil, nil, time.Second)
-		opts.CacheCapacity = 10
+		opts.CacheCapacity = 10 * swarm.ChunkWithSpanSize
 
 		testCacheStore(t, diskStorer(t, opts))
 	})
+	for _, policy := range []string{"lfu", "2q"} {
+		t.Run(policy, func(t *testing.T) {
+			t.Parallel()
+
+			opts := dbTestOps(swarm.RandAddress(t), 100, nil, nil, time.Second)
+			opts.CacheCapacity = 10 * swarm.ChunkWithSpanSize
+			opts.CacheEvictionPolicy = policy
+
+			testCacheStore(t, diskStorer(t, opts))
+		})
+	}
+	t.Run("unknown policy", func(t *testing.T) {
+		t.Parallel()
+
+		opts := dbTestOps(swarm.RandAddress(t), 100, nil, nil, time.Second)
+		opts.CacheEvictionPolicy = "mru"
+
+		if _, err := storer.New(context.Background(), "", opts); err == nil {
+			t.Fatal("expected error for the unknown cache eviction policy")
+		}
+	})
 }
 
 func BenchmarkCachePutter(b *testing.B) {
 	baseAddr := swarm.RandAddress(b)
 	opts := dbTestOps(baseAddr, 10000, nil, nil, time.Second)
-	opts.CacheCapacity = 10
+	opts.CacheCapacity = 10 * swarm.ChunkWithSpanSize
 	storer, err := diskStorer(b, opts)()
 	if err != nil {
 		b.Fatal(err)
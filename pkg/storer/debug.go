@@ -20,24 +20,34 @@ type UploadStat struct {
 	TotalUploaded uint64
 	TotalSynced   uint64
 	PendingUpload uint64
+	// PendingBytes is the byte size of the chunks pending upload.
+	PendingBytes uint64
 }
 
 type PinningStat struct {
 	TotalCollections int
 	TotalChunks      int
+	// TotalBytes is the byte size of the pinned chunks.
+	TotalBytes int
 }
 
 type CacheStat struct {
-	Size     int
+	// Size is the number of the chunks in the cache.
+	Size int
+	// Bytes is the byte size of the chunks in the cache.
+	Bytes int
+	// Capacity is the byte capacity of the cache.
 	Capacity int
 }
 
 type ReserveStat struct {
 	SizeWithinRadius int
 	TotalSize        int
-	Capacity         int
-	LastBinIDs       []uint64
-	Epoch            uint64
+	// TotalBytes is the byte size of the chunks in the reserve.
+	TotalBytes int
+	Capacity   int
+	LastBinIDs []uint64
+	Epoch      uint64
 }
 
 type ChunkStoreStat struct {
@@ -120,6 +130,7 @@ func (db *DB) DebugInfo(ctx context.Context) (Info, error) {
 	var (
 		collections int
 		chunkCount  int
+		pinnedBytes int
 	)
 	eg.Go(func() error {
 		return pinstore.IterateCollectionStats(
@@ -135,6 +146,7 @@ func (db *DB) DebugInfo(ctx context.Context) (Info, error) {
 
 				collections++
 				chunkCount += int(stat.Total - stat.DupInCollection)
+				pinnedBytes += int(stat.Size)
 				return false, nil
 			},
 		)
@@ -144,6 +156,7 @@ func (db *DB) DebugInfo(ctx context.Context) (Info, error) {
 		reserveCapacity         int
 		reserveSize             int
 		reserveSizeWithinRadius int
+		reserveBytes            int
 
 		lastBinIDs []uint64
 		epoch      uint64
@@ -151,6 +164,7 @@ func (db *DB) DebugInfo(ctx context.Context) (Info, error) {
 	if db.reserve != nil {
 		reserveCapacity = db.reserve.Capacity()
 		reserveSize = db.reserve.Size()
+		reserveBytes = db.reserve.Bytes()
 		eg.Go(func() error {
 			return db.reserve.IterateChunksItems(db.reserve.Radius(), func(ci *reserve.ChunkBinItem) (bool, error) {
 				reserveSizeWithinRadius++
@@ -169,7 +183,8 @@ func (db *DB) DebugInfo(ctx context.Context) (Info, error) {
 		return Info{}, err
 	}
 
-	cacheSize := db.cacheObj.Size()
+	cacheSize := db.cacheObj.Count()
+	cacheBytes := db.cacheObj.Size()
 	cacheCapacity := db.cacheObj.Capacity()
 
 	return Info{
@@ -177,18 +192,22 @@ func (db *DB) DebugInfo(ctx context.Context) (Info, error) {
 			TotalUploaded: uploaded,
 			TotalSynced:   synced,
 			PendingUpload: pendingUpload,
+			PendingBytes:  db.uploadSize.Bytes(),
 		},
 		Pinning: PinningStat{
 			TotalCollections: collections,
 			TotalChunks:      chunkCount,
+			TotalBytes:       pinnedBytes,
 		},
 		Cache: CacheStat{
 			Size:     int(cacheSize),
+			Bytes:    int(cacheBytes),
 			Capacity: int(cacheCapacity),
 		},
 		Reserve: ReserveStat{
 			SizeWithinRadius: reserveSizeWithinRadius,
 			TotalSize:        reserveSize,
+			TotalBytes:       reserveBytes,
 			Capacity:         reserveCapacity,
 			LastBinIDs:       lastBinIDs,
 			Epoch:            epoch,
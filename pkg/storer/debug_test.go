@@ -70,10 +70,12 @@ func testDebugInfo(t *testing.T, newStorer func() (*storer.DB, swarm.Address, er
 				TotalUploaded: 10,
 				TotalSynced:   0,
 				PendingUpload: 10,
+				PendingBytes:  10 * swarm.ChunkWithSpanSize,
 			},
 			Pinning: storer.PinningStat{
 				TotalCollections: 1,
 				TotalChunks:      10,
+				TotalBytes:       10 * swarm.ChunkWithSpanSize,
 			},
 			ChunkStore: storer.ChunkStoreStat{
 				TotalChunks:    10,
@@ -81,7 +83,7 @@ func testDebugInfo(t *testing.T, newStorer func() (*storer.DB, swarm.Address, er
 				ReferenceCount: 20,
 			},
 			Cache: storer.CacheStat{
-				Capacity: 1000000,
+				Capacity: int(storer.DefaultCacheCapacity),
 			},
 			Reserve: storer.ReserveStat{
 				Capacity:   100,
@@ -128,7 +130,8 @@ func testDebugInfo(t *testing.T, newStorer func() (*storer.DB, swarm.Address, er
 			},
 			Cache: storer.CacheStat{
 				Size:     10,
-				Capacity: 1000000,
+				Bytes:    10 * swarm.ChunkWithSpanSize,
+				Capacity: int(storer.DefaultCacheCapacity),
 			},
 			Reserve: storer.ReserveStat{
 				Capacity:   100,
@@ -179,11 +182,12 @@ func testDebugInfo(t *testing.T, newStorer func() (*storer.DB, swarm.Address, er
 				ReferenceCount: 10,
 			},
 			Cache: storer.CacheStat{
-				Capacity: 1000000,
+				Capacity: int(storer.DefaultCacheCapacity),
 			},
 			Reserve: storer.ReserveStat{
 				SizeWithinRadius: 10,
 				TotalSize:        10,
+				TotalBytes:       10 * swarm.ChunkSize, // the generated chunks have no span
 				Capacity:         100,
 				LastBinIDs:       ids,
 				Epoch:            epoch,
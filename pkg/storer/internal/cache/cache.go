@@ -10,11 +10,11 @@ import (
 	"errors"
 	"fmt"
 	"runtime"
-	"strconv"
 	"sync/atomic"
 	"time"
 
 	storage "github.com/ethersphere/bee/v2/pkg/storage"
+	"github.com/ethersphere/bee/v2/pkg/storer/internal/chunkstore"
 	"github.com/ethersphere/bee/v2/pkg/storer/internal/transaction"
 	"github.com/ethersphere/bee/v2/pkg/swarm"
 	"golang.org/x/sync/errgroup"
@@ -26,7 +26,19 @@ var now = time.Now
 // exported for migration
 type CacheEntryItem = cacheEntry
 
-const cacheEntrySize = swarm.HashSize + 8
+const (
+	// cacheEntrySizeV1 is the size of the cache entries stored before the
+	// number of the hits was added.
+	cacheEntrySizeV1 = swarm.HashSize + 8
+	// cacheEntrySizeV2 is the size of the cache entries stored before the
+	// size of the chunk was added.
+	cacheEntrySizeV2 = cacheEntrySizeV1 + 8
+	cacheEntrySize   = cacheEntrySizeV2 + 8
+
+	// reorderBatchSize is the number of the entries moved in one transaction
+	// when the cache order index is rebuilt for another eviction policy.
+	reorderBatchSize = 10_000
+)
 
 var _ storage.Item = (*cacheEntry)(nil)
 
@@ -40,33 +52,112 @@ var (
 // part of the reserve but are potentially useful to store for obtaining bandwidth
 // incentives.
 type Cache struct {
+	count    atomic.Int64
 	size     atomic.Int64
-	capacity int
+	hot      atomic.Int64 // byte size of the entries hit since they were added.
+	capacity uint64
+	policy   Policy
 	glock    *multex.Multex // blocks Get and Put ops while shallow copy is running.
 }
 
-// New creates a new Cache component with the specified capacity. The store is used
-// here only to read the initial state of the cache before shutdown if there was
-// any.
-func New(ctx context.Context, store storage.Reader, capacity uint64) (*Cache, error) {
-	count, err := store.Count(&cacheEntry{})
+// New creates a new Cache component with the specified capacity in bytes and
+// eviction policy. The store is used to read the initial state of the cache
+// before shutdown if there was any. If the cache was used with another policy,
+// the cache order index is rebuilt for the new one.
+func New(ctx context.Context, store transaction.Storage, capacity uint64, policy Policy) (*Cache, error) {
+	stored := &cachePolicyItem{}
+	err := store.IndexStore().Get(stored)
+	missing := errors.Is(err, storage.ErrNotFound)
+	switch {
+	case missing:
+		// the cache order index predating the eviction policies is in the LRU order.
+		stored.Name = LRU.String()
+	case err != nil:
+		return nil, fmt.Errorf("failed getting cache policy: %w", err)
+	}
+	prev, err := ParsePolicy(stored.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Cache{capacity: capacity, policy: policy, glock: multex.New()}
+
+	var entries []*cacheEntry
+	err = store.IndexStore().Iterate(
+		storage.Query{
+			Factory: func() storage.Item { return &cacheEntry{} },
+		},
+		func(res storage.Result) (bool, error) {
+			entry := res.Entry.(*cacheEntry)
+			c.count.Add(1)
+			c.size.Add(int64(entry.Size))
+			if entry.Hits > 0 {
+				c.hot.Add(int64(entry.Size))
+			}
+			if prev != policy {
+				entries = append(entries, entry)
+			}
+			return false, nil
+		},
+	)
 	if err != nil {
-		return nil, fmt.Errorf("failed counting cache entries: %w", err)
+		return nil, fmt.Errorf("failed iterating cache entries: %w", err)
 	}
 
-	c := &Cache{capacity: int(capacity), glock: multex.New()}
-	c.size.Store(int64(count))
+	if prev != policy || missing {
+		if err := c.reorder(ctx, store, prev, entries); err != nil {
+			return nil, fmt.Errorf("failed reordering cache from %s to %s: %w", prev, policy, err)
+		}
+	}
 
 	return c, nil
 }
 
-// Size returns the current size of the cache.
+// reorder moves the entries in the cache order index from the keys of the
+// previous policy to the keys of the policy of the cache.
+func (c *Cache) reorder(ctx context.Context, store transaction.Storage, prev Policy, entries []*cacheEntry) error {
+	for len(entries) > 0 {
+		batch := entries[:min(len(entries), reorderBatchSize)]
+		entries = entries[len(batch):]
+
+		err := store.Run(ctx, func(s transaction.Store) error {
+			for _, entry := range batch {
+				err := s.IndexStore().Delete(&cacheOrderIndex{Key: prev.orderKey(entry)})
+				if err != nil {
+					return fmt.Errorf("failed deleting cache order index: %w", err)
+				}
+				err = s.IndexStore().Put(&cacheOrderIndex{Key: c.policy.orderKey(entry)})
+				if err != nil {
+					return fmt.Errorf("failed adding cache order index: %w", err)
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return store.Run(ctx, func(s transaction.Store) error {
+		return s.IndexStore().Put(&cachePolicyItem{Name: c.policy.String()})
+	})
+}
+
+// Count returns the number of the chunks in the cache.
+func (c *Cache) Count() uint64 {
+	return uint64(c.count.Load())
+}
+
+// Size returns the current byte size of the chunks in the cache.
 func (c *Cache) Size() uint64 {
 	return uint64(c.size.Load())
 }
 
-// Capacity returns the capacity of the cache.
-func (c *Cache) Capacity() uint64 { return uint64(c.capacity) }
+// Capacity returns the byte capacity of the cache.
+func (c *Cache) Capacity() uint64 { return c.capacity }
+
+// Policy returns the eviction policy of the cache.
+func (c *Cache) Policy() Policy { return c.policy }
 
 // Putter returns a Storage.Putter instance which adds the chunk to the underlying
 // chunkstore and also adds a Cache entry for the chunk.
@@ -91,15 +182,13 @@ func (c *Cache) Putter(store transaction.Storage) storage.Putter {
 		}
 
 		newEntry.AccessTimestamp = now().UnixNano()
+		newEntry.Size = uint64(len(chunk.Data()))
 		err = trx.IndexStore().Put(newEntry)
 		if err != nil {
 			return fmt.Errorf("failed adding cache entry: %w", err)
 		}
 
-		err = trx.IndexStore().Put(&cacheOrderIndex{
-			Address:         newEntry.Address,
-			AccessTimestamp: newEntry.AccessTimestamp,
-		})
+		err = trx.IndexStore().Put(&cacheOrderIndex{Key: c.policy.orderKey(newEntry)})
 		if err != nil {
 			return fmt.Errorf("failed adding cache order index: %w", err)
 		}
@@ -113,7 +202,8 @@ func (c *Cache) Putter(store transaction.Storage) storage.Putter {
 			return fmt.Errorf("batch commit: %w", err)
 		}
 
-		c.size.Add(1)
+		c.count.Add(1)
+		c.size.Add(int64(newEntry.Size))
 
 		return nil
 	})
@@ -125,117 +215,146 @@ func (c *Cache) Putter(store transaction.Storage) storage.Putter {
 // of this getter to rollback the operation.
 func (c *Cache) Getter(store transaction.Storage) storage.Getter {
 	return storage.GetterFunc(func(ctx context.Context, address swarm.Address) (swarm.Chunk, error) {
+		ch, _, err := c.Get(ctx, store, address)
+		return ch, err
+	})
+}
 
-		c.glock.Lock(address.ByteString())
-		defer c.glock.Unlock(address.ByteString())
+// Get returns the chunk from the chunkstore and updates the cache indexes if
+// it is part of the cache, as the Getter does. It reports whether the chunk
+// has a cache entry, the chunks stored only by the other components of the
+// store are returned without one.
+func (c *Cache) Get(ctx context.Context, store transaction.Storage, address swarm.Address) (swarm.Chunk, bool, error) {
+	c.glock.Lock(address.ByteString())
+	defer c.glock.Unlock(address.ByteString())
 
-		trx, done := store.NewTransaction(ctx)
-		defer done()
+	trx, done := store.NewTransaction(ctx)
+	defer done()
 
-		ch, err := trx.ChunkStore().Get(ctx, address)
-		if err != nil {
-			return nil, err
-		}
+	ch, err := trx.ChunkStore().Get(ctx, address)
+	if err != nil {
+		return nil, false, err
+	}
 
-		// check if there is an entry in Cache. As this is the download path, we do
-		// a best-effort operation. So in case of any error we return the chunk.
-		entry := &cacheEntry{Address: address}
-		err = trx.IndexStore().Get(entry)
-		if err != nil {
-			if errors.Is(err, storage.ErrNotFound) {
-				return ch, nil
-			}
-			return nil, fmt.Errorf("unexpected error getting indexstore entry: %w", err)
+	// check if there is an entry in Cache. As this is the download path, we do
+	// a best-effort operation. So in case of any error we return the chunk.
+	entry := &cacheEntry{Address: address}
+	err = trx.IndexStore().Get(entry)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return ch, false, nil
 		}
+		return nil, false, fmt.Errorf("unexpected error getting indexstore entry: %w", err)
+	}
 
-		err = trx.IndexStore().Delete(&cacheOrderIndex{
-			Address:         entry.Address,
-			AccessTimestamp: entry.AccessTimestamp,
-		})
-		if err != nil {
-			return nil, fmt.Errorf("failed deleting cache order index: %w", err)
-		}
+	err = trx.IndexStore().Delete(&cacheOrderIndex{Key: c.policy.orderKey(entry)})
+	if err != nil {
+		return nil, false, fmt.Errorf("failed deleting cache order index: %w", err)
+	}
 
-		entry.AccessTimestamp = now().UnixNano()
-		err = trx.IndexStore().Put(&cacheOrderIndex{
-			Address:         entry.Address,
-			AccessTimestamp: entry.AccessTimestamp,
-		})
-		if err != nil {
-			return nil, fmt.Errorf("failed adding cache order index: %w", err)
-		}
+	entry.AccessTimestamp = now().UnixNano()
+	entry.Hits++
+	err = trx.IndexStore().Put(&cacheOrderIndex{Key: c.policy.orderKey(entry)})
+	if err != nil {
+		return nil, false, fmt.Errorf("failed adding cache order index: %w", err)
+	}
 
-		err = trx.IndexStore().Put(entry)
-		if err != nil {
-			return nil, fmt.Errorf("failed adding cache entry: %w", err)
-		}
+	err = trx.IndexStore().Put(entry)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed adding cache entry: %w", err)
+	}
 
-		err = trx.Commit()
-		if err != nil {
-			return nil, fmt.Errorf("batch commit: %w", err)
-		}
+	err = trx.Commit()
+	if err != nil {
+		return nil, false, fmt.Errorf("batch commit: %w", err)
+	}
 
-		return ch, nil
-	})
+	if entry.Hits == 1 {
+		c.hot.Add(int64(entry.Size))
+	}
+
+	return ch, true, nil
 }
 
-// RemoveOldest removes the oldest cache entries from the store. The count
-// specifies the number of entries to remove.
-func (c *Cache) RemoveOldest(ctx context.Context, st transaction.Storage, count uint64) error {
+// RemoveOldest removes the cache entries from the store in the order of the
+// eviction policy. The size specifies the number of bytes to remove, the
+// entries are removed until their size reaches it.
+func (c *Cache) RemoveOldest(ctx context.Context, st transaction.Storage, size uint64) error {
 
-	if count <= 0 {
+	if size <= 0 {
 		return nil
 	}
 
-	evictItems := make([]*cacheEntry, 0, count)
-	err := st.IndexStore().Iterate(
-		storage.Query{
-			Factory:      func() storage.Item { return &cacheOrderIndex{} },
-			ItemProperty: storage.QueryItemID,
-		},
-		func(res storage.Result) (bool, error) {
-			accessTime, addr, err := idFromKey(res.ID)
-			if err != nil {
-				return false, fmt.Errorf("failed to parse cache order index %s: %w", res.ID, err)
-			}
-			entry := &cacheEntry{
-				Address:         addr,
-				AccessTimestamp: accessTime,
-			}
-			evictItems = append(evictItems, entry)
-			count--
-			return count == 0, nil
-		},
+	var (
+		evictAddrs []swarm.Address
+		evictSize  uint64
+		seen       = make(map[string]struct{})
 	)
-	if err != nil {
-		return fmt.Errorf("failed iterating over cache order index: %w", err)
+	for _, r := range c.policy.evictionRanges(size, c.Size(), uint64(c.hot.Load()), c.Capacity()) {
+		n := min(r.size, size-min(evictSize, size))
+		if n == 0 {
+			continue
+		}
+		err := st.IndexStore().Iterate(
+			storage.Query{
+				Factory:      func() storage.Item { return &cacheOrderIndex{} },
+				Prefix:       r.prefix,
+				ItemProperty: storage.QueryItemID,
+			},
+			func(res storage.Result) (bool, error) {
+				addr, err := addressFromKey(res.ID)
+				if err != nil {
+					return false, fmt.Errorf("failed to parse cache order index %s: %w", res.ID, err)
+				}
+				if _, ok := seen[addr.ByteString()]; ok {
+					return false, nil
+				}
+				entry := &cacheEntry{Address: addr}
+				if err := st.IndexStore().Get(entry); err != nil {
+					return false, fmt.Errorf("failed getting cache entry: %w", err)
+				}
+				seen[addr.ByteString()] = struct{}{}
+				evictAddrs = append(evictAddrs, addr)
+				evictSize += entry.Size
+				n -= min(n, entry.Size)
+				return n == 0, nil
+			},
+		)
+		if err != nil {
+			return fmt.Errorf("failed iterating over cache order index: %w", err)
+		}
 	}
 
 	eg, ctx := errgroup.WithContext(ctx)
 	eg.SetLimit(runtime.NumCPU())
 
-	for _, item := range evictItems {
-		func(item *cacheEntry) {
+	for _, addr := range evictAddrs {
+		func(addr swarm.Address) {
 			eg.Go(func() error {
-				c.glock.Lock(item.Address.ByteString())
-				defer c.glock.Unlock(item.Address.ByteString())
+				c.glock.Lock(addr.ByteString())
+				defer c.glock.Unlock(addr.ByteString())
+				entry := &cacheEntry{Address: addr}
 				err := st.Run(ctx, func(s transaction.Store) error {
+					if err := s.IndexStore().Get(entry); err != nil {
+						return fmt.Errorf("failed getting cache entry: %w", err)
+					}
 					return errors.Join(
-						s.IndexStore().Delete(item),
-						s.IndexStore().Delete(&cacheOrderIndex{
-							Address:         item.Address,
-							AccessTimestamp: item.AccessTimestamp,
-						}),
-						s.ChunkStore().Delete(ctx, item.Address),
+						s.IndexStore().Delete(entry),
+						s.IndexStore().Delete(&cacheOrderIndex{Key: c.policy.orderKey(entry)}),
+						s.ChunkStore().Delete(ctx, entry.Address),
 					)
 				})
 				if err != nil {
 					return err
 				}
-				c.size.Add(-1)
+				c.count.Add(-1)
+				c.size.Add(-int64(entry.Size))
+				if entry.Hits > 0 {
+					c.hot.Add(-int64(entry.Size))
+				}
 				return nil
 			})
-		}(item)
+		}(addr)
 	}
 
 	return eg.Wait()
@@ -277,6 +396,11 @@ func (c *Cache) ShallowCopy(
 			_ = store.Run(ctx, func(s transaction.Store) error { return s.ChunkStore().Delete(ctx, addr) })
 			continue
 		}
+		size, err := chunkstore.Size(store.IndexStore(), addr)
+		if err != nil {
+			return fmt.Errorf("failed getting chunk size %s: %w", addr, err)
+		}
+		entry.Size = size
 		entries = append(entries, entry)
 	}
 
@@ -285,12 +409,18 @@ func (c *Cache) ShallowCopy(
 	}
 
 	//consider only the amount that can fit, the rest should be deleted from the chunkstore.
-	if len(entries) > c.capacity {
-		for _, addr := range entries[:len(entries)-c.capacity] {
-			_ = store.Run(ctx, func(s transaction.Store) error { return s.ChunkStore().Delete(ctx, addr.Address) })
-		}
-		entries = entries[len(entries)-c.capacity:]
+	var (
+		fit  = len(entries)
+		size uint64
+	)
+	for fit > 0 && size+entries[fit-1].Size <= c.capacity {
+		fit--
+		size += entries[fit].Size
+	}
+	for _, entry := range entries[:fit] {
+		_ = store.Run(ctx, func(s transaction.Store) error { return s.ChunkStore().Delete(ctx, entry.Address) })
 	}
+	entries = entries[fit:]
 
 	err = store.Run(ctx, func(s transaction.Store) error {
 		for _, entry := range entries {
@@ -298,10 +428,7 @@ func (c *Cache) ShallowCopy(
 			if err != nil {
 				return fmt.Errorf("failed adding entry %s: %w", entry, err)
 			}
-			err = s.IndexStore().Put(&cacheOrderIndex{
-				Address:         entry.Address,
-				AccessTimestamp: entry.AccessTimestamp,
-			})
+			err = s.IndexStore().Put(&cacheOrderIndex{Key: c.policy.orderKey(entry)})
 			if err != nil {
 				return fmt.Errorf("failed adding cache order index: %w", err)
 			}
@@ -312,13 +439,18 @@ func (c *Cache) ShallowCopy(
 		return err
 	}
 
-	c.size.Add(int64(len(entries)))
+	c.count.Add(int64(len(entries)))
+	c.size.Add(int64(size))
 	return nil
 }
 
 type cacheEntry struct {
 	Address         swarm.Address
 	AccessTimestamp int64
+	// Hits is the number of the accesses of the entry since it was added.
+	Hits uint64
+	// Size is the byte size of the chunk data.
+	Size uint64
 }
 
 func (c *cacheEntry) ID() string { return c.Address.ByteString() }
@@ -335,16 +467,24 @@ func (c *cacheEntry) Marshal() ([]byte, error) {
 	}
 	copy(entryBuf[:swarm.HashSize], c.Address.Bytes())
 	binary.LittleEndian.PutUint64(entryBuf[swarm.HashSize:], uint64(c.AccessTimestamp))
+	binary.LittleEndian.PutUint64(entryBuf[cacheEntrySizeV1:], c.Hits)
+	binary.LittleEndian.PutUint64(entryBuf[cacheEntrySizeV2:], c.Size)
 	return entryBuf, nil
 }
 
 func (c *cacheEntry) Unmarshal(buf []byte) error {
-	if len(buf) != cacheEntrySize {
+	if len(buf) != cacheEntrySize && len(buf) != cacheEntrySizeV2 && len(buf) != cacheEntrySizeV1 {
 		return errUnmarshalCacheEntryInvalidSize
 	}
 	newEntry := new(cacheEntry)
 	newEntry.Address = swarm.NewAddress(append(make([]byte, 0, swarm.HashSize), buf[:swarm.HashSize]...))
 	newEntry.AccessTimestamp = int64(binary.LittleEndian.Uint64(buf[swarm.HashSize:]))
+	if len(buf) >= cacheEntrySizeV2 {
+		newEntry.Hits = binary.LittleEndian.Uint64(buf[cacheEntrySizeV1:])
+	}
+	if len(buf) == cacheEntrySize {
+		newEntry.Size = binary.LittleEndian.Uint64(buf[cacheEntrySizeV2:])
+	}
 	*c = *newEntry
 	return nil
 }
@@ -356,22 +496,27 @@ func (c *cacheEntry) Clone() storage.Item {
 	return &cacheEntry{
 		Address:         c.Address.Clone(),
 		AccessTimestamp: c.AccessTimestamp,
+		Hits:            c.Hits,
+		Size:            c.Size,
 	}
 }
 
 func (c cacheEntry) String() string {
 	return fmt.Sprintf(
-		"cacheEntry { Address: %s AccessTimestamp: %s }",
+		"cacheEntry { Address: %s AccessTimestamp: %s Hits: %d Size: %d }",
 		c.Address,
 		time.Unix(c.AccessTimestamp, 0).UTC().Format(time.RFC3339),
+		c.Hits,
+		c.Size,
 	)
 }
 
 var _ storage.Item = (*cacheOrderIndex)(nil)
 
+// cacheOrderIndex orders the cache entries for the eviction, the key is
+// assigned by the eviction policy and ends with the address of the entry.
 type cacheOrderIndex struct {
-	AccessTimestamp int64
-	Address         swarm.Address
+	Key string
 }
 
 func keyFromID(ts int64, addr swarm.Address) string {
@@ -379,19 +524,14 @@ func keyFromID(ts int64, addr swarm.Address) string {
 	return tsStr + addr.ByteString()
 }
 
-func idFromKey(key string) (int64, swarm.Address, error) {
-	ts := key[:len(key)-swarm.HashSize]
-	addr := key[len(key)-swarm.HashSize:]
-	n, err := strconv.ParseInt(ts, 10, 64)
-	if err != nil {
-		return 0, swarm.ZeroAddress, err
+func addressFromKey(key string) (swarm.Address, error) {
+	if len(key) < swarm.HashSize {
+		return swarm.ZeroAddress, errors.New("key too short")
 	}
-	return n, swarm.NewAddress([]byte(addr)), nil
+	return swarm.NewAddress([]byte(key[len(key)-swarm.HashSize:])), nil
 }
 
-func (c *cacheOrderIndex) ID() string {
-	return keyFromID(c.AccessTimestamp, c.Address)
-}
+func (c *cacheOrderIndex) ID() string { return c.Key }
 
 func (cacheOrderIndex) Namespace() string { return "cacheOrderIndex" }
 
@@ -407,16 +547,9 @@ func (c *cacheOrderIndex) Clone() storage.Item {
 	if c == nil {
 		return nil
 	}
-	return &cacheOrderIndex{
-		AccessTimestamp: c.AccessTimestamp,
-		Address:         c.Address.Clone(),
-	}
+	return &cacheOrderIndex{Key: c.Key}
 }
 
 func (c cacheOrderIndex) String() string {
-	return fmt.Sprintf(
-		"cacheOrderIndex { AccessTimestamp: %d Address: %s }",
-		c.AccessTimestamp,
-		c.Address.ByteString(),
-	)
+	return fmt.Sprintf("cacheOrderIndex { Key: %s }", c.Key)
 }
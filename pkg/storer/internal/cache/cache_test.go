@@ -8,12 +8,15 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io/fs"
 	"math"
 	"os"
 	"sync"
 	"testing"
 	"time"
 
+	"github.com/ethersphere/bee/v2/pkg/cac"
+	"github.com/ethersphere/bee/v2/pkg/sharky"
 	storage "github.com/ethersphere/bee/v2/pkg/storage"
 	"github.com/ethersphere/bee/v2/pkg/storage/inmemchunkstore"
 	"github.com/ethersphere/bee/v2/pkg/storage/inmemstore"
@@ -22,7 +25,9 @@ import (
 	"github.com/ethersphere/bee/v2/pkg/storer/internal/cache"
 	"github.com/ethersphere/bee/v2/pkg/storer/internal/transaction"
 	"github.com/ethersphere/bee/v2/pkg/swarm"
+	"github.com/ethersphere/bee/v2/pkg/util/testutil"
 	"github.com/google/go-cmp/cmp"
+	"github.com/spf13/afero"
 )
 
 func TestCacheEntryItem(t *testing.T) {
@@ -53,6 +58,8 @@ func TestCacheEntryItem(t *testing.T) {
 			Item: &cache.CacheEntry{
 				Address:         swarm.NewAddress(storagetest.MaxAddressBytes[:]),
 				AccessTimestamp: math.MaxInt64,
+				Hits:            math.MaxUint64,
+				Size:            math.MaxUint64,
 			},
 			Factory: func() storage.Item { return new(cache.CacheEntry) },
 		},
@@ -119,7 +126,7 @@ func TestCache(t *testing.T) {
 		t.Parallel()
 
 		st := newTestStorage(t)
-		c, err := cache.New(context.TODO(), st.IndexStore(), 10)
+		c, err := cache.New(context.TODO(), st, 10*swarm.ChunkWithSpanSize, cache.LRU)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -130,7 +137,7 @@ func TestCache(t *testing.T) {
 		t.Parallel()
 
 		st := newTestStorage(t)
-		c, err := cache.New(context.TODO(), st.IndexStore(), 10)
+		c, err := cache.New(context.TODO(), st, 10*swarm.ChunkWithSpanSize, cache.LRU)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -149,7 +156,7 @@ func TestCache(t *testing.T) {
 		})
 
 		t.Run("new cache retains state", func(t *testing.T) {
-			c2, err := cache.New(context.TODO(), st.IndexStore(), 10)
+			c2, err := cache.New(context.TODO(), st, 10*swarm.ChunkWithSpanSize, cache.LRU)
 			if err != nil {
 				t.Fatal(err)
 			}
@@ -162,7 +169,7 @@ func TestCache(t *testing.T) {
 		t.Parallel()
 
 		st := newTestStorage(t)
-		c, err := cache.New(context.TODO(), st.IndexStore(), 10)
+		c, err := cache.New(context.TODO(), st, 10*swarm.ChunkWithSpanSize, cache.LRU)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -212,6 +219,16 @@ func TestCache(t *testing.T) {
 			verifyCacheOrder(t, c, st.IndexStore(), newOrder...)
 		})
 
+		t.Run("get reports hit", func(t *testing.T) {
+			_, hit, err := c.Get(context.TODO(), st, chunks[0].Address())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !hit {
+				t.Fatalf("cached chunk %s not reported as hit", chunks[0].Address())
+			}
+		})
+
 		t.Run("not in chunkstore returns error", func(t *testing.T) {
 			for i := 0; i < 5; i++ {
 				unknownChunk := chunktest.GenerateTestRandomChunk()
@@ -234,13 +251,16 @@ func TestCache(t *testing.T) {
 					t.Fatal(err)
 				}
 
-				readChunk, err := c.Getter(st).Get(context.TODO(), extraChunk.Address())
+				readChunk, hit, err := c.Get(context.TODO(), st, extraChunk.Address())
 				if err != nil {
 					t.Fatal(err)
 				}
 				if !readChunk.Equal(extraChunk) {
 					t.Fatalf("incorrect chunk: %s", extraChunk.Address())
 				}
+				if hit {
+					t.Fatalf("chunk %s without cache entry reported as hit", extraChunk.Address())
+				}
 				verifyCacheState(t, st.IndexStore(), c, state.Head, state.Tail, state.Size)
 			}
 		})
@@ -249,7 +269,7 @@ func TestCache(t *testing.T) {
 			t.Parallel()
 
 			st := newTestStorage(t)
-			c, err := cache.New(context.TODO(), st.IndexStore(), 10)
+			c, err := cache.New(context.TODO(), st, 10*swarm.ChunkWithSpanSize, cache.LRU)
 			if err != nil {
 				t.Fatal(err)
 			}
@@ -304,7 +324,7 @@ func TestRemoveOldest(t *testing.T) {
 	t.Parallel()
 
 	st := newTestStorage(t)
-	c, err := cache.New(context.Background(), st.IndexStore(), 10)
+	c, err := cache.New(context.Background(), st, 10*swarm.ChunkWithSpanSize, cache.LRU)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -321,7 +341,7 @@ func TestRemoveOldest(t *testing.T) {
 	verifyCacheState(t, st.IndexStore(), c, chunks[0].Address(), chunks[29].Address(), 30)
 	verifyCacheOrder(t, c, st.IndexStore(), chunks...)
 
-	err = c.RemoveOldestMaxBatch(context.Background(), st, 30, 5)
+	err = c.RemoveOldestMaxBatch(context.Background(), st, 30*swarm.ChunkWithSpanSize, 5)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -331,11 +351,64 @@ func TestRemoveOldest(t *testing.T) {
 	verifyChunksDeleted(t, st.ChunkStore(), chunks...)
 }
 
-func TestShallowCopy(t *testing.T) {
+func TestCacheSize(t *testing.T) {
 	t.Parallel()
 
 	st := newTestStorage(t)
-	c, err := cache.New(context.Background(), st.IndexStore(), 10)
+	c, err := cache.New(context.Background(), st, 3*swarm.ChunkWithSpanSize, cache.LRU)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	chunks := []swarm.Chunk{chunktest.GenerateTestRandomChunk()}
+	for i := 0; i < 4; i++ {
+		ch, err := cac.New(testutil.RandBytes(t, 100))
+		if err != nil {
+			t.Fatal(err)
+		}
+		chunks = append(chunks, ch)
+	}
+	for _, ch := range chunks {
+		if err := c.Putter(st).Put(context.Background(), ch); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	verifySize := func(t *testing.T, c *cache.Cache, count, size uint64) {
+		t.Helper()
+		if c.Count() != count || c.Size() != size {
+			t.Fatalf("got count %d size %d, want count %d size %d", c.Count(), c.Size(), count, size)
+		}
+	}
+	verifySize(t, c, 5, swarm.ChunkWithSpanSize+4*(swarm.SpanSize+100))
+
+	// the oldest chunk alone exceeds the size to evict
+	if err := c.RemoveOldest(context.Background(), st, 1); err != nil {
+		t.Fatal(err)
+	}
+	verifyChunksDeleted(t, st.ChunkStore(), chunks[0])
+	verifyChunksExist(t, st.ChunkStore(), chunks[1:]...)
+	verifySize(t, c, 4, 4*(swarm.SpanSize+100))
+
+	c, err = cache.New(context.Background(), st, 3*swarm.ChunkWithSpanSize, cache.LRU)
+	if err != nil {
+		t.Fatal(err)
+	}
+	verifySize(t, c, 4, 4*(swarm.SpanSize+100))
+
+	if err := c.RemoveOldest(context.Background(), st, 2*(swarm.SpanSize+100)); err != nil {
+		t.Fatal(err)
+	}
+	verifyChunksDeleted(t, st.ChunkStore(), chunks[1:3]...)
+	verifyChunksExist(t, st.ChunkStore(), chunks[3:]...)
+	verifySize(t, c, 2, 2*(swarm.SpanSize+100))
+}
+
+func TestShallowCopy(t *testing.T) {
+	t.Parallel()
+
+	st := newSharkyStorage(t)
+	c, err := cache.New(context.Background(), st, 10*swarm.ChunkWithSpanSize, cache.LRU)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -397,7 +470,7 @@ func TestShallowCopy(t *testing.T) {
 	verifyCacheState(t, st.IndexStore(), c, chunks[0].Address(), chunks1[9].Address(), 20)
 	verifyCacheOrder(t, c, st.IndexStore(), append(chunks, chunks1...)...)
 
-	err = c.RemoveOldest(context.Background(), st, 10)
+	err = c.RemoveOldest(context.Background(), st, 10*swarm.ChunkWithSpanSize)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -408,8 +481,8 @@ func TestShallowCopy(t *testing.T) {
 func TestShallowCopyOverCap(t *testing.T) {
 	t.Parallel()
 
-	st := newTestStorage(t)
-	c, err := cache.New(context.Background(), st.IndexStore(), 10)
+	st := newSharkyStorage(t)
+	c, err := cache.New(context.Background(), st, 10*swarm.ChunkWithSpanSize, cache.LRU)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -439,7 +512,7 @@ func TestShallowCopyOverCap(t *testing.T) {
 	verifyCacheState(t, st.IndexStore(), c, chunks[5].Address(), chunks[14].Address(), 10)
 	verifyCacheOrder(t, c, st.IndexStore(), chunks[5:15]...)
 
-	err = c.RemoveOldest(context.Background(), st, 5)
+	err = c.RemoveOldest(context.Background(), st, 5*swarm.ChunkWithSpanSize)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -451,7 +524,7 @@ func TestShallowCopyAlreadyCached(t *testing.T) {
 	t.Parallel()
 
 	st := newTestStorage(t)
-	c, err := cache.New(context.Background(), st.IndexStore(), 1000)
+	c, err := cache.New(context.Background(), st, 1000*swarm.ChunkWithSpanSize, cache.LRU)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -484,7 +557,7 @@ func TestShallowCopyAlreadyCached(t *testing.T) {
 
 	verifyChunksExist(t, st.ChunkStore(), chunks...)
 
-	err = c.RemoveOldest(context.Background(), st, 10)
+	err = c.RemoveOldest(context.Background(), st, 10*swarm.ChunkWithSpanSize)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -492,6 +565,149 @@ func TestShallowCopyAlreadyCached(t *testing.T) {
 	verifyChunksDeleted(t, st.ChunkStore(), chunks...)
 }
 
+func TestParsePolicy(t *testing.T) {
+	t.Parallel()
+
+	for _, p := range cache.Policies {
+		got, err := cache.ParsePolicy(p.String())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != p {
+			t.Fatalf("got policy %s, want %s", got, p)
+		}
+	}
+	if _, err := cache.ParsePolicy("mru"); !errors.Is(err, cache.ErrUnknownPolicy) {
+		t.Fatalf("got error %v, want %v", err, cache.ErrUnknownPolicy)
+	}
+}
+
+func TestPolicyLFU(t *testing.T) {
+	t.Parallel()
+
+	st := newTestStorage(t)
+	c, err := cache.New(context.Background(), st, 10*swarm.ChunkWithSpanSize, cache.LFU)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	chunks := chunktest.GenerateTestRandomChunks(3)
+	for _, ch := range chunks {
+		if err := c.Putter(st).Put(context.Background(), ch); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// the hits make the first chunk the most and the last one the least
+	// frequently accessed
+	for _, ch := range []swarm.Chunk{chunks[0], chunks[0], chunks[1]} {
+		if _, err := c.Getter(st).Get(context.Background(), ch.Address()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	verifyCacheOrder(t, c, st.IndexStore(), chunks[2], chunks[1], chunks[0])
+
+	if err := c.RemoveOldest(context.Background(), st, 2*swarm.ChunkWithSpanSize); err != nil {
+		t.Fatal(err)
+	}
+	verifyChunksDeleted(t, st.ChunkStore(), chunks[1:]...)
+	verifyChunksExist(t, st.ChunkStore(), chunks[0])
+}
+
+func TestPolicyScanResistance(t *testing.T) {
+	t.Parallel()
+
+	const capacity = 8
+
+	for _, tc := range []struct {
+		policy   cache.Policy
+		keepsHot bool
+	}{
+		{policy: cache.LRU, keepsHot: false},
+		{policy: cache.LFU, keepsHot: true},
+		{policy: cache.TwoQ, keepsHot: true},
+	} {
+		t.Run(tc.policy.String(), func(t *testing.T) {
+			t.Parallel()
+
+			st := newTestStorage(t)
+			c, err := cache.New(context.Background(), st, capacity*swarm.ChunkWithSpanSize, tc.policy)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			put := func(chunks []swarm.Chunk) {
+				t.Helper()
+				for _, ch := range chunks {
+					if err := c.Putter(st).Put(context.Background(), ch); err != nil {
+						t.Fatal(err)
+					}
+					if c.Size() > c.Capacity() {
+						if err := c.RemoveOldest(context.Background(), st, c.Size()-c.Capacity()); err != nil {
+							t.Fatal(err)
+						}
+					}
+				}
+			}
+
+			hot := chunktest.GenerateTestRandomChunks(4)
+			put(hot)
+			for _, ch := range hot {
+				if _, err := c.Getter(st).Get(context.Background(), ch.Address()); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			scan := chunktest.GenerateTestRandomChunks(3 * capacity)
+			put(scan)
+
+			if c.Count() != capacity {
+				t.Fatalf("got count %d, want %d", c.Count(), capacity)
+			}
+			if tc.keepsHot {
+				verifyChunksExist(t, st.ChunkStore(), hot...)
+			} else {
+				verifyChunksDeleted(t, st.ChunkStore(), hot...)
+			}
+			verifyChunksExist(t, st.ChunkStore(), scan[len(scan)-capacity/2:]...)
+		})
+	}
+}
+
+func TestPolicyChange(t *testing.T) {
+	t.Parallel()
+
+	st := newTestStorage(t)
+	c, err := cache.New(context.Background(), st, 10*swarm.ChunkWithSpanSize, cache.LRU)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	chunks := chunktest.GenerateTestRandomChunks(3)
+	for _, ch := range chunks {
+		if err := c.Putter(st).Put(context.Background(), ch); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, ch := range []swarm.Chunk{chunks[0], chunks[0], chunks[2]} {
+		if _, err := c.Getter(st).Get(context.Background(), ch.Address()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	verifyCacheOrder(t, c, st.IndexStore(), chunks[1], chunks[0], chunks[2])
+
+	c, err = cache.New(context.Background(), st, 10*swarm.ChunkWithSpanSize, cache.LFU)
+	if err != nil {
+		t.Fatal(err)
+	}
+	verifyCacheOrder(t, c, st.IndexStore(), chunks[1], chunks[2], chunks[0])
+
+	c, err = cache.New(context.Background(), st, 10*swarm.ChunkWithSpanSize, cache.LRU)
+	if err != nil {
+		t.Fatal(err)
+	}
+	verifyCacheOrder(t, c, st.IndexStore(), chunks[1], chunks[0], chunks[2])
+}
+
 func verifyCacheState(
 	t *testing.T,
 	store storage.Reader,
@@ -579,6 +795,32 @@ func verifyChunksExist(
 	}
 }
 
+type memFS struct {
+	afero.Fs
+}
+
+func (m *memFS) Open(path string) (fs.File, error) {
+	return m.Fs.OpenFile(path, os.O_RDWR|os.O_CREATE, 0644)
+}
+
+// newSharkyStorage returns a storage keeping the chunks in sharky, so the
+// byte size of the stored chunks is known from the index store.
+func newSharkyStorage(t *testing.T) transaction.Storage {
+	t.Helper()
+
+	sharky, err := sharky.New(&memFS{Fs: afero.NewMemMapFs()}, 1, swarm.SocMaxChunkSize)
+	if err != nil {
+		t.Fatal(err)
+	}
+	st := transaction.NewStorage(sharky, inmemstore.New())
+	t.Cleanup(func() {
+		if err := st.Close(); err != nil {
+			t.Errorf("Close(): unexpected error: %v", err)
+		}
+	})
+	return st
+}
+
 type inmemStorage struct {
 	indexStore *customIndexStore
 	chunkStore storage.ChunkStore
@@ -37,13 +37,13 @@ type CacheState struct {
 	Size uint64
 }
 
-func (c *Cache) RemoveOldestMaxBatch(ctx context.Context, st transaction.Storage, count uint64, batchCnt int) error {
-	return c.RemoveOldest(ctx, st, count)
+func (c *Cache) RemoveOldestMaxBatch(ctx context.Context, st transaction.Storage, size uint64, batchCnt int) error {
+	return c.RemoveOldest(ctx, st, size)
 }
 
 func (c *Cache) State(store storage.Reader) CacheState {
 	state := CacheState{}
-	state.Size = c.Size()
+	state.Size = c.Count()
 	runner := swarm.ZeroAddress
 
 	err := store.Iterate(
@@ -52,7 +52,7 @@ func (c *Cache) State(store storage.Reader) CacheState {
 			ItemProperty: storage.QueryItemID,
 		},
 		func(res storage.Result) (bool, error) {
-			_, addr, err := idFromKey(res.ID)
+			addr, err := addressFromKey(res.ID)
 			if err != nil {
 				return false, err
 			}
@@ -83,7 +83,7 @@ func (c *Cache) IterateOldToNew(
 			ItemProperty: storage.QueryItemID,
 		},
 		func(res storage.Result) (bool, error) {
-			_, addr, err := idFromKey(res.ID)
+			addr, err := addressFromKey(res.ID)
 			if err != nil {
 				return false, err
 			}
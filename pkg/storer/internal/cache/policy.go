@@ -0,0 +1,153 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cache
+
+import (
+	"errors"
+	"fmt"
+
+	storage "github.com/ethersphere/bee/v2/pkg/storage"
+)
+
+// ErrUnknownPolicy is returned when the name of the eviction policy is unknown.
+var ErrUnknownPolicy = errors.New("unknown cache eviction policy")
+
+// Policy decides which of the cache entries are evicted first. The entries are
+// kept in the cache order index under the keys assigned by the policy.
+type Policy interface {
+	// String returns the name of the policy.
+	String() string
+	// orderKey returns the key of the entry in the cache order index.
+	orderKey(e *cacheEntry) string
+	// evictionRanges returns the ranges of the cache order index to evict
+	// the bytes of the evict size from, given the byte size of the cache, the
+	// byte size of the entries hit since they were added and the capacity.
+	evictionRanges(evict, size, hot, capacity uint64) []evictionRange
+}
+
+// evictionRange are the oldest entries of the cache order index whose keys
+// start with the prefix, up to the byte size.
+type evictionRange struct {
+	prefix string
+	size   uint64
+}
+
+var (
+	// LRU evicts the least recently accessed entries first.
+	LRU Policy = lru{}
+	// LFU evicts the least frequently accessed entries first, the entries
+	// accessed equally often are evicted in the LRU order.
+	LFU Policy = lfu{}
+	// TwoQ is the simplified 2Q policy. The added entries are kept in a FIFO
+	// queue and move to an LRU queue once they are hit. The entries are
+	// evicted from the FIFO queue while it is over its share of the capacity,
+	// so a scan of the content accessed only once does not evict the entries
+	// of the LRU queue.
+	TwoQ Policy = twoQ{}
+)
+
+// Policies are the supported eviction policies.
+var Policies = []Policy{LRU, LFU, TwoQ}
+
+// ParsePolicy returns the eviction policy of the name.
+func ParsePolicy(name string) (Policy, error) {
+	for _, p := range Policies {
+		if p.String() == name {
+			return p, nil
+		}
+	}
+	return nil, fmt.Errorf("%q: %w", name, ErrUnknownPolicy)
+}
+
+type lru struct{}
+
+func (lru) String() string { return "lru" }
+
+func (lru) orderKey(e *cacheEntry) string {
+	return keyFromID(e.AccessTimestamp, e.Address)
+}
+
+func (lru) evictionRanges(evict, _, _, _ uint64) []evictionRange {
+	return []evictionRange{{size: evict}}
+}
+
+type lfu struct{}
+
+func (lfu) String() string { return "lfu" }
+
+func (lfu) orderKey(e *cacheEntry) string {
+	return fmt.Sprintf("%020d", e.Hits) + keyFromID(e.AccessTimestamp, e.Address)
+}
+
+func (lfu) evictionRanges(evict, _, _, _ uint64) []evictionRange {
+	return []evictionRange{{size: evict}}
+}
+
+const (
+	twoQFIFOPrefix = "0"
+	twoQLRUPrefix  = "1"
+
+	// twoQFIFOShare is the share of the capacity kept for the FIFO queue.
+	twoQFIFOShare = 4
+)
+
+type twoQ struct{}
+
+func (twoQ) String() string { return "2q" }
+
+func (twoQ) orderKey(e *cacheEntry) string {
+	if e.Hits == 0 {
+		return twoQFIFOPrefix + keyFromID(e.AccessTimestamp, e.Address)
+	}
+	return twoQLRUPrefix + keyFromID(e.AccessTimestamp, e.Address)
+}
+
+func (twoQ) evictionRanges(evict, size, hot, capacity uint64) []evictionRange {
+	var (
+		fifo      = size - min(hot, size)
+		fifoQuota = capacity / twoQFIFOShare
+		fromFIFO  uint64
+	)
+	if fifo > fifoQuota {
+		fromFIFO = min(evict, fifo-fifoQuota)
+	}
+	return []evictionRange{
+		{prefix: twoQFIFOPrefix, size: fromFIFO},
+		{prefix: twoQLRUPrefix, size: evict - fromFIFO},
+		// the LRU queue may not hold enough entries
+		{prefix: twoQFIFOPrefix, size: evict},
+	}
+}
+
+var _ storage.Item = (*cachePolicyItem)(nil)
+
+// cachePolicyItem stores the name of the policy of the cache order index.
+type cachePolicyItem struct {
+	Name string
+}
+
+func (cachePolicyItem) Namespace() string { return "cachePolicy" }
+
+func (cachePolicyItem) ID() string { return "" }
+
+func (c *cachePolicyItem) Marshal() ([]byte, error) {
+	return []byte(c.Name), nil
+}
+
+func (c *cachePolicyItem) Unmarshal(buf []byte) error {
+	c.Name = string(buf)
+	return nil
+}
+
+func (c *cachePolicyItem) Clone() storage.Item {
+	if c == nil {
+		return nil
+	}
+	return &cachePolicyItem{Name: c.Name}
+}
+
+func (c cachePolicyItem) String() string {
+	return fmt.Sprintf("cachePolicyItem { Name: %s }", c.Name)
+}
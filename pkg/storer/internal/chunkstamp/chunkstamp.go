@@ -181,6 +181,11 @@ func LoadWithBatchID(s storage.Reader, scope string, addr swarm.Address, batchID
 	return stamp, nil
 }
 
+// Count returns the number of the stamps related to the given address.
+func Count(s storage.Reader, scope string, addr swarm.Address) (int, error) {
+	return s.Count(&Item{scope: []byte(scope), address: addr})
+}
+
 // Store creates new or updated an existing stamp index
 // record related to the given scope and chunk.
 func Store(s storage.IndexStore, scope string, chunk swarm.Chunk) error {
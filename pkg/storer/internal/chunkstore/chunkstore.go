@@ -169,6 +169,15 @@ func IterateChunkEntries(st storage.Reader, fn func(swarm.Address, uint32) (bool
 	)
 }
 
+// Size returns the byte size of the data of the stored chunk.
+func Size(st storage.Reader, addr swarm.Address) (uint64, error) {
+	item := &RetrievalIndexItem{Address: addr}
+	if err := st.Get(item); err != nil {
+		return 0, err
+	}
+	return uint64(item.Location.Length), nil
+}
+
 type LocationResult struct {
 	Err      error
 	Location sharky.Location
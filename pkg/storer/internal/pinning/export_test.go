@@ -18,15 +18,18 @@ type (
 )
 
 var (
-	ErrInvalidPinCollectionItemAddr = errInvalidPinCollectionAddr
-	ErrInvalidPinCollectionItemUUID = errInvalidPinCollectionUUID
-	ErrInvalidPinCollectionItemSize = errInvalidPinCollectionSize
-	ErrPutterAlreadyClosed          = errPutterAlreadyClosed
-	ErrCollectionRootAddressIsZero  = errCollectionRootAddressIsZero
+	ErrInvalidPinCollectionItemAddr  = errInvalidPinCollectionAddr
+	ErrInvalidPinCollectionItemUUID  = errInvalidPinCollectionUUID
+	ErrInvalidPinCollectionItemSize  = errInvalidPinCollectionSize
+	ErrInvalidPinCollectionItemLabel = errInvalidPinCollectionLabel
+	ErrPutterAlreadyClosed           = errPutterAlreadyClosed
+	ErrCollectionRootAddressIsZero   = errCollectionRootAddressIsZero
 )
 
 var NewUUID = newUUID
 
+const PinCollectionItemSize = pinCollectionItemSize
+
 func GetStat(st storage.Reader, root swarm.Address) (CollectionStat, error) {
 	collection := &pinCollectionItem{Addr: root}
 	err := st.Get(collection)
@@ -11,6 +11,7 @@ import (
 	"errors"
 	"fmt"
 	"runtime"
+	"time"
 
 	"github.com/ethersphere/bee/v2/pkg/encryption"
 	storage "github.com/ethersphere/bee/v2/pkg/storage"
@@ -26,6 +27,8 @@ import (
 const (
 	// size of the UUID generated by the pinstore
 	uuidSize = 16
+	// MaxLabelLength is the maximum length of the label of a collection
+	MaxLabelLength = 256
 )
 
 var (
@@ -38,6 +41,9 @@ var (
 	// errInvalidPinCollectionSize is returned when trying to unmarshal a buffer of
 	// incorrect size
 	errInvalidPinCollectionSize = errors.New("unmarshal pinCollectionItem: invalid size")
+	// errInvalidPinCollectionLabel is returned when trying to marshal a pinCollectionItem
+	// with a label longer than MaxLabelLength
+	errInvalidPinCollectionLabel = errors.New("marshal pinCollectionItem: label too long")
 	// errPutterAlreadyClosed is returned when trying to use a Putter which is already closed
 	errPutterAlreadyClosed = errors.New("pin store: putter already closed")
 	// errCollectionRootAddressIsZero is returned if the putter is closed with a zero
@@ -60,27 +66,145 @@ var emptyKey = make([]byte, 32)
 type CollectionStat struct {
 	Total           uint64
 	DupInCollection uint64
+	// Size is the byte size of the chunks stored by the collection.
+	Size uint64
+}
+
+// Collection describes a pinning collection.
+type Collection struct {
+	Addr      swarm.Address
+	Label     string
+	CreatedAt time.Time
+	// ExpiresAt is the time the collection is to be removed, zero if never.
+	ExpiresAt time.Time
+	Stat      CollectionStat
+}
+
+// CollectionPutter is the putter of a new pin collection.
+type CollectionPutter interface {
+	internal.PutterCloserWithReference
+	// Size returns the byte size of the chunks added to the collection.
+	Size() uint64
 }
 
 // NewCollection returns a putter wrapped around the passed storage.
 // The putter will add the chunk to Chunk store if it doesn't exists within this collection.
 // It will create a new UUID for the collection which can be used to iterate on all the chunks
 // that are part of this collection. The root pin is only updated on successful close of this.
+// The label is stored with the collection to describe it to the user, and the
+// collection is to be removed at the expiry time unless it is zero.
 // Calls to the Putter MUST be mutex locked to prevent concurrent upload data races.
-func NewCollection(st storage.IndexStore) (internal.PutterCloserWithReference, error) {
+func NewCollection(st storage.IndexStore, label string, expiresAt time.Time) (CollectionPutter, error) {
+	if len(label) > MaxLabelLength {
+		return nil, errInvalidPinCollectionLabel
+	}
 	newCollectionUUID := newUUID()
 	err := st.Put(&dirtyCollection{UUID: newCollectionUUID})
 	if err != nil {
 		return nil, err
 	}
-	return &collectionPutter{
-		collection: &pinCollectionItem{UUID: newCollectionUUID},
-	}, nil
+	c := &pinCollectionItem{
+		UUID:      newCollectionUUID,
+		Label:     label,
+		CreatedAt: time.Now().UnixNano(),
+	}
+	if !expiresAt.IsZero() {
+		c.ExpiresAt = expiresAt.UnixNano()
+	}
+	return &collectionPutter{collection: c}, nil
+}
+
+// PendingCollection returns a putter of the collection of the root like
+// NewCollection, but the collection is kept by CleanupDirty, so the chunks
+// already put are not lost on a restart. If the pending collection of the root
+// exists, it is resumed with its label and expiry and the chunks already
+// collected are counted to the stats when they are put again.
+// Calls to the Putter MUST be mutex locked to prevent concurrent upload data races.
+func PendingCollection(st storage.IndexStore, root swarm.Address, label string, expiresAt time.Time) (CollectionPutter, error) {
+	if root.IsZero() {
+		return nil, errCollectionRootAddressIsZero
+	}
+	if len(label) > MaxLabelLength {
+		return nil, errInvalidPinCollectionLabel
+	}
+
+	var pending *pinCollectionItem
+	err := iteratePending(st, func(p *pinCollectionItem) (bool, error) {
+		if p.Addr.Equal(root) {
+			pending = p
+			return true, nil
+		}
+		return false, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if pending != nil {
+		pending.Stat = CollectionStat{}
+		return &collectionPutter{collection: pending, seen: make(map[string]struct{})}, nil
+	}
+
+	c := &pinCollectionItem{
+		Addr:      root.Clone(),
+		UUID:      newUUID(),
+		Label:     label,
+		CreatedAt: time.Now().UnixNano(),
+	}
+	if !expiresAt.IsZero() {
+		c.ExpiresAt = expiresAt.UnixNano()
+	}
+	err = st.Put(&dirtyCollection{UUID: c.UUID, Pending: c})
+	if err != nil {
+		return nil, err
+	}
+	return &collectionPutter{collection: c.Clone().(*pinCollectionItem)}, nil
+}
+
+// PendingCollections lists the pending collections. The Total of their stats
+// is the number of the chunks collected so far.
+func PendingCollections(st storage.Reader) ([]Collection, error) {
+	var collections []Collection
+	err := iteratePending(st, func(p *pinCollectionItem) (bool, error) {
+		n, err := st.Count(&pinChunkItem{UUID: p.UUID})
+		if err != nil {
+			return true, fmt.Errorf("pin store: failed counting collection chunks: %w", err)
+		}
+		p.Stat.Total = uint64(n)
+		collections = append(collections, p.collection())
+		return false, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return collections, nil
+}
+
+// iteratePending iterates over the pending collections.
+func iteratePending(st storage.Reader, fn func(*pinCollectionItem) (bool, error)) error {
+	err := st.Iterate(
+		storage.Query{
+			Factory: func() storage.Item { return new(dirtyCollection) },
+		},
+		func(r storage.Result) (bool, error) {
+			di := r.Entry.(*dirtyCollection)
+			if di.Pending == nil {
+				return false, nil
+			}
+			return fn(di.Pending)
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("pin store: failed iterating dirty collections: %w", err)
+	}
+	return nil
 }
 
 type collectionPutter struct {
 	collection *pinCollectionItem
 	closed     bool
+	// seen holds the chunks put to a resumed collection, the chunks collected
+	// before are not in it when they are put again.
+	seen map[string]struct{}
 }
 
 // Put adds a chunk to the pin collection.
@@ -103,6 +227,12 @@ func (c *collectionPutter) Put(ctx context.Context, st transaction.Store, ch swa
 		return fmt.Errorf("pin store: failed to check chunk: %w", err)
 	}
 	if found {
+		if _, ok := c.seen[ch.Address().ByteString()]; c.seen != nil && !ok {
+			// The chunk was collected before the collection was resumed.
+			c.seen[ch.Address().ByteString()] = struct{}{}
+			c.collection.Stat.Size += uint64(len(ch.Data()))
+			return nil
+		}
 		// If we already have this chunk in the current collection, don't add it
 		// again.
 		c.collection.Stat.DupInCollection++
@@ -119,9 +249,17 @@ func (c *collectionPutter) Put(ctx context.Context, st transaction.Store, ch swa
 		return fmt.Errorf("pin store: failed putting chunk: %w", err)
 	}
 
+	c.collection.Stat.Size += uint64(len(ch.Data()))
+	if c.seen != nil {
+		c.seen[ch.Address().ByteString()] = struct{}{}
+	}
 	return nil
 }
 
+func (c *collectionPutter) Size() uint64 {
+	return c.collection.Stat.Size
+}
+
 func (c *collectionPutter) Close(st storage.IndexStore, root swarm.Address) error {
 	if root.IsZero() {
 		return errCollectionRootAddressIsZero
@@ -174,16 +312,19 @@ func (c *collectionPutter) Cleanup(st transaction.Storage) error {
 	return nil
 }
 
-// CleanupDirty will iterate over all the dirty collections and delete them.
+// CleanupDirty will iterate over all the dirty collections and delete them,
+// except the pending ones.
 func CleanupDirty(st transaction.Storage) error {
 
 	dirtyCollections := make([]*dirtyCollection, 0)
 	err := st.IndexStore().Iterate(
 		storage.Query{
-			Factory:      func() storage.Item { return new(dirtyCollection) },
-			ItemProperty: storage.QueryItemID,
+			Factory: func() storage.Item { return new(dirtyCollection) },
 		},
 		func(r storage.Result) (bool, error) {
+			if r.Entry.(*dirtyCollection).Pending != nil {
+				return false, nil
+			}
 			di := &dirtyCollection{UUID: []byte(r.ID)}
 			dirtyCollections = append(dirtyCollections, di)
 			return false, nil
@@ -228,6 +369,46 @@ func Pins(st storage.Reader) ([]swarm.Address, error) {
 	return pins, nil
 }
 
+// Collections lists all the added pinning collections with their metadata.
+func Collections(st storage.Reader) ([]Collection, error) {
+	var collections []Collection
+	err := st.Iterate(storage.Query{
+		Factory: func() storage.Item { return new(pinCollectionItem) },
+	}, func(r storage.Result) (bool, error) {
+		collections = append(collections, r.Entry.(*pinCollectionItem).collection())
+		return false, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("pin store: failed iterating collections: %w", err)
+	}
+
+	return collections, nil
+}
+
+// GetCollection returns the pinning collection with the root reference.
+func GetCollection(st storage.Reader, root swarm.Address) (Collection, error) {
+	collection := &pinCollectionItem{Addr: root}
+	err := st.Get(collection)
+	if err != nil {
+		return Collection{}, fmt.Errorf("pin store: failed getting collection: %w", err)
+	}
+
+	return collection.collection(), nil
+}
+
+// SetCollectionSize sets the byte size of the chunks stored by the pinning
+// collection with the root reference.
+func SetCollectionSize(st storage.IndexStore, root swarm.Address, size uint64) error {
+	collection := &pinCollectionItem{Addr: root}
+	err := st.Get(collection)
+	if err != nil {
+		return fmt.Errorf("pin store: failed getting collection: %w", err)
+	}
+
+	collection.Stat.Size = size
+	return st.Put(collection)
+}
+
 func deleteCollectionChunks(ctx context.Context, st transaction.Storage, collectionUUID []byte) error {
 	chunksToDelete := make([]*pinChunkItem, 0)
 
@@ -323,19 +504,28 @@ func IterateCollectionStats(st storage.Reader, iterateFn func(st CollectionStat)
 	)
 }
 
-// pinCollectionSize represents the size of the pinCollectionItem
+// pinCollectionSize represents the size of the pinCollectionItem without the
+// metadata, the collections stored by the earlier versions have this size.
 const pinCollectionItemSize = encryption.ReferenceSize + uuidSize + 8 + 8
 
+// pinCollectionItemMetadataSize represents the size of the metadata of the
+// pinCollectionItem without the label.
+const pinCollectionItemMetadataSize = 8 + 8 + 8
+
 var _ storage.Item = (*pinCollectionItem)(nil)
 
 // pinCollectionItem is the index used to describe a pinning collection. The Addr
 // is the root reference of the collection and UUID is a unique UUID for this collection.
 // The Address could be an encrypted swarm hash. This hash has the key to decrypt the
-// collection.
+// collection. The CreatedAt and ExpiresAt are unix times in nanoseconds, the
+// ExpiresAt is zero if the collection does not expire.
 type pinCollectionItem struct {
-	Addr swarm.Address
-	UUID []byte
-	Stat CollectionStat
+	Addr      swarm.Address
+	UUID      []byte
+	Stat      CollectionStat
+	Label     string
+	CreatedAt int64
+	ExpiresAt int64
 }
 
 func (p *pinCollectionItem) ID() string { return p.Addr.ByteString() }
@@ -349,18 +539,28 @@ func (p *pinCollectionItem) Marshal() ([]byte, error) {
 	if len(p.UUID) == 0 {
 		return nil, errInvalidPinCollectionUUID
 	}
-	buf := make([]byte, pinCollectionItemSize)
+	if len(p.Label) > MaxLabelLength {
+		return nil, errInvalidPinCollectionLabel
+	}
+	buf := make([]byte, pinCollectionItemSize+pinCollectionItemMetadataSize+len(p.Label))
 	copy(buf[:encryption.ReferenceSize], p.Addr.Bytes())
 	off := encryption.ReferenceSize
 	copy(buf[off:off+uuidSize], p.UUID)
 	statBufOff := encryption.ReferenceSize + uuidSize
 	binary.LittleEndian.PutUint64(buf[statBufOff:], p.Stat.Total)
 	binary.LittleEndian.PutUint64(buf[statBufOff+8:], p.Stat.DupInCollection)
+	metaBuf := buf[pinCollectionItemSize:]
+	binary.LittleEndian.PutUint64(metaBuf, p.Stat.Size)
+	binary.LittleEndian.PutUint64(metaBuf[8:], uint64(p.CreatedAt))
+	binary.LittleEndian.PutUint64(metaBuf[16:], uint64(p.ExpiresAt))
+	copy(metaBuf[pinCollectionItemMetadataSize:], p.Label)
 	return buf, nil
 }
 
 func (p *pinCollectionItem) Unmarshal(buf []byte) error {
-	if len(buf) != pinCollectionItemSize {
+	if len(buf) != pinCollectionItemSize &&
+		(len(buf) < pinCollectionItemSize+pinCollectionItemMetadataSize ||
+			len(buf) > pinCollectionItemSize+pinCollectionItemMetadataSize+MaxLabelLength) {
 		return errInvalidPinCollectionSize
 	}
 	ni := new(pinCollectionItem)
@@ -374,6 +574,13 @@ func (p *pinCollectionItem) Unmarshal(buf []byte) error {
 	statBuf := buf[off+uuidSize:]
 	ni.Stat.Total = binary.LittleEndian.Uint64(statBuf[:8])
 	ni.Stat.DupInCollection = binary.LittleEndian.Uint64(statBuf[8:16])
+	// the collections stored by the earlier versions have no metadata
+	if metaBuf := buf[pinCollectionItemSize:]; len(metaBuf) > 0 {
+		ni.Stat.Size = binary.LittleEndian.Uint64(metaBuf)
+		ni.CreatedAt = int64(binary.LittleEndian.Uint64(metaBuf[8:]))
+		ni.ExpiresAt = int64(binary.LittleEndian.Uint64(metaBuf[16:]))
+		ni.Label = string(metaBuf[pinCollectionItemMetadataSize:])
+	}
 	*p = *ni
 	return nil
 }
@@ -383,12 +590,31 @@ func (p *pinCollectionItem) Clone() storage.Item {
 		return nil
 	}
 	return &pinCollectionItem{
-		Addr: p.Addr.Clone(),
-		UUID: append([]byte(nil), p.UUID...),
-		Stat: p.Stat,
+		Addr:      p.Addr.Clone(),
+		UUID:      append([]byte(nil), p.UUID...),
+		Stat:      p.Stat,
+		Label:     p.Label,
+		CreatedAt: p.CreatedAt,
+		ExpiresAt: p.ExpiresAt,
 	}
 }
 
+// collection returns the description of the pinning collection.
+func (p *pinCollectionItem) collection() Collection {
+	c := Collection{
+		Addr:  p.Addr.Clone(),
+		Label: p.Label,
+		Stat:  p.Stat,
+	}
+	if p.CreatedAt != 0 {
+		c.CreatedAt = time.Unix(0, p.CreatedAt)
+	}
+	if p.ExpiresAt != 0 {
+		c.ExpiresAt = time.Unix(0, p.ExpiresAt)
+	}
+	return c
+}
+
 func (p pinCollectionItem) String() string {
 	return storageutil.JoinFields(p.Namespace(), p.ID())
 }
@@ -432,8 +658,11 @@ func (p pinChunkItem) String() string {
 	return storageutil.JoinFields(p.Namespace(), p.ID())
 }
 
+// dirtyCollection marks a collection which is not closed yet. The Pending
+// is the collection of a pending collection, nil otherwise.
 type dirtyCollection struct {
-	UUID []byte
+	UUID    []byte
+	Pending *pinCollectionItem
 }
 
 func (d *dirtyCollection) ID() string { return string(d.UUID) }
@@ -441,10 +670,22 @@ func (d *dirtyCollection) ID() string { return string(d.UUID) }
 func (dirtyCollection) Namespace() string { return "dirtyCollection" }
 
 func (d *dirtyCollection) Marshal() ([]byte, error) {
-	return nil, nil
+	if d.Pending == nil {
+		return nil, nil
+	}
+	return d.Pending.Marshal()
 }
 
-func (d *dirtyCollection) Unmarshal(_ []byte) error {
+func (d *dirtyCollection) Unmarshal(buf []byte) error {
+	if len(buf) == 0 {
+		d.Pending = nil
+		return nil
+	}
+	pending := new(pinCollectionItem)
+	if err := pending.Unmarshal(buf); err != nil {
+		return err
+	}
+	d.Pending = pending
 	return nil
 }
 
@@ -452,9 +693,13 @@ func (d *dirtyCollection) Clone() storage.Item {
 	if d == nil {
 		return nil
 	}
-	return &dirtyCollection{
+	dc := &dirtyCollection{
 		UUID: append([]byte(nil), d.UUID...),
 	}
+	if d.Pending != nil {
+		dc.Pending = d.Pending.Clone().(*pinCollectionItem)
+	}
+	return dc
 }
 
 func (d dirtyCollection) String() string {
@@ -9,7 +9,9 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"strings"
 	"testing"
+	"time"
 
 	storage "github.com/ethersphere/bee/v2/pkg/storage"
 	"github.com/ethersphere/bee/v2/pkg/storer/internal/transaction"
@@ -70,10 +72,16 @@ func TestPinStore(t *testing.T) {
 		for tCount, tc := range tests {
 			t.Run(fmt.Sprintf("create collection %d", tCount), func(t *testing.T) {
 
+				// the first collection does not expire
+				var expiresAt time.Time
+				if tCount > 0 {
+					expiresAt = time.Now().Add(time.Duration(tCount) * time.Hour)
+				}
+
 				var putter internal.PutterCloserWithReference
 				var err error
 				err = st.Run(context.Background(), func(s transaction.Store) error {
-					putter, err = pinstore.NewCollection(s.IndexStore())
+					putter, err = pinstore.NewCollection(s.IndexStore(), fmt.Sprintf("collection %d", tCount), expiresAt)
 					return err
 				})
 				if err != nil {
@@ -186,6 +194,41 @@ func TestPinStore(t *testing.T) {
 			if stat.DupInCollection != uint64(len(tc.dupChunks)-1) {
 				t.Fatalf("incorrect no of duplicate chunks, expected %d found %d", len(tc.dupChunks)-1, stat.DupInCollection)
 			}
+			size := len(tc.dupChunks[0].Data())
+			for _, ch := range append(tc.uniqueChunks, tc.root) {
+				size += len(ch.Data())
+			}
+			if stat.Size != uint64(size) {
+				t.Fatalf("incorrect size of collection, expected %d found %d", size, stat.Size)
+			}
+		}
+	})
+
+	t.Run("collections", func(t *testing.T) {
+		collections, err := pinstore.Collections(st.IndexStore())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(collections) != len(tests) {
+			t.Fatalf("incorrect no of collections, expected %d found %d", len(tests), len(collections))
+		}
+		for tCount, tc := range tests {
+			c, err := pinstore.GetCollection(st.IndexStore(), tc.root.Address())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want := fmt.Sprintf("collection %d", tCount); c.Label != want {
+				t.Fatalf("incorrect label, expected %q found %q", want, c.Label)
+			}
+			if c.CreatedAt.IsZero() || c.CreatedAt.After(time.Now()) {
+				t.Fatalf("incorrect creation time %s", c.CreatedAt)
+			}
+			if c.ExpiresAt.IsZero() != (tCount == 0) || tCount > 0 && c.ExpiresAt.Before(c.CreatedAt) {
+				t.Fatalf("incorrect expiry time %s", c.ExpiresAt)
+			}
+			if c.Stat.Total != uint64(len(tc.uniqueChunks)+len(tc.dupChunks)+1) {
+				t.Fatalf("incorrect no of chunks, expected %d found %d", len(tc.uniqueChunks)+len(tc.dupChunks)+1, c.Stat.Total)
+			}
 		}
 	})
 
@@ -266,7 +309,7 @@ func TestPinStore(t *testing.T) {
 			err    error
 		)
 		err = st.Run(context.Background(), func(s transaction.Store) error {
-			putter, err = pinstore.NewCollection(s.IndexStore())
+			putter, err = pinstore.NewCollection(s.IndexStore(), "", time.Time{})
 			return err
 		})
 		if err != nil {
@@ -303,7 +346,7 @@ func TestPinStore(t *testing.T) {
 			err    error
 		)
 		err = st.Run(context.Background(), func(s transaction.Store) error {
-			putter, err = pinstore.NewCollection(s.IndexStore())
+			putter, err = pinstore.NewCollection(s.IndexStore(), "", time.Time{})
 			return err
 		})
 		if err != nil {
@@ -340,7 +383,7 @@ func TestPinStore(t *testing.T) {
 			err    error
 		)
 		err = st.Run(context.Background(), func(s transaction.Store) error {
-			putter, err = pinstore.NewCollection(s.IndexStore())
+			putter, err = pinstore.NewCollection(s.IndexStore(), "", time.Time{})
 			return err
 		})
 		if err != nil {
@@ -377,7 +420,7 @@ func TestCleanup(t *testing.T) {
 			err    error
 		)
 		err = st.Run(context.Background(), func(s transaction.Store) error {
-			putter, err = pinstore.NewCollection(s.IndexStore())
+			putter, err = pinstore.NewCollection(s.IndexStore(), "", time.Time{})
 			return err
 		})
 		if err != nil {
@@ -420,7 +463,7 @@ func TestCleanup(t *testing.T) {
 			err    error
 		)
 		err = st.Run(context.Background(), func(s transaction.Store) error {
-			putter, err = pinstore.NewCollection(s.IndexStore())
+			putter, err = pinstore.NewCollection(s.IndexStore(), "", time.Time{})
 			return err
 		})
 		if err != nil {
@@ -453,6 +496,97 @@ func TestCleanup(t *testing.T) {
 	})
 }
 
+func TestPendingCollection(t *testing.T) {
+	t.Parallel()
+
+	st := newTestStorage(t)
+	chunks := chunktest.GenerateTestRandomChunks(5)
+	root := chunks[0].Address()
+
+	pending := func(t *testing.T, label string) pinstore.CollectionPutter {
+		t.Helper()
+
+		var (
+			putter pinstore.CollectionPutter
+			err    error
+		)
+		err = st.Run(context.Background(), func(s transaction.Store) error {
+			putter, err = pinstore.PendingCollection(s.IndexStore(), root, label, time.Time{})
+			return err
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return putter
+	}
+	put := func(t *testing.T, putter pinstore.CollectionPutter, chunks []swarm.Chunk) {
+		t.Helper()
+
+		for _, ch := range chunks {
+			err := st.Run(context.Background(), func(s transaction.Store) error {
+				return putter.Put(context.Background(), s, ch)
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	put(t, pending(t, "label"), chunks[:3])
+
+	if err := pinstore.CleanupDirty(st); err != nil {
+		t.Fatal(err)
+	}
+	for _, ch := range chunks[:3] {
+		exists, err := st.ChunkStore().Has(context.Background(), ch.Address())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !exists {
+			t.Fatal("chunk should exist")
+		}
+	}
+
+	collections, err := pinstore.PendingCollections(st.IndexStore())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(collections) != 1 || !collections[0].Addr.Equal(root) || collections[0].Label != "label" || collections[0].Stat.Total != 3 {
+		t.Fatalf("unexpected pending collections %+v", collections)
+	}
+
+	putter := pending(t, "other")
+	put(t, putter, append(chunks, chunks[1]))
+
+	err = st.Run(context.Background(), func(s transaction.Store) error {
+		return putter.Close(s.IndexStore(), root)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c, err := pinstore.GetCollection(st.IndexStore(), root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var size uint64
+	for _, ch := range chunks {
+		size += uint64(len(ch.Data()))
+	}
+	want := pinstore.CollectionStat{Total: 6, DupInCollection: 1, Size: size}
+	if c.Label != "label" || c.Stat != want {
+		t.Fatalf("unexpected collection %+v, want stat %+v", c, want)
+	}
+
+	collections, err = pinstore.PendingCollections(st.IndexStore())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(collections) != 0 {
+		t.Fatalf("unexpected pending collections %+v", collections)
+	}
+}
+
 func TestPinCollectionItem(t *testing.T) {
 	t.Parallel()
 
@@ -502,10 +636,25 @@ func TestPinCollectionItem(t *testing.T) {
 				Stat: pinstore.CollectionStat{
 					Total:           math.MaxUint64,
 					DupInCollection: math.MaxUint64,
+					Size:            math.MaxUint64,
 				},
+				Label:     strings.Repeat("x", pinstore.MaxLabelLength),
+				CreatedAt: math.MaxInt64,
+				ExpiresAt: math.MaxInt64,
 			},
 			Factory: func() storage.Item { return new(pinstore.PinCollectionItem) },
 		},
+	}, {
+		name: "label too long",
+		test: &storagetest.ItemMarshalAndUnmarshalTest{
+			Item: &pinstore.PinCollectionItem{
+				Addr:  swarm.NewAddress(storagetest.MinAddressBytes[:]),
+				UUID:  pinstore.NewUUID(),
+				Label: strings.Repeat("x", pinstore.MaxLabelLength+1),
+			},
+			Factory:    func() storage.Item { return new(pinstore.PinCollectionItem) },
+			MarshalErr: pinstore.ErrInvalidPinCollectionItemLabel,
+		},
 	}, {
 		name: "invalid size",
 		test: &storagetest.ItemMarshalAndUnmarshalTest{
@@ -538,6 +687,29 @@ func TestPinCollectionItem(t *testing.T) {
 	}
 }
 
+func TestPinCollectionItemWithoutMetadata(t *testing.T) {
+	t.Parallel()
+
+	item := &pinstore.PinCollectionItem{
+		Addr: swarm.RandAddress(t),
+		UUID: pinstore.NewUUID(),
+		Stat: pinstore.CollectionStat{Total: 10, DupInCollection: 2},
+	}
+	buf, err := item.Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the collections stored by the earlier versions have no metadata
+	got := new(pinstore.PinCollectionItem)
+	if err := got.Unmarshal(buf[:pinstore.PinCollectionItemSize]); err != nil {
+		t.Fatal(err)
+	}
+	if !got.Addr.Equal(item.Addr) || got.Stat != item.Stat || got.Label != "" || got.CreatedAt != 0 {
+		t.Fatalf("unexpected item %+v, want %+v", got, item)
+	}
+}
+
 func TestPinChunkItem(t *testing.T) {
 	t.Parallel()
 
@@ -557,4 +729,18 @@ func TestDirtyCollectionsItem(t *testing.T) {
 			UUID: pinstore.NewUUID(),
 		},
 	})
+	pending := &pinstore.DirtyCollection{
+		UUID: pinstore.NewUUID(),
+		Pending: &pinstore.PinCollectionItem{
+			Addr:      swarm.RandAddress(t),
+			UUID:      pinstore.NewUUID(),
+			Label:     "label",
+			CreatedAt: time.Now().UnixNano(),
+		},
+	}
+	storagetest.TestItemClone(t, &storagetest.ItemCloneTest{Item: pending})
+	storagetest.TestItemMarshalAndUnmarshal(t, &storagetest.ItemMarshalAndUnmarshalTest{
+		Item:    pending,
+		Factory: func() storage.Item { return &pinstore.DirtyCollection{UUID: pending.UUID} },
+	})
 }
@@ -248,6 +248,52 @@ func (c *BinItem) Unmarshal(buf []byte) error {
 	return nil
 }
 
+// BytesItem stores a part of the byte size of the chunks in the reserve. The
+// part of a bin is only updated under the lock of the bin, so the concurrent
+// transactions do not overwrite each other; the byte size is the sum of all parts.
+type BytesItem struct {
+	Bin   uint8
+	Bytes int64
+}
+
+func (b *BytesItem) Namespace() string {
+	return "reserveBytes"
+}
+
+func (b *BytesItem) ID() string {
+	return string(b.Bin)
+}
+
+func (b *BytesItem) String() string {
+	return path.Join(b.Namespace(), b.ID())
+}
+
+func (b *BytesItem) Clone() storage.Item {
+	if b == nil {
+		return nil
+	}
+	return &BytesItem{
+		Bin:   b.Bin,
+		Bytes: b.Bytes,
+	}
+}
+
+const bytesItemSize = 8
+
+func (b *BytesItem) Marshal() ([]byte, error) {
+	buf := make([]byte, bytesItemSize)
+	binary.BigEndian.PutUint64(buf, uint64(b.Bytes))
+	return buf, nil
+}
+
+func (b *BytesItem) Unmarshal(buf []byte) error {
+	if len(buf) != bytesItemSize {
+		return errUnmarshalInvalidSize
+	}
+	b.Bytes = int64(binary.BigEndian.Uint64(buf))
+	return nil
+}
+
 // EpochItem stores the timestamp in seconds of the initial creation of the reserve.
 type EpochItem struct {
 	Timestamp uint64
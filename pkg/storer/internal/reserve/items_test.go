@@ -56,6 +56,15 @@ func TestReserveItems(t *testing.T) {
 				Factory: func() storage.Item { return new(reserve.BinItem) },
 			},
 		},
+		{
+			name: "BytesItem",
+			test: &storagetest.ItemMarshalAndUnmarshalTest{
+				Item: &reserve.BytesItem{
+					Bytes: -4096,
+				},
+				Factory: func() storage.Item { return new(reserve.BytesItem) },
+			},
+		},
 		{
 			name: "RadiusItem",
 			test: &storagetest.ItemMarshalAndUnmarshalTest{
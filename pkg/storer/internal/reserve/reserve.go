@@ -20,6 +20,7 @@ import (
 	"github.com/ethersphere/bee/v2/pkg/postage"
 	"github.com/ethersphere/bee/v2/pkg/storage"
 	"github.com/ethersphere/bee/v2/pkg/storer/internal/chunkstamp"
+	"github.com/ethersphere/bee/v2/pkg/storer/internal/chunkstore"
 	"github.com/ethersphere/bee/v2/pkg/storer/internal/stampindex"
 	"github.com/ethersphere/bee/v2/pkg/storer/internal/transaction"
 	"github.com/ethersphere/bee/v2/pkg/swarm"
@@ -37,6 +38,7 @@ type Reserve struct {
 
 	capacity int
 	size     atomic.Int64
+	bytes    atomic.Int64 // byte size of the chunks, counted once per chunk.
 	radius   atomic.Uint32
 
 	multx *multex.Multex
@@ -86,6 +88,12 @@ func New(
 			return err
 		}
 		rs.size.Store(int64(size))
+
+		byteSize, err := loadBytes(s.IndexStore())
+		if err != nil {
+			return err
+		}
+		rs.bytes.Store(byteSize)
 		return nil
 	})
 
@@ -127,7 +135,10 @@ func (r *Reserve) Put(ctx context.Context, chunk swarm.Chunk) error {
 	r.multx.Lock(strconv.Itoa(int(bin)))
 	defer r.multx.Unlock(strconv.Itoa(int(bin)))
 
-	var shouldIncReserveSize bool
+	var (
+		shouldIncReserveSize bool
+		bytesDelta           int64
+	)
 
 	err = r.st.Run(ctx, func(s transaction.Store) error {
 
@@ -211,6 +222,21 @@ func (r *Reserve) Put(ctx context.Context, chunk swarm.Chunk) error {
 					return nil
 				}
 
+				// the data of the replaced soc may differ in size
+				if _, err := lockStamps(ctx, s, chunk.Address()); err != nil {
+					return err
+				}
+				switch oldSize, err := chunkstore.Size(s.IndexStore(), chunk.Address()); {
+				case err == nil:
+					bytesDelta = int64(len(chunk.Data())) - int64(oldSize)
+				case !errors.Is(err, storage.ErrNotFound):
+					return err
+				}
+
+				if err := addBytes(s.IndexStore(), bin, bytesDelta); err != nil {
+					return err
+				}
+
 				r.logger.Debug("replacing soc in chunkstore", "address", chunk.Address())
 				return s.ChunkStore().Replace(ctx, chunk)
 			}
@@ -230,10 +256,11 @@ func (r *Reserve) Put(ctx context.Context, chunk swarm.Chunk) error {
 			// 3. Delete ALL old chunk related items from the reserve.
 			// 4. Update the stamp index.
 
-			err = r.removeChunk(ctx, s, oldStampIndex.ChunkAddress, oldStampIndex.BatchID, oldStampIndex.StampHash)
+			freed, err := r.removeChunk(ctx, s, oldStampIndex.ChunkAddress, oldStampIndex.BatchID, oldStampIndex.StampHash)
 			if err != nil {
 				return fmt.Errorf("failed removing older chunk %s: %w", oldStampIndex.ChunkAddress, err)
 			}
+			bytesDelta -= freed
 
 			r.logger.Warning(
 				"replacing chunk stamp index",
@@ -249,6 +276,14 @@ func (r *Reserve) Put(ctx context.Context, chunk swarm.Chunk) error {
 			}
 		}
 
+		stamps, err := lockStamps(ctx, s, chunk.Address())
+		if err != nil {
+			return err
+		}
+		if stamps == 0 {
+			bytesDelta += int64(len(chunk.Data()))
+		}
+
 		binID, err := r.IncBinID(s.IndexStore(), bin)
 		if err != nil {
 			return err
@@ -272,6 +307,7 @@ func (r *Reserve) Put(ctx context.Context, chunk swarm.Chunk) error {
 				StampHash: stampHash,
 			}),
 			s.ChunkStore().Put(ctx, chunk),
+			addBytes(s.IndexStore(), bin, bytesDelta),
 		)
 		if err != nil {
 			return err
@@ -289,6 +325,7 @@ func (r *Reserve) Put(ctx context.Context, chunk swarm.Chunk) error {
 	if shouldIncReserveSize {
 		r.size.Add(1)
 	}
+	r.bytes.Add(bytesDelta)
 	return nil
 }
 
@@ -359,18 +396,28 @@ func (r *Reserve) EvictBatchBin(
 	eg, ctx := errgroup.WithContext(ctx)
 	eg.SetLimit(runtime.NumCPU())
 
-	var evicted atomic.Int64
+	var evicted, freed atomic.Int64
 
 	for _, item := range evicteditems {
 		func(item *BatchRadiusItem) {
 			eg.Go(func() error {
-				err := r.st.Run(ctx, func(s transaction.Store) error {
-					return RemoveChunkWithItem(ctx, s, item)
+				// the byte size part of the bin is updated under its lock.
+				r.multx.Lock(strconv.Itoa(int(item.Bin)))
+				defer r.multx.Unlock(strconv.Itoa(int(item.Bin)))
+
+				var n int64
+				err := r.st.Run(ctx, func(s transaction.Store) (err error) {
+					n, err = removeChunkWithItem(ctx, s, item)
+					if err != nil {
+						return err
+					}
+					return addBytes(s.IndexStore(), item.Bin, -n)
 				})
 				if err != nil {
 					return err
 				}
 				evicted.Add(1)
+				freed.Add(n)
 				return nil
 			})
 		}(item)
@@ -379,6 +426,7 @@ func (r *Reserve) EvictBatchBin(
 	err = eg.Wait()
 
 	r.size.Add(-evicted.Load())
+	r.bytes.Add(-freed.Load())
 
 	return int(evicted.Load()), err
 }
@@ -389,7 +437,7 @@ func (r *Reserve) removeChunk(
 	chunkAddress swarm.Address,
 	batchID []byte,
 	stampHash []byte,
-) error {
+) (int64, error) {
 	item := &BatchRadiusItem{
 		Bin:       swarm.Proximity(r.baseAddr.Bytes(), chunkAddress.Bytes()),
 		BatchID:   batchID,
@@ -398,9 +446,66 @@ func (r *Reserve) removeChunk(
 	}
 	err := trx.IndexStore().Get(item)
 	if err != nil {
+		return 0, err
+	}
+	return removeChunkWithItem(ctx, trx, item)
+}
+
+// removeChunkWithItem removes the chunk of the item like RemoveChunkWithItem
+// and returns the byte size freed in the reserve, which is zero if the chunk
+// is still held under another stamp.
+func removeChunkWithItem(
+	ctx context.Context,
+	trx transaction.Store,
+	item *BatchRadiusItem,
+) (int64, error) {
+	stamps, err := lockStamps(ctx, trx, item.Address)
+	if err != nil {
+		return 0, err
+	}
+	var size uint64
+	if stamps == 1 {
+		size, err = chunkstore.Size(trx.IndexStore(), item.Address)
+		if err != nil && !errors.Is(err, storage.ErrNotFound) {
+			return 0, err
+		}
+	}
+	return int64(size), RemoveChunkWithItem(ctx, trx, item)
+}
+
+// lockStamps locks the chunk address in the transaction, so the reserve stamps
+// of the chunk do not change until it is committed, and returns their number.
+func lockStamps(ctx context.Context, trx transaction.Store, addr swarm.Address) (int, error) {
+	if _, err := trx.ChunkStore().Has(ctx, addr); err != nil {
+		return 0, err
+	}
+	return chunkstamp.Count(trx.IndexStore(), reserveScope, addr)
+}
+
+// addBytes adds the delta to the byte size part of the bin. The lock of the
+// bin must be held until the transaction is committed.
+func addBytes(st storage.IndexStore, bin uint8, delta int64) error {
+	if delta == 0 {
+		return nil
+	}
+	item := &BytesItem{Bin: bin}
+	if err := st.Get(item); err != nil && !errors.Is(err, storage.ErrNotFound) {
 		return err
 	}
-	return RemoveChunkWithItem(ctx, trx, item)
+	item.Bytes += delta
+	return st.Put(item)
+}
+
+// loadBytes returns the byte size of the chunks in the reserve.
+func loadBytes(st storage.Reader) (int64, error) {
+	var size int64
+	err := st.Iterate(storage.Query{
+		Factory: func() storage.Item { return &BytesItem{} },
+	}, func(res storage.Result) (bool, error) {
+		size += res.Entry.(*BytesItem).Bytes
+		return false, nil
+	})
+	return size, err
 }
 
 func RemoveChunkWithItem(
@@ -566,10 +671,13 @@ func (r *Reserve) Reset(ctx context.Context) error {
 	}
 	sitems = nil
 
-	// step 4: delete binItems
+	// step 4: delete binItems and bytesItems
 	err = r.st.Run(context.Background(), func(s transaction.Store) error {
 		for i := uint8(0); i < swarm.MaxBins; i++ {
-			err := s.IndexStore().Delete(&BinItem{Bin: i})
+			err := errors.Join(
+				s.IndexStore().Delete(&BinItem{Bin: i}),
+				s.IndexStore().Delete(&BytesItem{Bin: i}),
+			)
 			if err != nil {
 				return err
 			}
@@ -581,6 +689,7 @@ func (r *Reserve) Reset(ctx context.Context) error {
 	}
 
 	r.size.Store(0)
+	r.bytes.Store(0)
 
 	return nil
 }
@@ -593,6 +702,11 @@ func (r *Reserve) Size() int {
 	return int(r.size.Load())
 }
 
+// Bytes returns the byte size of the chunks in the reserve.
+func (r *Reserve) Bytes() int {
+	return int(r.bytes.Load())
+}
+
 func (r *Reserve) Capacity() int {
 	return r.capacity
 }
@@ -8,8 +8,10 @@ import (
 	"bytes"
 	"context"
 	"errors"
+	"io/fs"
 	"math"
 	"math/rand"
+	"os"
 	"testing"
 	"time"
 
@@ -17,8 +19,10 @@ import (
 	"github.com/ethersphere/bee/v2/pkg/log"
 	"github.com/ethersphere/bee/v2/pkg/postage"
 	postagetesting "github.com/ethersphere/bee/v2/pkg/postage/testing"
+	"github.com/ethersphere/bee/v2/pkg/sharky"
 	soctesting "github.com/ethersphere/bee/v2/pkg/soc/testing"
 	"github.com/ethersphere/bee/v2/pkg/storage"
+	"github.com/ethersphere/bee/v2/pkg/storage/inmemstore"
 	chunk "github.com/ethersphere/bee/v2/pkg/storage/testing"
 	"github.com/ethersphere/bee/v2/pkg/storer/internal"
 	"github.com/ethersphere/bee/v2/pkg/storer/internal/chunkstamp"
@@ -27,6 +31,7 @@ import (
 	"github.com/ethersphere/bee/v2/pkg/storer/internal/transaction"
 	"github.com/ethersphere/bee/v2/pkg/swarm"
 	kademlia "github.com/ethersphere/bee/v2/pkg/topology/mock"
+	"github.com/spf13/afero"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -660,6 +665,74 @@ func TestEvictMaxCount(t *testing.T) {
 	}
 }
 
+func TestReserveBytes(t *testing.T) {
+	t.Parallel()
+
+	baseAddr := swarm.RandAddress(t)
+
+	// the byte size of the chunks is read from the chunk store index
+	sharky, err := sharky.New(&memFS{Fs: afero.NewMemMapFs()}, 1, swarm.SocMaxChunkSize)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ts := transaction.NewStorage(sharky, inmemstore.New())
+	t.Cleanup(func() {
+		if err := ts.Close(); err != nil {
+			t.Errorf("Close(): unexpected error: %v", err)
+		}
+	})
+
+	r, err := reserve.New(
+		baseAddr,
+		ts,
+		0, kademlia.NewTopologyDriver(),
+		log.Noop,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	batchA, batchB := postagetesting.MustNewBatch(), postagetesting.MustNewBatch()
+
+	chunks := []swarm.Chunk{
+		chunk.GenerateTestRandomChunkAt(t, baseAddr, 0).WithStamp(postagetesting.MustNewBatchStamp(batchA.ID)),
+		chunk.GenerateTestRandomChunkAt(t, baseAddr, 0).WithStamp(postagetesting.MustNewBatchStamp(batchA.ID)),
+	}
+	// the same chunk held under another stamp is counted once
+	chunks = append(chunks, swarm.NewChunk(chunks[0].Address(), chunks[0].Data()).WithStamp(postagetesting.MustNewBatchStamp(batchB.ID)))
+	for _, ch := range chunks {
+		if err := r.Put(context.Background(), ch); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	verify := func(t *testing.T, want int) {
+		t.Helper()
+		if have := r.Bytes(); have != want {
+			t.Fatalf("Bytes(): want %d, got %d", want, have)
+		}
+		loaded, err := reserve.New(baseAddr, ts, 0, kademlia.NewTopologyDriver(), log.Noop)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if have := loaded.Bytes(); have != want {
+			t.Fatalf("loaded Bytes(): want %d, got %d", want, have)
+		}
+	}
+
+	verify(t, len(chunks[0].Data())+len(chunks[1].Data()))
+
+	if _, err := r.EvictBatchBin(context.Background(), batchA.ID, math.MaxInt, swarm.MaxBins); err != nil {
+		t.Fatal(err)
+	}
+	verify(t, len(chunks[0].Data()))
+
+	if _, err := r.EvictBatchBin(context.Background(), batchB.ID, math.MaxInt, swarm.MaxBins); err != nil {
+		t.Fatal(err)
+	}
+	verify(t, 0)
+}
+
 func TestIterate(t *testing.T) {
 	t.Parallel()
 
@@ -954,3 +1027,11 @@ func checkChunkInIndexStore(t *testing.T, s storage.Reader, bin uint8, binId uin
 	checkStore(t, s, &reserve.BatchRadiusItem{Bin: bin, BatchID: ch.Stamp().BatchID(), Address: ch.Address(), StampHash: stampHash}, false)
 	checkStore(t, s, &reserve.ChunkBinItem{Bin: bin, BinID: binId, StampHash: stampHash}, false)
 }
+
+type memFS struct {
+	afero.Fs
+}
+
+func (m *memFS) Open(path string) (fs.File, error) {
+	return m.Fs.OpenFile(path, os.O_RDWR|os.O_CREATE, 0644)
+}
@@ -11,6 +11,7 @@ import (
 	"fmt"
 	"runtime"
 	"strconv"
+	"sync/atomic"
 	"time"
 
 	"github.com/ethersphere/bee/v2/pkg/encryption"
@@ -18,6 +19,7 @@ import (
 	"github.com/ethersphere/bee/v2/pkg/storage/storageutil"
 	"github.com/ethersphere/bee/v2/pkg/storer/internal"
 	"github.com/ethersphere/bee/v2/pkg/storer/internal/chunkstamp"
+	"github.com/ethersphere/bee/v2/pkg/storer/internal/chunkstore"
 	"github.com/ethersphere/bee/v2/pkg/storer/internal/transaction"
 	"github.com/ethersphere/bee/v2/pkg/swarm"
 	"golang.org/x/sync/errgroup"
@@ -374,16 +376,96 @@ var (
 	errOverwriteOfNewerBatch = errors.New("upload store: overwrite of existing batch with newer timestamp")
 )
 
+// PendingSize keeps the byte size of the chunks pending upload. A chunk
+// uploaded with several batches is counted once.
+type PendingSize struct {
+	size atomic.Int64
+}
+
+// NewPendingSize returns the PendingSize of the chunks pending upload in the store.
+func NewPendingSize(st storage.Reader) (*PendingSize, error) {
+	p := new(PendingSize)
+	var prev swarm.Address
+	err := IterateAllAddresses(st, func(addr swarm.Address) (bool, error) {
+		// the upload items are ordered by the chunk address
+		if addr.Equal(prev) {
+			return false, nil
+		}
+		prev = addr
+
+		size, err := chunkstore.Size(st, addr)
+		if errors.Is(err, storage.ErrNotFound) {
+			return false, nil
+		}
+		if err != nil {
+			return true, err
+		}
+		p.size.Add(int64(size))
+		return false, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// Bytes returns the byte size of the chunks pending upload.
+func (p *PendingSize) Bytes() uint64 {
+	return uint64(p.size.Load())
+}
+
+func (p *PendingSize) add(n int64) {
+	p.size.Add(n)
+}
+
+// lockUploads locks the chunk address in the transaction, so the upload items
+// of the chunk do not change until it is committed, and returns their number.
+func lockUploads(ctx context.Context, st transaction.Store, addr swarm.Address) (int, error) {
+	if _, err := st.ChunkStore().Has(ctx, addr); err != nil {
+		return 0, err
+	}
+	var n int
+	err := st.IndexStore().Iterate(
+		storage.Query{
+			Factory:      func() storage.Item { return new(uploadItem) },
+			Prefix:       addr.ByteString(),
+			ItemProperty: storage.QueryItemID,
+		},
+		func(storage.Result) (bool, error) {
+			n++
+			return false, nil
+		},
+	)
+	return n, err
+}
+
+// freedSize returns the byte size freed once the upload item of the chunk is
+// deleted in the transaction, zero if the chunk is still pending upload with
+// another batch.
+func freedSize(ctx context.Context, st transaction.Store, addr swarm.Address) (int64, error) {
+	uploads, err := lockUploads(ctx, st, addr)
+	if err != nil || uploads != 1 {
+		return 0, err
+	}
+	size, err := chunkstore.Size(st.IndexStore(), addr)
+	if errors.Is(err, storage.ErrNotFound) {
+		return 0, nil
+	}
+	return int64(size), err
+}
+
 type uploadPutter struct {
 	tagID  uint64
 	split  uint64
 	seen   uint64
 	closed bool
+	size   *PendingSize
 }
 
-// NewPutter returns a new chunk putter associated with the tagID.
+// NewPutter returns a new chunk putter associated with the tagID, which keeps
+// the byte size of the chunks pending upload in the size.
 // Calls to the Putter must be mutex locked to prevent concurrent upload data races.
-func NewPutter(s storage.IndexStore, tagID uint64) (internal.PutterCloserWithReference, error) {
+func NewPutter(s storage.IndexStore, tagID uint64, size *PendingSize) (internal.PutterCloserWithReference, error) {
 	ti := &TagItem{TagID: tagID}
 	has, err := s.Has(ti)
 	if err != nil {
@@ -398,6 +480,7 @@ func NewPutter(s storage.IndexStore, tagID uint64) (internal.PutterCloserWithRef
 	}
 	return &uploadPutter{
 		tagID: ti.TagID,
+		size:  size,
 	}, nil
 }
 
@@ -426,6 +509,11 @@ func (u *uploadPutter) Put(ctx context.Context, st transaction.Store, chunk swar
 
 	u.split++
 
+	uploads, err := lockUploads(ctx, st, chunk.Address())
+	if err != nil {
+		return fmt.Errorf("store count upload items call failed: %w", err)
+	}
+
 	ui.Uploaded = now().UnixNano()
 	ui.TagID = u.tagID
 
@@ -436,12 +524,20 @@ func (u *uploadPutter) Put(ctx context.Context, st transaction.Store, chunk swar
 		TagID:     u.tagID,
 	}
 
-	return errors.Join(
+	err = errors.Join(
 		st.IndexStore().Put(ui),
 		st.IndexStore().Put(pi),
 		st.ChunkStore().Put(ctx, chunk),
 		chunkstamp.Store(st.IndexStore(), uploadScope, chunk),
 	)
+	if err != nil {
+		return err
+	}
+
+	if uploads == 0 {
+		u.size.add(int64(len(chunk.Data())))
+	}
+	return nil
 }
 
 // Close provides the CloseWithReference interface where the session can be associated
@@ -511,7 +607,12 @@ func (u *uploadPutter) Cleanup(st transaction.Storage) error {
 	for _, item := range itemsToDelete {
 		func(item *pushItem) {
 			eg.Go(func() error {
-				return st.Run(context.Background(), func(s transaction.Store) error {
+				var freed int64
+				err := st.Run(context.Background(), func(s transaction.Store) (err error) {
+					freed, err = freedSize(context.Background(), s, item.Address)
+					if err != nil {
+						return err
+					}
 					ui := &uploadItem{Address: item.Address, BatchID: item.BatchID}
 					return errors.Join(
 						s.IndexStore().Delete(ui),
@@ -520,6 +621,11 @@ func (u *uploadPutter) Cleanup(st transaction.Storage) error {
 						s.IndexStore().Delete(item),
 					)
 				})
+				if err != nil {
+					return err
+				}
+				u.size.add(-freed)
+				return nil
 			})
 		}(item)
 	}
@@ -533,7 +639,7 @@ func (u *uploadPutter) Cleanup(st transaction.Storage) error {
 }
 
 // CleanupDirty does a best-effort cleanup of dirty tags. This is called on startup.
-func CleanupDirty(st transaction.Storage) error {
+func CleanupDirty(st transaction.Storage, size *PendingSize) error {
 	dirtyTags := make([]*dirtyTagItem, 0)
 
 	err := st.IndexStore().Iterate(
@@ -551,14 +657,15 @@ func CleanupDirty(st transaction.Storage) error {
 	}
 
 	for _, di := range dirtyTags {
-		err = errors.Join(err, (&uploadPutter{tagID: di.TagID}).Cleanup(st))
+		err = errors.Join(err, (&uploadPutter{tagID: di.TagID, size: size}).Cleanup(st))
 	}
 
 	return err
 }
 
-// Report is the implementation of the PushReporter interface.
-func Report(ctx context.Context, st transaction.Store, chunk swarm.Chunk, state storage.ChunkState) error {
+// Report is the implementation of the PushReporter interface. The chunks no
+// longer pending upload are removed from the size.
+func Report(ctx context.Context, st transaction.Store, size *PendingSize, chunk swarm.Chunk, state storage.ChunkState) error {
 
 	ui := &uploadItem{Address: chunk.Address(), BatchID: chunk.Stamp().BatchID()}
 
@@ -612,12 +719,23 @@ func Report(ctx context.Context, st transaction.Store, chunk swarm.Chunk, state
 		BatchID:   chunk.Stamp().BatchID(),
 	}
 
-	return errors.Join(
+	freed, err := freedSize(ctx, st, chunk.Address())
+	if err != nil {
+		return fmt.Errorf("failed getting freed size: %w", err)
+	}
+
+	err = errors.Join(
 		indexStore.Delete(pi),
 		chunkstamp.Delete(indexStore, uploadScope, pi.Address, pi.BatchID),
 		st.ChunkStore().Delete(ctx, chunk.Address()),
 		indexStore.Delete(ui),
 	)
+	if err != nil {
+		return err
+	}
+
+	size.add(-freed)
+	return nil
 }
 
 var (
@@ -763,6 +881,22 @@ func IterateAll(st storage.Reader, iterateFn func(item storage.Item) (bool, erro
 	)
 }
 
+// IterateAllAddresses iterates over the chunk addresses of all the upload items.
+func IterateAllAddresses(st storage.Reader, iterateFn func(addr swarm.Address) (bool, error)) error {
+	return st.Iterate(
+		storage.Query{
+			Factory:      func() storage.Item { return new(uploadItem) },
+			ItemProperty: storage.QueryItemID,
+		},
+		func(r storage.Result) (bool, error) {
+			if len(r.ID) < swarm.HashSize {
+				return true, fmt.Errorf("invalid upload item id %x", r.ID)
+			}
+			return iterateFn(swarm.NewAddress([]byte(r.ID[:swarm.HashSize])))
+		},
+	)
+}
+
 func IterateAllTagItems(st storage.Reader, cb func(ti *TagItem) (bool, error)) error {
 	return st.Iterate(
 		storage.Query{
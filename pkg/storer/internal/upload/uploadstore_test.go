@@ -9,13 +9,17 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io/fs"
 	"math"
 	"math/rand"
 	"os"
 	"testing"
 	"time"
 
+	postagetesting "github.com/ethersphere/bee/v2/pkg/postage/testing"
+	"github.com/ethersphere/bee/v2/pkg/sharky"
 	storage "github.com/ethersphere/bee/v2/pkg/storage"
+	"github.com/ethersphere/bee/v2/pkg/storage/inmemstore"
 	"github.com/ethersphere/bee/v2/pkg/storage/storagetest"
 	chunktest "github.com/ethersphere/bee/v2/pkg/storage/testing"
 	"github.com/ethersphere/bee/v2/pkg/storer/internal"
@@ -24,6 +28,7 @@ import (
 	"github.com/ethersphere/bee/v2/pkg/swarm"
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/spf13/afero"
 )
 
 // now is a function that returns the current time and replaces time.Now.
@@ -436,6 +441,32 @@ func newTestStorage(t *testing.T) transaction.Storage {
 	return storg
 }
 
+type memFS struct {
+	afero.Fs
+}
+
+func (m *memFS) Open(path string) (fs.File, error) {
+	return m.Fs.OpenFile(path, os.O_RDWR|os.O_CREATE, 0644)
+}
+
+// newSharkyStorage returns a storage keeping the chunks in sharky, so the
+// byte size of the stored chunks is known from the index store.
+func newSharkyStorage(t *testing.T) transaction.Storage {
+	t.Helper()
+
+	sharky, err := sharky.New(&memFS{Fs: afero.NewMemMapFs()}, 1, swarm.SocMaxChunkSize)
+	if err != nil {
+		t.Fatal(err)
+	}
+	st := transaction.NewStorage(sharky, inmemstore.New())
+	t.Cleanup(func() {
+		if err := st.Close(); err != nil {
+			t.Errorf("Close(): unexpected error: %v", err)
+		}
+	})
+	return st
+}
+
 func TestChunkPutter(t *testing.T) {
 	t.Parallel()
 
@@ -448,7 +479,7 @@ func TestChunkPutter(t *testing.T) {
 		t.Fatalf("failed creating tag: %v", err)
 	}
 
-	putter, err := upload.NewPutter(tx.IndexStore(), tag.TagID)
+	putter, err := upload.NewPutter(tx.IndexStore(), tag.TagID, new(upload.PendingSize))
 	if err != nil {
 		t.Fatalf("failed creating putter: %v", err)
 	}
@@ -624,7 +655,7 @@ func TestChunkPutter(t *testing.T) {
 		var putter internal.PutterCloserWithReference
 
 		err = ts.Run(context.Background(), func(s transaction.Store) error {
-			putter, err = upload.NewPutter(s.IndexStore(), tag.TagID)
+			putter, err = upload.NewPutter(s.IndexStore(), tag.TagID, new(upload.PendingSize))
 			return err
 		})
 		if err != nil {
@@ -683,8 +714,9 @@ func TestChunkReporter(t *testing.T) {
 		t.Fatalf("failed creating tag: %v", err)
 	}
 
+	size := new(upload.PendingSize)
 	if err := ts.Run(context.Background(), func(s transaction.Store) error {
-		putter, err = upload.NewPutter(s.IndexStore(), tag.TagID)
+		putter, err = upload.NewPutter(s.IndexStore(), tag.TagID, size)
 		return err
 	}); err != nil {
 		t.Fatalf("failed creating putter: %v", err)
@@ -702,7 +734,7 @@ func TestChunkReporter(t *testing.T) {
 			report := func(ch swarm.Chunk, state int) {
 				t.Helper()
 				if err := ts.Run(context.Background(), func(s transaction.Store) error {
-					return upload.Report(context.Background(), s, ch, state)
+					return upload.Report(context.Background(), s, size, ch, state)
 				}); err != nil {
 					t.Fatalf("Report(...): unexpected error: %v", err)
 				}
@@ -919,7 +951,7 @@ func TestIterate(t *testing.T) {
 
 		var putter internal.PutterCloserWithReference
 		err = ts.Run(context.Background(), func(s transaction.Store) error {
-			putter, err = upload.NewPutter(s.IndexStore(), tag.TagID)
+			putter, err = upload.NewPutter(s.IndexStore(), tag.TagID, new(upload.PendingSize))
 			return err
 		})
 		if err != nil {
@@ -1020,7 +1052,7 @@ func TestBatchIDForChunk(t *testing.T) {
 
 	var putter internal.PutterCloserWithReference
 	err = ts.Run(context.Background(), func(s transaction.Store) error {
-		putter, err = upload.NewPutter(s.IndexStore(), tag.TagID)
+		putter, err = upload.NewPutter(s.IndexStore(), tag.TagID, new(upload.PendingSize))
 		return err
 	})
 	if err != nil {
@@ -1042,6 +1074,71 @@ func TestBatchIDForChunk(t *testing.T) {
 	}
 }
 
+func TestPendingSize(t *testing.T) {
+	t.Parallel()
+
+	ts := newSharkyStorage(t)
+
+	var tag upload.TagItem
+	var err error
+	err = ts.Run(context.Background(), func(s transaction.Store) error {
+		tag, err = upload.NextTag(s.IndexStore())
+		return err
+	})
+	if err != nil {
+		t.Fatalf("failed creating tag: %v", err)
+	}
+
+	size := new(upload.PendingSize)
+	var putter internal.PutterCloserWithReference
+	err = ts.Run(context.Background(), func(s transaction.Store) error {
+		putter, err = upload.NewPutter(s.IndexStore(), tag.TagID, size)
+		return err
+	})
+	if err != nil {
+		t.Fatalf("failed creating putter: %v", err)
+	}
+
+	chunks := chunktest.GenerateTestRandomChunks(2)
+	// the same chunk uploaded with another batch is counted once
+	chunks = append(chunks, swarm.NewChunk(chunks[0].Address(), chunks[0].Data()).WithStamp(postagetesting.MustNewStamp()))
+	for _, ch := range chunks {
+		if err := put(t, ts, putter, ch); err != nil {
+			t.Fatalf("Put(...): unexpected error: %v", err)
+		}
+	}
+
+	verify := func(t *testing.T, want int) {
+		t.Helper()
+		if have := size.Bytes(); have != uint64(want) {
+			t.Fatalf("Bytes(): want %d; have %d", want, have)
+		}
+		loaded, err := upload.NewPendingSize(ts.IndexStore())
+		if err != nil {
+			t.Fatalf("NewPendingSize(...): unexpected error: %v", err)
+		}
+		if have := loaded.Bytes(); have != uint64(want) {
+			t.Fatalf("NewPendingSize(...).Bytes(): want %d; have %d", want, have)
+		}
+	}
+
+	verify(t, len(chunks[0].Data())+len(chunks[1].Data()))
+
+	for i, want := range []int{
+		len(chunks[0].Data()) + len(chunks[1].Data()),
+		len(chunks[0].Data()),
+		0,
+	} {
+		err := ts.Run(context.Background(), func(s transaction.Store) error {
+			return upload.Report(context.Background(), s, size, chunks[i], storage.ChunkSynced)
+		})
+		if err != nil {
+			t.Fatalf("Report(...): unexpected error: %v", err)
+		}
+		verify(t, want)
+	}
+}
+
 func TestCleanup(t *testing.T) {
 	t.Parallel()
 
@@ -1062,7 +1159,7 @@ func TestCleanup(t *testing.T) {
 
 		var putter internal.PutterCloserWithReference
 		err = ts.Run(context.Background(), func(s transaction.Store) error {
-			putter, err = upload.NewPutter(s.IndexStore(), tag.TagID)
+			putter, err = upload.NewPutter(s.IndexStore(), tag.TagID, new(upload.PendingSize))
 			return err
 		})
 		if err != nil {
@@ -1111,7 +1208,7 @@ func TestCleanup(t *testing.T) {
 
 		var putter internal.PutterCloserWithReference
 		err = ts.Run(context.Background(), func(s transaction.Store) error {
-			putter, err = upload.NewPutter(s.IndexStore(), tag.TagID)
+			putter, err = upload.NewPutter(s.IndexStore(), tag.TagID, new(upload.PendingSize))
 			return err
 		})
 		if err != nil {
@@ -1124,7 +1221,7 @@ func TestCleanup(t *testing.T) {
 			t.Fatal("session.Put(...): unexpected error", err)
 		}
 
-		err = upload.CleanupDirty(ts)
+		err = upload.CleanupDirty(ts, new(upload.PendingSize))
 		if err != nil {
 			t.Fatal("upload.Cleanup(...): unexpected error", err)
 		}
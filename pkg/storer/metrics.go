@@ -24,8 +24,12 @@ type metrics struct {
 	ReserveCleanup          prometheus.Counter
 	StorageRadius           prometheus.Gauge
 	CacheSize               prometheus.Gauge
+	CacheBytes              prometheus.Gauge
+	CacheHits               prometheus.CounterVec
+	CacheMisses             prometheus.CounterVec
 	EvictedChunkCount       prometheus.Counter
 	ExpiredChunkCount       prometheus.Counter
+	ExpiredPinCount         prometheus.Counter
 	OverCapTriggerCount     prometheus.Counter
 	ExpiredBatchCount       prometheus.Counter
 	LevelDBStats            prometheus.HistogramVec
@@ -106,6 +110,32 @@ func newMetrics() metrics {
 				Help:      "Number of chunks in cache.",
 			},
 		),
+		CacheBytes: prometheus.NewGauge(
+			prometheus.GaugeOpts{
+				Namespace: m.Namespace,
+				Subsystem: subsystem,
+				Name:      "cache_bytes",
+				Help:      "Byte size of chunks in cache.",
+			},
+		),
+		CacheHits: *prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: m.Namespace,
+				Subsystem: subsystem,
+				Name:      "cache_hits",
+				Help:      "Number of cache lookups served by a cache entry.",
+			},
+			[]string{"policy"},
+		),
+		CacheMisses: *prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: m.Namespace,
+				Subsystem: subsystem,
+				Name:      "cache_misses",
+				Help:      "Number of cache lookups not served by a cache entry.",
+			},
+			[]string{"policy"},
+		),
 		EvictedChunkCount: prometheus.NewCounter(
 			prometheus.CounterOpts{
 				Namespace: m.Namespace,
@@ -122,6 +152,14 @@ func newMetrics() metrics {
 				Help:      "Number of chunks expired from reserve due to stamp expirations.",
 			},
 		),
+		ExpiredPinCount: prometheus.NewCounter(
+			prometheus.CounterOpts{
+				Namespace: m.Namespace,
+				Subsystem: subsystem,
+				Name:      "expired_pin_count",
+				Help:      "Number of pin collections removed due to their expiry.",
+			},
+		),
 		OverCapTriggerCount: prometheus.NewCounter(
 			prometheus.CounterOpts{
 				Namespace: m.Namespace,
@@ -25,6 +25,9 @@ func AfterInitSteps(
 		4: step_04(sharkyPath, sharkyNoOfShards, st, logger),
 		5: step_05(st, logger),
 		6: step_06(st, logger),
+		7: step_07(st, logger),
+		8: step_08(st, logger),
+		9: step_09(st, logger),
 	}
 }
 
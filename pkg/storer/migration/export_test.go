@@ -10,4 +10,7 @@ var (
 	Step_04 = step_04
 	Step_05 = step_05
 	Step_06 = step_06
+	Step_07 = step_07
+	Step_08 = step_08
+	Step_09 = step_09
 )
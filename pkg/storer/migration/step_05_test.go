@@ -68,7 +68,7 @@ func Test_Step_05(t *testing.T) {
 
 	var putter internal.PutterCloserWithReference
 	err = store.Run(context.Background(), func(s transaction.Store) error {
-		putter, err = upload.NewPutter(s.IndexStore(), tag.TagID)
+		putter, err = upload.NewPutter(s.IndexStore(), tag.TagID, new(upload.PendingSize))
 		return err
 	})
 	if err != nil {
@@ -0,0 +1,75 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package migration
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ethersphere/bee/v2/pkg/log"
+	"github.com/ethersphere/bee/v2/pkg/storage"
+	"github.com/ethersphere/bee/v2/pkg/storer/internal/cache"
+	"github.com/ethersphere/bee/v2/pkg/storer/internal/chunkstore"
+	"github.com/ethersphere/bee/v2/pkg/storer/internal/transaction"
+)
+
+// step_07BatchSize is the number of the cache entries updated in one transaction.
+const step_07BatchSize = 10_000
+
+// step_07 is a migration step that adds the byte size of the chunk to all the
+// cache entries, so the capacity of the cache is measured in bytes.
+func step_07(st transaction.Storage, logger log.Logger) func() error {
+	return func() error {
+		logger := logger.WithName("migration-step-07").Register()
+
+		logger.Info("start adding chunk size to cache entries")
+
+		var entries []*cache.CacheEntryItem
+		err := st.IndexStore().Iterate(
+			storage.Query{
+				Factory: func() storage.Item { return &cache.CacheEntryItem{} },
+			},
+			func(res storage.Result) (bool, error) {
+				entry := res.Entry.(*cache.CacheEntryItem)
+				if entry.Size > 0 {
+					return false, nil
+				}
+				size, err := chunkstore.Size(st.IndexStore(), entry.Address)
+				switch {
+				case errors.Is(err, storage.ErrNotFound):
+					logger.Debug("chunk of cache entry not found", "address", entry.Address)
+					return false, nil
+				case err != nil:
+					return true, fmt.Errorf("chunk size %s: %w", entry.Address, err)
+				}
+				entry.Size = size
+				entries = append(entries, entry)
+				return false, nil
+			},
+		)
+		if err != nil {
+			return err
+		}
+
+		for i := 0; i < len(entries); i += step_07BatchSize {
+			batch := entries[i:min(i+step_07BatchSize, len(entries))]
+			err := st.Run(context.Background(), func(s transaction.Store) error {
+				for _, entry := range batch {
+					if err := s.IndexStore().Put(entry); err != nil {
+						return err
+					}
+				}
+				return nil
+			})
+			if err != nil {
+				return fmt.Errorf("put cache entries: %w", err)
+			}
+		}
+
+		logger.Info("finished adding chunk size to cache entries", "migrated", len(entries))
+		return nil
+	}
+}
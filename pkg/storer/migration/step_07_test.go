@@ -0,0 +1,68 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package migration_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ethersphere/bee/v2/pkg/cac"
+	"github.com/ethersphere/bee/v2/pkg/log"
+	"github.com/ethersphere/bee/v2/pkg/sharky"
+	"github.com/ethersphere/bee/v2/pkg/storage/leveldbstore"
+	chunktest "github.com/ethersphere/bee/v2/pkg/storage/testing"
+	"github.com/ethersphere/bee/v2/pkg/storer/internal/cache"
+	"github.com/ethersphere/bee/v2/pkg/storer/internal/transaction"
+	localmigration "github.com/ethersphere/bee/v2/pkg/storer/migration"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+	"github.com/ethersphere/bee/v2/pkg/util/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Step_07(t *testing.T) {
+	t.Parallel()
+
+	sharkyStore, err := sharky.New(&dirFS{basedir: t.TempDir()}, 1, swarm.SocMaxChunkSize)
+	assert.NoError(t, err)
+
+	lstore, err := leveldbstore.New("", nil)
+	assert.NoError(t, err)
+
+	store := transaction.NewStorage(sharkyStore, lstore)
+	t.Cleanup(func() {
+		if err := store.Close(); err != nil {
+			t.Fatalf("Close(): unexpected closing storer: %v", err)
+		}
+	})
+
+	short, err := cac.New(testutil.RandBytes(t, 100))
+	assert.NoError(t, err)
+	chunks := append(chunktest.GenerateTestRandomChunks(5), short)
+
+	// simulate the cache entries stored without the chunk size.
+	err = store.Run(context.Background(), func(s transaction.Store) error {
+		for _, ch := range chunks {
+			if err := s.ChunkStore().Put(context.Background(), ch); err != nil {
+				return err
+			}
+			entry := &cache.CacheEntryItem{Address: ch.Address(), AccessTimestamp: time.Now().UnixNano()}
+			if err := s.IndexStore().Put(entry); err != nil {
+				return err
+			}
+		}
+		// the chunk of the entry is missing
+		return s.IndexStore().Put(&cache.CacheEntryItem{Address: swarm.RandAddress(t), AccessTimestamp: time.Now().UnixNano()})
+	})
+	assert.NoError(t, err)
+
+	assert.NoError(t, localmigration.Step_07(store, log.Noop)())
+
+	for _, ch := range chunks {
+		entry := &cache.CacheEntryItem{Address: ch.Address()}
+		assert.NoError(t, store.IndexStore().Get(entry))
+		assert.Equal(t, uint64(len(ch.Data())), entry.Size)
+	}
+}
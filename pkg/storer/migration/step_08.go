@@ -0,0 +1,70 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package migration
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ethersphere/bee/v2/pkg/log"
+	"github.com/ethersphere/bee/v2/pkg/storage"
+	"github.com/ethersphere/bee/v2/pkg/storer/internal/chunkstore"
+	pinstore "github.com/ethersphere/bee/v2/pkg/storer/internal/pinning"
+	"github.com/ethersphere/bee/v2/pkg/storer/internal/transaction"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+)
+
+// step_08 is a migration step that adds the byte size of the stored chunks to
+// the pinning collections created before the size was recorded, so the pin
+// quota accounts for all the pinned data.
+func step_08(st transaction.Storage, logger log.Logger) func() error {
+	return func() error {
+		logger := logger.WithName("migration-step-08").Register()
+
+		logger.Info("start adding chunk size to pinning collections")
+
+		collections, err := pinstore.Collections(st.IndexStore())
+		if err != nil {
+			return err
+		}
+
+		migrated := 0
+		for _, c := range collections {
+			// the collections with chunks always have a size once it is recorded
+			if c.Stat.Size > 0 || c.Stat.Total == 0 {
+				continue
+			}
+
+			var size uint64
+			err := pinstore.IterateCollection(st.IndexStore(), c.Addr, func(addr swarm.Address) (bool, error) {
+				s, err := chunkstore.Size(st.IndexStore(), addr)
+				switch {
+				case errors.Is(err, storage.ErrNotFound):
+					logger.Debug("chunk of pinning collection not found", "collection", c.Addr, "address", addr)
+					return false, nil
+				case err != nil:
+					return true, fmt.Errorf("chunk size %s: %w", addr, err)
+				}
+				size += s
+				return false, nil
+			})
+			if err != nil {
+				return err
+			}
+
+			err = st.Run(context.Background(), func(s transaction.Store) error {
+				return pinstore.SetCollectionSize(s.IndexStore(), c.Addr, size)
+			})
+			if err != nil {
+				return fmt.Errorf("set size of pinning collection %s: %w", c.Addr, err)
+			}
+			migrated++
+		}
+
+		logger.Info("finished adding chunk size to pinning collections", "migrated", migrated)
+		return nil
+	}
+}
@@ -0,0 +1,90 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package migration_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ethersphere/bee/v2/pkg/cac"
+	"github.com/ethersphere/bee/v2/pkg/log"
+	"github.com/ethersphere/bee/v2/pkg/sharky"
+	"github.com/ethersphere/bee/v2/pkg/storage/leveldbstore"
+	chunktest "github.com/ethersphere/bee/v2/pkg/storage/testing"
+	pinstore "github.com/ethersphere/bee/v2/pkg/storer/internal/pinning"
+	"github.com/ethersphere/bee/v2/pkg/storer/internal/transaction"
+	localmigration "github.com/ethersphere/bee/v2/pkg/storer/migration"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+	"github.com/ethersphere/bee/v2/pkg/util/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Step_08(t *testing.T) {
+	t.Parallel()
+
+	sharkyStore, err := sharky.New(&dirFS{basedir: t.TempDir()}, 1, swarm.SocMaxChunkSize)
+	assert.NoError(t, err)
+
+	lstore, err := leveldbstore.New("", nil)
+	assert.NoError(t, err)
+
+	store := transaction.NewStorage(sharkyStore, lstore)
+	t.Cleanup(func() {
+		if err := store.Close(); err != nil {
+			t.Fatalf("Close(): unexpected closing storer: %v", err)
+		}
+	})
+
+	short, err := cac.New(testutil.RandBytes(t, 100))
+	assert.NoError(t, err)
+
+	// pin collections with and without a duplicate chunk
+	collections := [][]swarm.Chunk{
+		append(chunktest.GenerateTestRandomChunks(5), short),
+		append(chunktest.GenerateTestRandomChunks(3), short, short),
+	}
+	var (
+		roots []swarm.Address
+		sizes []uint64
+	)
+	for _, chunks := range collections {
+		var putter pinstore.CollectionPutter
+		err := store.Run(context.Background(), func(s transaction.Store) error {
+			putter, err = pinstore.NewCollection(s.IndexStore(), "", time.Time{})
+			return err
+		})
+		assert.NoError(t, err)
+
+		for _, ch := range chunks {
+			err := store.Run(context.Background(), func(s transaction.Store) error {
+				return putter.Put(context.Background(), s, ch)
+			})
+			assert.NoError(t, err)
+		}
+
+		root := chunks[0].Address()
+		err = store.Run(context.Background(), func(s transaction.Store) error {
+			return putter.Close(s.IndexStore(), root)
+		})
+		assert.NoError(t, err)
+		// simulate the collection stored without the chunk size.
+		err = store.Run(context.Background(), func(s transaction.Store) error {
+			return pinstore.SetCollectionSize(s.IndexStore(), root, 0)
+		})
+		assert.NoError(t, err)
+
+		roots = append(roots, root)
+		sizes = append(sizes, putter.Size())
+	}
+
+	assert.NoError(t, localmigration.Step_08(store, log.Noop)())
+
+	for i, root := range roots {
+		c, err := pinstore.GetCollection(store.IndexStore(), root)
+		assert.NoError(t, err)
+		assert.Equal(t, sizes[i], c.Stat.Size)
+	}
+}
@@ -0,0 +1,80 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package migration
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ethersphere/bee/v2/pkg/log"
+	"github.com/ethersphere/bee/v2/pkg/storage"
+	"github.com/ethersphere/bee/v2/pkg/storer/internal/chunkstamp"
+	"github.com/ethersphere/bee/v2/pkg/storer/internal/chunkstore"
+	"github.com/ethersphere/bee/v2/pkg/storer/internal/reserve"
+	"github.com/ethersphere/bee/v2/pkg/storer/internal/transaction"
+)
+
+// step_09 is a migration step that stores the byte size of the chunks in the
+// reserve, so it does not have to be computed on every start of the node.
+// A chunk held under several stamps is counted under the first of them only.
+func step_09(st transaction.Storage, logger log.Logger) func() error {
+	return func() error {
+		logger := logger.WithName("migration-step-09").Register()
+
+		logger.Info("start computing the byte size of the reserve")
+
+		var size int64
+		err := st.IndexStore().Iterate(
+			storage.Query{
+				Factory: func() storage.Item { return &reserve.ChunkBinItem{} },
+			},
+			func(res storage.Result) (bool, error) {
+				item := res.Entry.(*reserve.ChunkBinItem)
+
+				stamp, err := chunkstamp.Load(st.IndexStore(), "reserve", item.Address)
+				switch {
+				case errors.Is(err, storage.ErrNotFound):
+					logger.Debug("stamp of reserve chunk not found", "address", item.Address)
+					return false, nil
+				case err != nil:
+					return true, fmt.Errorf("load stamp %s: %w", item.Address, err)
+				}
+				stampHash, err := stamp.Hash()
+				if err != nil {
+					return true, err
+				}
+				if !bytes.Equal(stampHash, item.StampHash) {
+					return false, nil
+				}
+
+				n, err := chunkstore.Size(st.IndexStore(), item.Address)
+				switch {
+				case errors.Is(err, storage.ErrNotFound):
+					logger.Debug("reserve chunk not found", "address", item.Address)
+					return false, nil
+				case err != nil:
+					return true, fmt.Errorf("chunk size %s: %w", item.Address, err)
+				}
+				size += int64(n)
+				return false, nil
+			},
+		)
+		if err != nil {
+			return err
+		}
+
+		err = st.Run(context.Background(), func(s transaction.Store) error {
+			return s.IndexStore().Put(&reserve.BytesItem{Bin: 0, Bytes: size})
+		})
+		if err != nil {
+			return fmt.Errorf("put reserve byte size: %w", err)
+		}
+
+		logger.Info("finished computing the byte size of the reserve", "bytes", size)
+		return nil
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright 2024 The Swarm Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package migration_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ethersphere/bee/v2/pkg/log"
+	postagetesting "github.com/ethersphere/bee/v2/pkg/postage/testing"
+	"github.com/ethersphere/bee/v2/pkg/sharky"
+	"github.com/ethersphere/bee/v2/pkg/storage/leveldbstore"
+	chunktest "github.com/ethersphere/bee/v2/pkg/storage/testing"
+	"github.com/ethersphere/bee/v2/pkg/storer/internal/reserve"
+	"github.com/ethersphere/bee/v2/pkg/storer/internal/transaction"
+	localmigration "github.com/ethersphere/bee/v2/pkg/storer/migration"
+	"github.com/ethersphere/bee/v2/pkg/swarm"
+	kademlia "github.com/ethersphere/bee/v2/pkg/topology/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Step_09(t *testing.T) {
+	t.Parallel()
+
+	sharkyStore, err := sharky.New(&dirFS{basedir: t.TempDir()}, 1, swarm.SocMaxChunkSize)
+	assert.NoError(t, err)
+
+	lstore, err := leveldbstore.New("", nil)
+	assert.NoError(t, err)
+
+	store := transaction.NewStorage(sharkyStore, lstore)
+	t.Cleanup(func() {
+		if err := store.Close(); err != nil {
+			t.Fatalf("Close(): unexpected closing storer: %v", err)
+		}
+	})
+
+	baseAddr := swarm.RandAddress(t)
+	r, err := reserve.New(baseAddr, store, 0, kademlia.NewTopologyDriver(), log.Noop)
+	assert.NoError(t, err)
+
+	batchA, batchB := postagetesting.MustNewBatch(), postagetesting.MustNewBatch()
+
+	var want int
+	for bin := uint8(0); bin < 4; bin++ {
+		ch := chunktest.GenerateTestRandomChunkAt(t, baseAddr, int(bin)).WithStamp(postagetesting.MustNewBatchStamp(batchA.ID))
+		assert.NoError(t, r.Put(context.Background(), ch))
+		want += len(ch.Data())
+
+		// the same chunk held under another stamp is counted once
+		same := swarm.NewChunk(ch.Address(), ch.Data()).WithStamp(postagetesting.MustNewBatchStamp(batchB.ID))
+		assert.NoError(t, r.Put(context.Background(), same))
+	}
+
+	// simulate the reserve stored without the byte size.
+	err = store.Run(context.Background(), func(s transaction.Store) error {
+		for bin := uint8(0); bin < swarm.MaxBins; bin++ {
+			if err := s.IndexStore().Delete(&reserve.BytesItem{Bin: bin}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	assert.NoError(t, err)
+
+	r, err = reserve.New(baseAddr, store, 0, kademlia.NewTopologyDriver(), log.Noop)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, r.Bytes())
+
+	assert.NoError(t, localmigration.Step_09(store, log.Noop)())
+
+	r, err = reserve.New(baseAddr, store, 0, kademlia.NewTopologyDriver(), log.Noop)
+	assert.NoError(t, err)
+	assert.Equal(t, want, r.Bytes())
+}
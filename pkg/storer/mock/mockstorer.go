@@ -23,16 +23,19 @@ var now = time.Now
 type mockStorer struct {
 	chunkStore     storage.ChunkStore
 	mu             sync.Mutex
-	pins           []swarm.Address
+	pins           []storer.PinCollection
+	pending        map[string]*storer.PinCollection
 	sessionID      atomic.Uint64
 	activeSessions map[uint64]*storer.SessionInfo
 	chunkPushC     chan *pusher.Op
 	debugInfo      storer.Info
+	pinQuota       uint64
 }
 
 type putterSession struct {
 	chunkStore storage.Putter
 	done       func(swarm.Address) error
+	cleanup    func() error
 }
 
 func (p *putterSession) Put(ctx context.Context, ch swarm.Chunk) error {
@@ -46,7 +49,12 @@ func (p *putterSession) Done(address swarm.Address) error {
 	return nil
 }
 
-func (p *putterSession) Cleanup() error { return nil }
+func (p *putterSession) Cleanup() error {
+	if p.cleanup != nil {
+		return p.cleanup()
+	}
+	return nil
+}
 
 // New returns a mock storer implementation that is designed to be used for the
 // unit tests.
@@ -55,6 +63,7 @@ func New() *mockStorer {
 		chunkStore:     inmemchunkstore.New(),
 		chunkPushC:     make(chan *pusher.Op),
 		activeSessions: make(map[uint64]*storer.SessionInfo),
+		pending:        make(map[string]*storer.PinCollection),
 	}
 }
 
@@ -63,6 +72,7 @@ func NewWithChunkStore(cs storage.ChunkStore) *mockStorer {
 		chunkStore:     cs,
 		chunkPushC:     make(chan *pusher.Op),
 		activeSessions: make(map[uint64]*storer.SessionInfo),
+		pending:        make(map[string]*storer.PinCollection),
 	}
 }
 
@@ -72,6 +82,14 @@ func NewWithDebugInfo(info storer.Info) *mockStorer {
 	return st
 }
 
+// NewWithPinQuota returns a mock storer which fails the pinning of the content
+// above the quota in bytes.
+func NewWithPinQuota(quota uint64) *mockStorer {
+	st := New()
+	st.pinQuota = quota
+	return st
+}
+
 func (m *mockStorer) Upload(_ context.Context, pin bool, tagID uint64) (storer.PutterSession, error) {
 	return &putterSession{
 		chunkStore: m.chunkStore,
@@ -80,7 +98,7 @@ func (m *mockStorer) Upload(_ context.Context, pin bool, tagID uint64) (storer.P
 			defer m.mu.Unlock()
 
 			if pin {
-				m.pins = append(m.pins, address)
+				m.pins = append(m.pins, storer.PinCollection{Reference: address, CreatedAt: now()})
 			}
 			if session, ok := m.activeSessions[tagID]; ok {
 				session.Address = address
@@ -141,7 +159,7 @@ func (m *mockStorer) DeletePin(_ context.Context, address swarm.Address) error {
 	defer m.mu.Unlock()
 
 	for idx, p := range m.pins {
-		if p.Equal(address) {
+		if p.Reference.Equal(address) {
 			m.pins = append(m.pins[:idx], m.pins[idx+1:]...)
 			break
 		}
@@ -155,35 +173,133 @@ func (m *mockStorer) Pins() ([]swarm.Address, error) {
 
 	pins := make([]swarm.Address, 0, len(m.pins))
 	for _, p := range m.pins {
-		pins = append(pins, p.Clone())
+		pins = append(pins, p.Reference.Clone())
 	}
 	return pins, nil
 }
 
+func (m *mockStorer) PinCollections() ([]storer.PinCollection, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return append([]storer.PinCollection(nil), m.pins...), nil
+}
+
+func (m *mockStorer) PinCollection(address swarm.Address) (storer.PinCollection, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, p := range m.pins {
+		if p.Reference.Equal(address) {
+			return p, nil
+		}
+	}
+	return storer.PinCollection{}, storage.ErrNotFound
+}
+
+// pinnedSize returns the byte size of the pinned content.
+func (m *mockStorer) pinnedSize() uint64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var size uint64
+	for _, p := range m.pins {
+		size += p.Size
+	}
+	return size
+}
+
 func (m *mockStorer) HasPin(address swarm.Address) (bool, error) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 	for _, p := range m.pins {
-		if p.Equal(address) {
+		if p.Reference.Equal(address) {
 			return true, nil
 		}
 	}
 	return false, nil
 }
 
-func (m *mockStorer) NewCollection(ctx context.Context) (storer.PutterSession, error) {
+func (m *mockStorer) NewCollection(ctx context.Context, label string, ttl time.Duration) (storer.PutterSession, error) {
+	var chunks, size atomic.Uint64
 	return &putterSession{
-		chunkStore: m.chunkStore,
+		chunkStore: storage.PutterFunc(func(ctx context.Context, ch swarm.Chunk) error {
+			if m.pinQuota > 0 && m.pinnedSize()+size.Load()+uint64(len(ch.Data())) > m.pinQuota {
+				return storer.ErrPinQuotaExceeded
+			}
+			chunks.Inc()
+			size.Add(uint64(len(ch.Data())))
+			return m.chunkStore.Put(ctx, ch)
+		}),
 		done: func(address swarm.Address) error {
 			m.mu.Lock()
 			defer m.mu.Unlock()
 
-			m.pins = append(m.pins, address)
+			p := storer.PinCollection{
+				Reference: address,
+				Label:     label,
+				CreatedAt: now(),
+				Chunks:    chunks.Load(),
+				Size:      size.Load(),
+			}
+			if ttl > 0 {
+				p.ExpiresAt = p.CreatedAt.Add(ttl)
+			}
+			m.pins = append(m.pins, p)
+			return nil
+		},
+	}, nil
+}
+
+func (m *mockStorer) PendingCollection(ctx context.Context, root swarm.Address, label string, ttl time.Duration) (storer.PutterSession, error) {
+	m.mu.Lock()
+	p, ok := m.pending[root.ByteString()]
+	if !ok {
+		p = &storer.PinCollection{Reference: root, Label: label, CreatedAt: now()}
+		if ttl > 0 {
+			p.ExpiresAt = p.CreatedAt.Add(ttl)
+		}
+		m.pending[root.ByteString()] = p
+	}
+	m.mu.Unlock()
+
+	return &putterSession{
+		chunkStore: storage.PutterFunc(func(ctx context.Context, ch swarm.Chunk) error {
+			m.mu.Lock()
+			p.Chunks++
+			p.Size += uint64(len(ch.Data()))
+			m.mu.Unlock()
+			return m.chunkStore.Put(ctx, ch)
+		}),
+		done: func(address swarm.Address) error {
+			m.mu.Lock()
+			defer m.mu.Unlock()
+
+			delete(m.pending, root.ByteString())
+			m.pins = append(m.pins, *p)
+			return nil
+		},
+		cleanup: func() error {
+			m.mu.Lock()
+			defer m.mu.Unlock()
+
+			delete(m.pending, root.ByteString())
 			return nil
 		},
 	}, nil
 }
 
+func (m *mockStorer) PendingPins() ([]storer.PinCollection, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	pending := make([]storer.PinCollection, 0, len(m.pending))
+	for _, p := range m.pending {
+		pending = append(pending, *p)
+	}
+	return pending, nil
+}
+
 func (m *mockStorer) Lookup() storage.Getter {
 	return m.chunkStore
 }
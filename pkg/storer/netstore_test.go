@@ -339,7 +339,7 @@ func TestNetStore(t *testing.T) {
 		testNetStore(t, func(r retrieval.Interface) (*storer.DB, error) {
 
 			opts := dbTestOps(swarm.RandAddress(t), 0, nil, nil, time.Second)
-			opts.CacheCapacity = 100
+			opts.CacheCapacity = 100 * swarm.ChunkWithSpanSize
 
 			db, err := storer.New(context.Background(), "", opts)
 			if err == nil {
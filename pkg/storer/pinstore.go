@@ -6,24 +6,101 @@ package storer
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sync"
 	"time"
 
 	storage "github.com/ethersphere/bee/v2/pkg/storage"
-	"github.com/ethersphere/bee/v2/pkg/storer/internal"
 	pinstore "github.com/ethersphere/bee/v2/pkg/storer/internal/pinning"
 	"github.com/ethersphere/bee/v2/pkg/storer/internal/transaction"
 	"github.com/ethersphere/bee/v2/pkg/swarm"
 )
 
+// ErrPinQuotaExceeded is returned when the pinned content would exceed the
+// pin quota of the node.
+var ErrPinQuotaExceeded = errors.New("pin quota exceeded")
+
+// pinQuota accounts the byte size of the pinned content against the quota.
+type pinQuota struct {
+	mu    sync.Mutex
+	quota uint64 // zero is unlimited
+	size  uint64
+}
+
+// reserve adds n bytes to the pinned size unless it would exceed the quota.
+func (q *pinQuota) reserve(n uint64) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.quota > 0 && q.size+n > q.quota {
+		return ErrPinQuotaExceeded
+	}
+	q.size += n
+	return nil
+}
+
+// release subtracts n bytes from the pinned size.
+func (q *pinQuota) release(n uint64) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.size -= min(n, q.size)
+}
+
+// pinReservation accounts the chunks added by a pinning putter to the quota.
+// Calls MUST be locked with the uploadsLock like the pinning putter.
+type pinReservation struct {
+	quota    *pinQuota
+	putter   pinstore.CollectionPutter
+	reserved uint64
+	pending  string // the root of a pending collection
+}
+
+// put reserves the size of the chunk before it is put with the putFn, and
+// releases it unless the chunk was added to the collection, as the duplicates
+// within the collection are not stored again.
+func (r *pinReservation) put(ch swarm.Chunk, putFn func() error) error {
+	n := uint64(len(ch.Data()))
+	if err := r.quota.reserve(n); err != nil {
+		return err
+	}
+	size := r.putter.Size()
+	if err := putFn(); err != nil {
+		r.quota.release(n)
+		return err
+	}
+	added := r.putter.Size() - size
+	r.quota.release(n - added)
+	r.reserved += added
+	return nil
+}
+
+// commit keeps the chunks added by the putter reserved once the collection is
+// closed, the pinned size is released when the pin is deleted.
+func (r *pinReservation) commit() {
+	r.reserved = 0
+}
+
+// cancel releases the chunks added by the putter.
+func (r *pinReservation) cancel() {
+	r.quota.release(r.reserved)
+	r.reserved = 0
+}
+
 // NewCollection is the implementation of the PinStore.NewCollection method.
-func (db *DB) NewCollection(ctx context.Context) (PutterSession, error) {
+func (db *DB) NewCollection(ctx context.Context, label string, ttl time.Duration) (PutterSession, error) {
+	var expiresAt time.Time
+	if ttl > 0 {
+		expiresAt = time.Now().Add(ttl)
+	}
+
 	var (
-		pinningPutter internal.PutterCloserWithReference
+		pinningPutter pinstore.CollectionPutter
 		err           error
 	)
 	err = db.storage.Run(ctx, func(store transaction.Store) error {
-		pinningPutter, err = pinstore.NewCollection(store.IndexStore())
+		pinningPutter, err = pinstore.NewCollection(store.IndexStore(), label, expiresAt)
 		if err != nil {
 			return fmt.Errorf("pinstore.NewCollection: %w", err)
 		}
@@ -33,14 +110,81 @@ func (db *DB) NewCollection(ctx context.Context) (PutterSession, error) {
 		return nil, err
 	}
 
+	return db.pinSession(ctx, pinningPutter, &pinReservation{quota: &db.pinQuota, putter: pinningPutter}), nil
+}
+
+// PendingCollection is the implementation of the PinStore.PendingCollection method.
+func (db *DB) PendingCollection(ctx context.Context, root swarm.Address, label string, ttl time.Duration) (PutterSession, error) {
+	var expiresAt time.Time
+	if ttl > 0 {
+		expiresAt = time.Now().Add(ttl)
+	}
+
+	unlock := db.Lock(uploadsLock)
+	defer unlock()
+
+	var (
+		pinningPutter pinstore.CollectionPutter
+		err           error
+	)
+	err = db.storage.Run(ctx, func(store transaction.Store) error {
+		pinningPutter, err = pinstore.PendingCollection(store.IndexStore(), root, label, expiresAt)
+		if err != nil {
+			return fmt.Errorf("pinstore.PendingCollection: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// The session of the pending collection replaces the one it is resumed
+	// from, whose chunks are counted again by the new reservation.
+	key := root.ByteString()
+	if r, ok := db.pendingReservations[key]; ok {
+		r.cancel()
+	}
+	reservation := &pinReservation{quota: &db.pinQuota, putter: pinningPutter, pending: key}
+	db.pendingReservations[key] = reservation
+
+	return db.pinSession(ctx, pinningPutter, reservation), nil
+}
+
+// PendingPins is the implementation of the PinStore.PendingPins method.
+func (db *DB) PendingPins() (collections []PinCollection, err error) {
+	dur := captureDuration(time.Now())
+	defer func() {
+		db.metrics.MethodCallsDuration.WithLabelValues("pinstore", "PendingPins").Observe(dur())
+		if err == nil {
+			db.metrics.MethodCalls.WithLabelValues("pinstore", "PendingPins", "success").Inc()
+		} else {
+			db.metrics.MethodCalls.WithLabelValues("pinstore", "PendingPins", "failure").Inc()
+		}
+	}()
+
+	cs, err := pinstore.PendingCollections(db.storage.IndexStore())
+	if err != nil {
+		return nil, err
+	}
+	collections = make([]PinCollection, 0, len(cs))
+	for _, c := range cs {
+		collections = append(collections, pinCollection(c))
+	}
+	return collections, nil
+}
+
+// pinSession returns the PutterSession of the pinning putter.
+func (db *DB) pinSession(ctx context.Context, pinningPutter pinstore.CollectionPutter, reservation *pinReservation) PutterSession {
 	return &putterSession{
 		Putter: putterWithMetrics{
 			storage.PutterFunc(
 				func(ctx context.Context, chunk swarm.Chunk) error {
 					unlock := db.Lock(uploadsLock)
 					defer unlock()
-					return db.storage.Run(ctx, func(s transaction.Store) error {
-						return pinningPutter.Put(ctx, s, chunk)
+					return reservation.put(chunk, func() error {
+						return db.storage.Run(ctx, func(s transaction.Store) error {
+							return pinningPutter.Put(ctx, s, chunk)
+						})
 					})
 				},
 			),
@@ -50,16 +194,32 @@ func (db *DB) NewCollection(ctx context.Context) (PutterSession, error) {
 		done: func(address swarm.Address) error {
 			unlock := db.Lock(uploadsLock)
 			defer unlock()
-			return db.storage.Run(ctx, func(s transaction.Store) error {
+			err := db.storage.Run(ctx, func(s transaction.Store) error {
 				return pinningPutter.Close(s.IndexStore(), address)
 			})
+			if err != nil {
+				return err
+			}
+			reservation.commit()
+			db.forgetPendingReservation(reservation)
+			return nil
 		},
 		cleanup: func() error {
 			unlock := db.Lock(uploadsLock)
 			defer unlock()
+			defer db.forgetPendingReservation(reservation)
+			defer reservation.cancel()
 			return pinningPutter.Cleanup(db.storage)
 		},
-	}, nil
+	}
+}
+
+// forgetPendingReservation removes the reservation of the closed session of a
+// pending collection. It MUST be called with the uploadsLock held.
+func (db *DB) forgetPendingReservation(r *pinReservation) {
+	if r.pending != "" && db.pendingReservations[r.pending] == r {
+		delete(db.pendingReservations, r.pending)
+	}
 }
 
 // DeletePin is the implementation of the PinStore.DeletePin method.
@@ -77,7 +237,60 @@ func (db *DB) DeletePin(ctx context.Context, root swarm.Address) (err error) {
 	unlock := db.Lock(uploadsLock)
 	defer unlock()
 
-	return pinstore.DeletePin(ctx, db.storage, root)
+	c, err := pinstore.GetCollection(db.storage.IndexStore(), root)
+	if err != nil {
+		return err
+	}
+	if err := pinstore.DeletePin(ctx, db.storage, root); err != nil {
+		return err
+	}
+	db.pinQuota.release(c.Stat.Size)
+	return nil
+}
+
+// pinPruneWorker periodically removes the expired pin collections.
+func (db *DB) pinPruneWorker(ctx context.Context) {
+	defer db.inFlight.Done()
+
+	ticker := time.NewTicker(db.pinPruneWakeUpTime)
+	defer ticker.Stop()
+
+	for {
+		if err := db.pruneExpiredPins(ctx); err != nil {
+			db.logger.Error(err, "prune expired pins")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-db.quit:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// pruneExpiredPins removes the pin collections which expired by now.
+func (db *DB) pruneExpiredPins(ctx context.Context) error {
+	collections, err := pinstore.Collections(db.storage.IndexStore())
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for _, c := range collections {
+		if c.ExpiresAt.IsZero() || c.ExpiresAt.After(now) {
+			continue
+		}
+		err := db.DeletePin(ctx, c.Addr)
+		if err != nil && !errors.Is(err, storage.ErrNotFound) {
+			return fmt.Errorf("delete expired pin %s: %w", c.Addr, err)
+		}
+		if err == nil {
+			db.metrics.ExpiredPinCount.Inc()
+		}
+	}
+	return nil
 }
 
 // Pins is the implementation of the PinStore.Pins method.
@@ -95,6 +308,59 @@ func (db *DB) Pins() (address []swarm.Address, err error) {
 	return pinstore.Pins(db.storage.IndexStore())
 }
 
+// PinCollections is the implementation of the PinStore.PinCollections method.
+func (db *DB) PinCollections() (collections []PinCollection, err error) {
+	dur := captureDuration(time.Now())
+	defer func() {
+		db.metrics.MethodCallsDuration.WithLabelValues("pinstore", "PinCollections").Observe(dur())
+		if err == nil {
+			db.metrics.MethodCalls.WithLabelValues("pinstore", "PinCollections", "success").Inc()
+		} else {
+			db.metrics.MethodCalls.WithLabelValues("pinstore", "PinCollections", "failure").Inc()
+		}
+	}()
+
+	cs, err := pinstore.Collections(db.storage.IndexStore())
+	if err != nil {
+		return nil, err
+	}
+	collections = make([]PinCollection, 0, len(cs))
+	for _, c := range cs {
+		collections = append(collections, pinCollection(c))
+	}
+	return collections, nil
+}
+
+// PinCollection is the implementation of the PinStore.PinCollection method.
+func (db *DB) PinCollection(root swarm.Address) (collection PinCollection, err error) {
+	dur := captureDuration(time.Now())
+	defer func() {
+		db.metrics.MethodCallsDuration.WithLabelValues("pinstore", "PinCollection").Observe(dur())
+		if err == nil {
+			db.metrics.MethodCalls.WithLabelValues("pinstore", "PinCollection", "success").Inc()
+		} else {
+			db.metrics.MethodCalls.WithLabelValues("pinstore", "PinCollection", "failure").Inc()
+		}
+	}()
+
+	c, err := pinstore.GetCollection(db.storage.IndexStore(), root)
+	if err != nil {
+		return PinCollection{}, err
+	}
+	return pinCollection(c), nil
+}
+
+func pinCollection(c pinstore.Collection) PinCollection {
+	return PinCollection{
+		Reference: c.Addr,
+		Label:     c.Label,
+		CreatedAt: c.CreatedAt,
+		ExpiresAt: c.ExpiresAt,
+		Chunks:    c.Stat.Total - c.Stat.DupInCollection,
+		Size:      c.Stat.Size,
+	}
+}
+
 // HasPin is the implementation of the PinStore.HasPin method.
 func (db *DB) HasPin(root swarm.Address) (has bool, err error) {
 	dur := captureDuration(time.Now())
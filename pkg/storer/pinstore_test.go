@@ -6,10 +6,12 @@ package storer_test
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"testing"
 	"time"
 
+	"github.com/ethersphere/bee/v2/pkg/spinlock"
 	chunktesting "github.com/ethersphere/bee/v2/pkg/storage/testing"
 	storer "github.com/ethersphere/bee/v2/pkg/storer"
 	"github.com/ethersphere/bee/v2/pkg/swarm"
@@ -45,7 +47,7 @@ func testPinStore(t *testing.T, newStorer func() (*storer.DB, error)) {
 			testName += "_rollback"
 		}
 		t.Run(testName, func(t *testing.T) {
-			session, err := lstore.NewCollection(context.TODO())
+			session, err := lstore.NewCollection(context.TODO(), testName, 0)
 			if err != nil {
 				t.Fatalf("NewCollection(...): unexpected error: %v", err)
 			}
@@ -97,6 +99,29 @@ func testPinStore(t *testing.T, newStorer func() (*storer.DB, error)) {
 		}
 	})
 
+	t.Run("pin collections", func(t *testing.T) {
+		collections, err := lstore.PinCollections()
+		if err != nil {
+			t.Fatalf("PinCollections(): unexpected error: %v", err)
+		}
+		if len(collections) != 2 {
+			t.Fatalf("unexpected no of pin collections: want %d have %d", 2, len(collections))
+		}
+
+		tc := testCases[2]
+		c, err := lstore.PinCollection(tc.chunks[0].Address())
+		if err != nil {
+			t.Fatalf("PinCollection(...): unexpected error: %v", err)
+		}
+		size := 0
+		for _, ch := range tc.chunks {
+			size += len(ch.Data())
+		}
+		if c.Label != fmt.Sprintf("pin_%d_chunks", len(tc.chunks)) || c.CreatedAt.IsZero() || c.Chunks != uint64(len(tc.chunks)) || c.Size != uint64(size) {
+			t.Fatalf("unexpected pin collection %+v", c)
+		}
+	})
+
 	t.Run("delete pin", func(t *testing.T) {
 		t.Run("commit", func(t *testing.T) {
 			err := lstore.DeletePin(context.TODO(), testCases[2].chunks[0].Address())
@@ -111,12 +136,12 @@ func testPinStore(t *testing.T, newStorer func() (*storer.DB, error)) {
 	t.Run("duplicate parallel upload does not leave orphaned chunks", func(t *testing.T) {
 		chunks := chunktesting.GenerateTestRandomChunks(4)
 
-		session1, err := lstore.NewCollection(context.TODO())
+		session1, err := lstore.NewCollection(context.TODO(), "", 0)
 		if err != nil {
 			t.Fatalf("NewCollection(...): unexpected error: %v", err)
 		}
 
-		session2, err := lstore.NewCollection(context.TODO())
+		session2, err := lstore.NewCollection(context.TODO(), "", 0)
 		if err != nil {
 			t.Fatalf("NewCollection2(...): unexpected error: %v", err)
 		}
@@ -170,3 +195,203 @@ func TestPinStore(t *testing.T) {
 		testPinStore(t, diskStorer(t, dbTestOps(swarm.RandAddress(t), 0, nil, nil, time.Second)))
 	})
 }
+
+func TestPinQuota(t *testing.T) {
+	t.Parallel()
+
+	chunks := chunktesting.GenerateTestRandomChunks(25)
+	chunkSize := uint64(len(chunks[0].Data()))
+
+	opts := dbTestOps(swarm.RandAddress(t), 0, nil, nil, time.Second)
+	opts.PinQuota = 15 * chunkSize
+	lstore := makeInmemStorer(t, opts)
+
+	pin := func(t *testing.T, chunks []swarm.Chunk) error {
+		t.Helper()
+
+		session, err := lstore.NewCollection(context.TODO(), "", 0)
+		if err != nil {
+			t.Fatalf("NewCollection(...): unexpected error: %v", err)
+		}
+		for _, ch := range chunks {
+			if err := session.Put(context.TODO(), ch); err != nil {
+				if err := session.Cleanup(); err != nil {
+					t.Fatalf("session.Cleanup(): unexpected error: %v", err)
+				}
+				return err
+			}
+		}
+		return session.Done(chunks[0].Address())
+	}
+
+	if err := pin(t, chunks[:10]); err != nil {
+		t.Fatalf("pin: unexpected error: %v", err)
+	}
+
+	t.Run("exceeded", func(t *testing.T) {
+		err := pin(t, chunks[10:])
+		if !errors.Is(err, storer.ErrPinQuotaExceeded) {
+			t.Fatalf("pin: want error %v, got %v", storer.ErrPinQuotaExceeded, err)
+		}
+		verifyPinCollection(t, lstore.Storage(), chunks[10], chunks[10:15], false)
+	})
+
+	t.Run("duplicates within quota", func(t *testing.T) {
+		dups := append(append([]swarm.Chunk{}, chunks[10:14]...), chunks[10:14]...)
+		if err := pin(t, dups); err != nil {
+			t.Fatalf("pin: unexpected error: %v", err)
+		}
+		if err := lstore.DeletePin(context.TODO(), chunks[10].Address()); err != nil {
+			t.Fatalf("DeletePin(...): unexpected error: %v", err)
+		}
+	})
+
+	t.Run("released by delete", func(t *testing.T) {
+		if err := lstore.DeletePin(context.TODO(), chunks[0].Address()); err != nil {
+			t.Fatalf("DeletePin(...): unexpected error: %v", err)
+		}
+		if err := pin(t, chunks[10:]); err != nil {
+			t.Fatalf("pin: unexpected error: %v", err)
+		}
+		verifyPinCollection(t, lstore.Storage(), chunks[10], chunks[10:], true)
+	})
+
+	t.Run("pinned upload", func(t *testing.T) {
+		tag, err := lstore.NewSession()
+		if err != nil {
+			t.Fatalf("NewSession(): unexpected error: %v", err)
+		}
+		session, err := lstore.Upload(context.TODO(), true, tag.TagID)
+		if err != nil {
+			t.Fatalf("Upload(...): unexpected error: %v", err)
+		}
+		err = session.Put(context.TODO(), chunks[0])
+		if !errors.Is(err, storer.ErrPinQuotaExceeded) {
+			t.Fatalf("session.Put(...): want error %v, got %v", storer.ErrPinQuotaExceeded, err)
+		}
+		if err := session.Cleanup(); err != nil {
+			t.Fatalf("session.Cleanup(): unexpected error: %v", err)
+		}
+		verifyChunks(t, lstore.Storage(), chunks[:1], false)
+	})
+}
+
+func TestPinExpiry(t *testing.T) {
+	t.Parallel()
+
+	opts := dbTestOps(swarm.RandAddress(t), 0, nil, nil, time.Second)
+	opts.PinPruneWakeUpDuration = 10 * time.Millisecond
+	lstore := makeInmemStorer(t, opts)
+
+	pin := func(t *testing.T, chunks []swarm.Chunk, ttl time.Duration) {
+		t.Helper()
+
+		session, err := lstore.NewCollection(context.TODO(), "", ttl)
+		if err != nil {
+			t.Fatalf("NewCollection(...): unexpected error: %v", err)
+		}
+		for _, ch := range chunks {
+			if err := session.Put(context.TODO(), ch); err != nil {
+				t.Fatalf("session.Put(...): unexpected error: %v", err)
+			}
+		}
+		if err := session.Done(chunks[0].Address()); err != nil {
+			t.Fatalf("session.Done(...): unexpected error: %v", err)
+		}
+	}
+
+	expiring := chunktesting.GenerateTestRandomChunks(5)
+	permanent := chunktesting.GenerateTestRandomChunks(5)
+	pin(t, expiring, 100*time.Millisecond)
+	pin(t, permanent, 0)
+
+	c, err := lstore.PinCollection(expiring[0].Address())
+	if err != nil {
+		t.Fatalf("PinCollection(...): unexpected error: %v", err)
+	}
+	if c.ExpiresAt.IsZero() {
+		t.Fatal("expiry of the collection not set")
+	}
+
+	err = spinlock.Wait(5*time.Second, func() bool {
+		has, err := lstore.HasPin(expiring[0].Address())
+		return err == nil && !has
+	})
+	if err != nil {
+		t.Fatal("expired pin collection not removed")
+	}
+
+	verifyPinCollection(t, lstore.Storage(), expiring[0], expiring, false)
+	verifyPinCollection(t, lstore.Storage(), permanent[0], permanent, true)
+}
+
+func TestPendingPin(t *testing.T) {
+	t.Parallel()
+
+	var (
+		dir    = t.TempDir()
+		opts   = dbTestOps(swarm.RandAddress(t), 0, nil, nil, time.Second)
+		chunks = chunktesting.GenerateTestRandomChunks(10)
+		root   = chunks[0].Address()
+	)
+
+	put := func(t *testing.T, lstore *storer.DB, chunks []swarm.Chunk) storer.PutterSession {
+		t.Helper()
+
+		session, err := lstore.PendingCollection(context.TODO(), root, "label", 0)
+		if err != nil {
+			t.Fatalf("PendingCollection(...): unexpected error: %v", err)
+		}
+		for _, ch := range chunks {
+			if err := session.Put(context.TODO(), ch); err != nil {
+				t.Fatalf("session.Put(...): unexpected error: %v", err)
+			}
+		}
+		return session
+	}
+
+	lstore, err := storer.New(context.Background(), dir, opts)
+	if err != nil {
+		t.Fatalf("New(...): unexpected error: %v", err)
+	}
+	put(t, lstore, chunks[:5])
+	if err := lstore.Close(); err != nil {
+		t.Fatalf("Close(): unexpected error: %v", err)
+	}
+
+	lstore, err = newStorer(t, dir, opts)
+	if err != nil {
+		t.Fatalf("New(...): unexpected error: %v", err)
+	}
+	verifyChunks(t, lstore.Storage(), chunks[:5], true)
+
+	pending, err := lstore.PendingPins()
+	if err != nil {
+		t.Fatalf("PendingPins(): unexpected error: %v", err)
+	}
+	if len(pending) != 1 || !pending[0].Reference.Equal(root) || pending[0].Label != "label" || pending[0].Chunks != 5 {
+		t.Fatalf("unexpected pending pins %+v", pending)
+	}
+
+	session := put(t, lstore, chunks)
+	if err := session.Done(root); err != nil {
+		t.Fatalf("session.Done(...): unexpected error: %v", err)
+	}
+	verifyPinCollection(t, lstore.Storage(), chunks[0], chunks, true)
+
+	c, err := lstore.PinCollection(root)
+	if err != nil {
+		t.Fatalf("PinCollection(...): unexpected error: %v", err)
+	}
+	if c.Chunks != 10 || c.Label != "label" {
+		t.Fatalf("unexpected pin collection %+v", c)
+	}
+
+	pending, err = lstore.PendingPins()
+	if err != nil {
+		t.Fatalf("PendingPins(): unexpected error: %v", err)
+	}
+	if len(pending) != 0 {
+		t.Fatalf("unexpected pending pins %+v", pending)
+	}
+}
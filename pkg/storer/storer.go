@@ -85,18 +85,49 @@ type UploadStore interface {
 type PinStore interface {
 	// NewCollection can be used to create a new PutterSession which writes a new
 	// pinning collection. The address passed in during the Done of the session is
-	// used as the root referencce.
-	NewCollection(context.Context) (PutterSession, error)
+	// used as the root referencce. The label is stored with the collection, and
+	// the collection is removed after the ttl unless it is zero. The Put of the
+	// session fails with ErrPinQuotaExceeded if the pinned content would exceed
+	// the pin quota, releasing the chunks already written.
+	NewCollection(ctx context.Context, label string, ttl time.Duration) (PutterSession, error)
+
+	// PendingCollection returns a PutterSession which writes the pinning
+	// collection of the root like NewCollection, but the chunks put are kept
+	// across restarts until the session is done or cleaned up. If the pending
+	// collection of the root exists, it is resumed with its label and expiry.
+	PendingCollection(ctx context.Context, root swarm.Address, label string, ttl time.Duration) (PutterSession, error)
+
+	// PendingPins returns the pending collections, their Chunks is the number
+	// of the chunks collected so far.
+	PendingPins() ([]PinCollection, error)
 	// DeletePin deletes all the chunks associated with the collection pointed to
 	// by the swarm.Address passed in.
 	DeletePin(context.Context, swarm.Address) error
 	// Pins returns all the root references of pinning collections.
 	Pins() ([]swarm.Address, error)
+	// PinCollections returns all the pinning collections with their metadata.
+	PinCollections() ([]PinCollection, error)
+	// PinCollection returns the metadata of the pinning collection with the
+	// root reference passed in.
+	PinCollection(swarm.Address) (PinCollection, error)
 	// HasPin is a helper which checks if a collection exists with the root
 	// reference passed in.
 	HasPin(swarm.Address) (bool, error)
 }
 
+// PinCollection describes a pinning collection.
+type PinCollection struct {
+	Reference swarm.Address
+	Label     string
+	CreatedAt time.Time
+	// ExpiresAt is the time the collection is removed, zero if never.
+	ExpiresAt time.Time
+	// Chunks is the number of the chunks stored by the collection.
+	Chunks uint64
+	// Size is the byte size of the chunks stored by the collection.
+	Size uint64
+}
+
 // PinIterator is a helper interface which can be used to iterate over all the
 // chunks in a pinning collection.
 type PinIterator interface {
@@ -241,8 +272,9 @@ const (
 	defaultBlockCacheCapacity     = uint64(32 * 1024 * 1024)
 	defaultWriteBufferSize        = uint64(32 * 1024 * 1024)
 	defaultDisableSeeksCompaction = false
-	defaultCacheCapacity          = uint64(1_000_000)
+	DefaultCacheCapacity          = uint64(1_000_000 * swarm.ChunkWithSpanSize) // bytes of 1000000 full chunks
 	defaultBgCacheWorkers         = 16
+	defaultPinPruneWakeUpDuration = time.Minute
 	DefaultReserveCapacity        = 1 << 22 // 4194304 chunks
 
 	indexPath  = "indexstore"
@@ -388,8 +420,19 @@ type Options struct {
 	ReserveMinEvictCount    uint64
 	ReserveCapacityDoubling int
 
-	CacheCapacity      uint64
+	// CacheCapacity is the byte size of the chunks kept in the cache.
+	CacheCapacity uint64
+	// CacheMinEvictCount is the minimum number of the full chunks worth of
+	// bytes evicted from the cache at once.
 	CacheMinEvictCount uint64
+	// CacheEvictionPolicy is the name of the policy deciding which of the
+	// cached chunks are evicted first: lru, lfu or 2q. The default is lru.
+	CacheEvictionPolicy string
+
+	// PinQuota is the maximum byte size of the pinned content, zero is unlimited.
+	PinQuota uint64
+	// PinPruneWakeUpDuration is the interval of the removal of the expired pins.
+	PinPruneWakeUpDuration time.Duration
 
 	MinimumStorageRadius uint
 }
@@ -400,10 +443,11 @@ func defaultOptions() *Options {
 		LdbBlockCacheCapacity:     defaultBlockCacheCapacity,
 		LdbWriteBufferSize:        defaultWriteBufferSize,
 		LdbDisableSeeksCompaction: defaultDisableSeeksCompaction,
-		CacheCapacity:             defaultCacheCapacity,
+		CacheCapacity:             DefaultCacheCapacity,
 		Logger:                    log.Noop,
 		ReserveCapacity:           DefaultReserveCapacity,
 		ReserveWakeUpDuration:     time.Minute * 30,
+		PinPruneWakeUpDuration:    defaultPinPruneWakeUpDuration,
 	}
 }
 
@@ -433,6 +477,7 @@ type DB struct {
 	subscriptionsWG     sync.WaitGroup
 	events              *events.Subscriber
 	directUploadLimiter chan struct{}
+	uploadSize          *upload.PendingSize
 
 	reserve          *reserve.Reserve
 	inFlight         sync.WaitGroup
@@ -445,6 +490,11 @@ type DB struct {
 	reserveOptions   reserveOpts
 
 	pinIntegrity *PinIntegrity
+	pinQuota     pinQuota
+	// pendingReservations are the reservations of the sessions of the pending
+	// collections by their root, guarded by the uploadsLock.
+	pendingReservations map[string]*pinReservation
+	pinPruneWakeUpTime  time.Duration
 }
 
 type reserveOpts struct {
@@ -511,7 +561,15 @@ func New(ctx context.Context, dirPath string, opts *Options) (*DB, error) {
 		return nil, fmt.Errorf("failed regular migration: %w", err)
 	}
 
-	cacheObj, err := cache.New(ctx, st.IndexStore(), opts.CacheCapacity)
+	cachePolicy := cache.LRU
+	if opts.CacheEvictionPolicy != "" {
+		cachePolicy, err = cache.ParsePolicy(opts.CacheEvictionPolicy)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	cacheObj, err := cache.New(ctx, st, opts.CacheCapacity, cachePolicy)
 	if err != nil {
 		return nil, err
 	}
@@ -550,6 +608,12 @@ func New(ctx context.Context, dirPath string, opts *Options) (*DB, error) {
 		},
 		directUploadLimiter: make(chan struct{}, pusher.ConcurrentPushes),
 		pinIntegrity:        pinIntegrity,
+		pinQuota:            pinQuota{quota: opts.PinQuota},
+		pendingReservations: make(map[string]*pinReservation),
+		pinPruneWakeUpTime:  opts.PinPruneWakeUpDuration,
+	}
+	if db.pinPruneWakeUpTime <= 0 {
+		db.pinPruneWakeUpTime = defaultPinPruneWakeUpDuration
 	}
 
 	if db.validStamp == nil {
@@ -574,18 +638,32 @@ func New(ctx context.Context, dirPath string, opts *Options) (*DB, error) {
 	}
 	db.metrics.CacheSize.Set(float64(db.cacheObj.Size()))
 
+	db.uploadSize, err = upload.NewPendingSize(db.storage.IndexStore())
+	if err != nil {
+		return nil, err
+	}
+
 	// Cleanup any dirty state in upload and pinning stores, this could happen
 	// in case of dirty shutdowns
 	err = errors.Join(
-		upload.CleanupDirty(db.storage),
+		upload.CleanupDirty(db.storage, db.uploadSize),
 		pinstore.CleanupDirty(db.storage),
 	)
 	if err != nil {
 		return nil, err
 	}
 
-	db.inFlight.Add(1)
+	err = pinstore.IterateCollectionStats(db.storage.IndexStore(), func(stat pinstore.CollectionStat) (bool, error) {
+		db.pinQuota.size += stat.Size
+		return false, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	db.inFlight.Add(2)
 	go db.cacheWorker(ctx)
+	go db.pinPruneWorker(ctx)
 
 	return db, nil
 }
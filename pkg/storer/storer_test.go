@@ -150,7 +150,7 @@ func TestNew(t *testing.T) {
 		t.Parallel()
 
 		opts := dbTestOps(swarm.RandAddress(t), 0, nil, nil, time.Second)
-		opts.CacheCapacity = 10
+		opts.CacheCapacity = 10 * swarm.ChunkWithSpanSize
 
 		lstore := makeDiskStorer(t, opts)
 		if lstore == nil {
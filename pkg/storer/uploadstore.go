@@ -9,6 +9,7 @@ import (
 	"errors"
 	"fmt"
 	"sort"
+	"time"
 
 	storage "github.com/ethersphere/bee/v2/pkg/storage"
 	"github.com/ethersphere/bee/v2/pkg/storer/internal"
@@ -28,7 +29,7 @@ func (db *DB) Report(ctx context.Context, chunk swarm.Chunk, state storage.Chunk
 	defer unlock()
 
 	err := db.storage.Run(ctx, func(s transaction.Store) error {
-		return upload.Report(ctx, s, chunk, state)
+		return upload.Report(ctx, s, db.uploadSize, chunk, state)
 	})
 	if err != nil {
 		return fmt.Errorf("reporter.Report: %w", err)
@@ -45,21 +46,23 @@ func (db *DB) Upload(ctx context.Context, pin bool, tagID uint64) (PutterSession
 
 	var (
 		uploadPutter  internal.PutterCloserWithReference
-		pinningPutter internal.PutterCloserWithReference
+		pinningPutter pinstore.CollectionPutter
+		reservation   *pinReservation
 		err           error
 	)
 
 	err = db.storage.Run(ctx, func(s transaction.Store) error {
-		uploadPutter, err = upload.NewPutter(s.IndexStore(), tagID)
+		uploadPutter, err = upload.NewPutter(s.IndexStore(), tagID, db.uploadSize)
 		if err != nil {
 			return fmt.Errorf("upload.NewPutter: %w", err)
 		}
 
 		if pin {
-			pinningPutter, err = pinstore.NewCollection(s.IndexStore())
+			pinningPutter, err = pinstore.NewCollection(s.IndexStore(), "", time.Time{})
 			if err != nil {
 				return fmt.Errorf("pinstore.NewCollection: %w", err)
 			}
+			reservation = &pinReservation{quota: &db.pinQuota, putter: pinningPutter}
 		}
 		return nil
 	})
@@ -73,19 +76,21 @@ func (db *DB) Upload(ctx context.Context, pin bool, tagID uint64) (PutterSession
 			storage.PutterFunc(func(ctx context.Context, chunk swarm.Chunk) error {
 				unlock := db.Lock(uploadsLock)
 				defer unlock()
-				return errors.Join(
-					db.storage.Run(ctx, func(s transaction.Store) error {
-						return uploadPutter.Put(ctx, s, chunk)
-					}),
-					func() error {
-						if pinningPutter != nil {
-							return db.storage.Run(ctx, func(s transaction.Store) error {
+				if pinningPutter != nil {
+					return reservation.put(chunk, func() error {
+						return errors.Join(
+							db.storage.Run(ctx, func(s transaction.Store) error {
+								return uploadPutter.Put(ctx, s, chunk)
+							}),
+							db.storage.Run(ctx, func(s transaction.Store) error {
 								return pinningPutter.Put(ctx, s, chunk)
-							})
-						}
-						return nil
-					}(),
-				)
+							}),
+						)
+					})
+				}
+				return db.storage.Run(ctx, func(s transaction.Store) error {
+					return uploadPutter.Put(ctx, s, chunk)
+				})
 			}),
 			db.metrics,
 			"uploadstore",
@@ -101,13 +106,17 @@ func (db *DB) Upload(ctx context.Context, pin bool, tagID uint64) (PutterSession
 				}),
 				func() error {
 					if pinningPutter != nil {
-						return db.storage.Run(ctx, func(s transaction.Store) error {
-							pinErr := pinningPutter.Close(s.IndexStore(), address)
-							if errors.Is(pinErr, pinstore.ErrDuplicatePinCollection) {
-								pinErr = pinningPutter.Cleanup(db.storage)
-							}
-							return pinErr
+						pinErr := db.storage.Run(ctx, func(s transaction.Store) error {
+							return pinningPutter.Close(s.IndexStore(), address)
 						})
+						switch {
+						case errors.Is(pinErr, pinstore.ErrDuplicatePinCollection):
+							defer reservation.cancel()
+							pinErr = pinningPutter.Cleanup(db.storage)
+						case pinErr == nil:
+							reservation.commit()
+						}
+						return pinErr
 					}
 					return nil
 				}(),
@@ -121,6 +130,7 @@ func (db *DB) Upload(ctx context.Context, pin bool, tagID uint64) (PutterSession
 				uploadPutter.Cleanup(db.storage),
 				func() error {
 					if pinningPutter != nil {
+						defer reservation.cancel()
 						return pinningPutter.Cleanup(db.storage)
 					}
 					return nil